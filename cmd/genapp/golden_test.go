@@ -0,0 +1,194 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Golden Files of the Generated Output
+
+// Notes:
+//	1.	Each directory in testdata/golden has the exec json file and
+//		the json files of a fixture. Its output is generated and compared
+//		with the golden files in its "out" directory so that changes to
+//		the models show up as diffs in review.
+//	2.	go test -run TestGolden -update regenerates the golden files.
+//	3.	The generation time is fixed so that the output does not change
+//		between runs.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"genapp/pkg/genCmn"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the generated output")
+
+const goldenTime = "Mon Jan  1, 2001 00:00"
+
+// readTree returns the files within the directory by their slash separated
+// relative paths skipping the genapp manifest.
+func readTree(t *testing.T, dir string) map[string]string {
+
+	files := map[string]string{}
+	err := filepath.Walk(dir,
+		func(fn string, fi os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if fi.IsDir() {
+				if fi.Name() == ".genapp" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			data, err := ioutil.ReadFile(fn)
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(dir, fn)
+			files[filepath.ToSlash(rel)] = string(data)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Error: Reading %s: %s\n", dir, err)
+	}
+
+	return files
+}
+
+// writeTree replaces the directory with the files.
+func writeTree(t *testing.T, dir string, files map[string]string) {
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("Error: Removing %s: %s\n", dir, err)
+	}
+	for n, data := range files {
+		fn := filepath.Join(dir, filepath.FromSlash(n))
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatalf("Error: Creating %s: %s\n", filepath.Dir(fn), err)
+		}
+		if err := ioutil.WriteFile(fn, []byte(data), 0644); err != nil {
+			t.Fatalf("Error: Writing %s: %s\n", fn, err)
+		}
+	}
+}
+
+// unusedModels returns the models of the generator which were not used to
+// generate the output according to its manifest.
+func unusedModels(t *testing.T, gen, outDir string) []string {
+	var manifest genCmn.Manifest
+	var list []string
+
+	data, err := ioutil.ReadFile(filepath.Join(outDir, filepath.FromSlash(genCmn.ManifestPath)))
+	if err == nil {
+		err = json.Unmarshal(data, &manifest)
+	}
+	if err != nil {
+		t.Fatalf("Error: Reading the manifest of %s: %s\n", outDir, err)
+	}
+	mdls, err := genCmn.ListModels()
+	if err != nil {
+		t.Fatalf("Error: Listing the models: %s\n", err)
+	}
+	for _, m := range mdls {
+		if !strings.HasPrefix(m.Model, gen+"/") {
+			continue
+		}
+		used := false
+		for _, f := range manifest.Files {
+			if m.Model == f.Model || strings.HasPrefix(m.Model, f.Model+"/") {
+				used = true
+				break
+			}
+		}
+		if !used {
+			list = append(list, m.Model)
+		}
+	}
+
+	return list
+}
+
+//----------------------------------------------------------------------------
+//								TestGolden
+//----------------------------------------------------------------------------
+
+func TestGolden(t *testing.T) {
+
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("Skipping the golden files since go is not available")
+	}
+	t.Log("main::TestGolden()")
+
+	tmp, err := ioutil.TempDir("", "genapp")
+	if err != nil {
+		t.Fatalf("Error: TempDir: %s\n", err)
+	}
+	defer os.RemoveAll(tmp)
+	genapp := buildGenapp(t, tmp)
+
+	execs, _ := filepath.Glob("testdata/golden/*/exec.json.txt")
+	if len(execs) == 0 {
+		t.Fatalf("Error: There are no testdata/golden/*/exec.json.txt files!\n")
+	}
+	for _, execPath := range execs {
+		dir := filepath.Dir(execPath)
+		name := filepath.Base(dir)
+		t.Run(name, func(t *testing.T) {
+			var names []string
+
+			os.Mkdir(filepath.Join(tmp, name), 0755)
+			outDir := filepath.Join(tmp, name, "app")
+			generateApp(t, genapp, execPath, outDir, "-d", "Time="+goldenTime)
+			if list := unusedModels(t, name, outDir); len(list) > 0 {
+				t.Logf("Models without golden files: %s\n", strings.Join(list, ", "))
+			}
+
+			got := readTree(t, outDir)
+			golden := filepath.Join(dir, "out")
+			if *update {
+				writeTree(t, golden, got)
+				return
+			}
+
+			want := readTree(t, golden)
+			for n := range got {
+				names = append(names, n)
+			}
+			for n := range want {
+				if _, ok := got[n]; !ok {
+					names = append(names, n)
+				}
+			}
+			sort.Strings(names)
+			for _, n := range names {
+				w, inWant := want[n]
+				g, inGot := got[n]
+				switch {
+				case !inWant:
+					t.Errorf("%s is generated, but has no golden file\n", n)
+				case !inGot:
+					t.Errorf("%s has a golden file, but is no longer generated\n", n)
+				case w != g:
+					t.Errorf("%s differs from its golden file:\n%s", n,
+						genCmn.UnifiedDiff("golden/"+n, "generated/"+n, w, g))
+				}
+			}
+			if t.Failed() {
+				t.Logf("If the changes are expected, run: go test ./cmd/genapp -run TestGolden -update\n")
+			}
+		})
+	}
+
+	t.Log("...End of main::TestGolden")
+}
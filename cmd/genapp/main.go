@@ -12,9 +12,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"genapp/pkg/genCObj"
+	"genapp/pkg/genCmn"
 	"genapp/pkg/genSqlAppGo"
 	"genapp/pkg/sharedData"
 
@@ -22,9 +24,14 @@ import (
 )
 
 var (
+	dataPath      	string
 	debug         	bool
+	diffJson      	string			// Path of the json changes of -noop.
+	dsn           	string
 	execPath      	string
 	force         	bool
+	fromPath      	string
+	genAuth       	string			// Generated authentication mode.
 	genDebugging  	bool
 	genHttps    	bool			// Generate HTTPS support.
 	genLogging    	bool
@@ -36,6 +43,8 @@ var (
 	outdir        	string
 	quiet         	bool
 	replace       	bool
+	sqlType       	string
+	toPath        	string
 )
 
 var defns map[string]interface{}
@@ -53,21 +62,53 @@ func (t *defineFlags) Set(value string) error {
 
 var defnFlags defineFlags
 
-func ChkSetMdlDir(s string) {
-	var path *util.Path
+// ChkSetMdlDir sets the project directory whose models override the user's
+// and the ones embedded in genapp. If none is given, ./models is used if it
+// exists.
+func ChkSetMdlDir(s string) error {
 
 	if len(s) == 0 {
 		s = "./models"
+		if !util.NewPath(s).IsPathDir() {
+			s = ""
+		}
+	} else if !util.NewPath(s).IsPathDir() {
+		return fmt.Errorf("Error: Model Directory, %s, does not exist!\n", s)
 	}
-	path = util.NewPath(s)
-	if !path.IsPathDir() {
-		path = util.NewPath(os.ExpandEnv("${GENAPP_MODELS}"))
-		if !path.IsPathDir() {
-			panic("Error - Missing Model Directory! Set with Env(GENAPP_MODELS) or -mdldir flag.")
+	mdldir = s
+	sharedData.SetMdlDir(s)
+
+	return nil
+}
+
+// ChkSetUserMdlDir sets the user's directory whose models override the ones
+// embedded in genapp. It is $GENAPP_MODELS or ~/.genapp/models if either
+// exists.
+func ChkSetUserMdlDir() {
+	var s string
+
+	s = os.ExpandEnv("${GENAPP_MODELS}")
+	if len(s) == 0 {
+		if home, err := os.UserHomeDir(); err == nil {
+			s = filepath.Join(home, ".genapp", "models")
 		}
 	}
-	mdldir = path.String()
-	sharedData.SetMdlDir(path.String())
+	if len(s) > 0 && !util.NewPath(s).IsPathDir() {
+		s = ""
+	}
+	sharedData.SetUserMdlDir(s)
+}
+
+// execValue returns the value of the first of the names given which is
+// in the Exec JSON File. Keys are lower case, but the spelling of the
+// CLI flag is also accepted.
+func execValue(m map[string]interface{}, names ...string) (interface{}, bool) {
+	for _, nm := range names {
+		if wrk, ok := m[nm]; ok {
+			return wrk, true
+		}
+	}
+	return nil, false
 }
 
 // SetupShared combines several sources of program options into
@@ -84,11 +125,23 @@ func SetupShared(execPath string, cmd string) error {
 	sharedData.SetCmd(cmd)
 	sharedData.SetDebug(debug)
 	sharedData.SetForce(force)
+	sharedData.SetDefn("GenAuth", genAuth)
 	sharedData.SetDefn("GenDebugging", genDebugging)
 	sharedData.SetDefn("GenLogging", genLogging)
 	sharedData.SetDefn("GenHttps", genHttps)
 	sharedData.SetDefn("GenMuxWrapper", genMuxWrapper)
-	ChkSetMdlDir(mdldir)
+	sharedData.SetDefn("From", fromPath)
+	sharedData.SetDefn("To", toPath)
+	sharedData.SetDefn("Dsn", dsn)
+	sharedData.SetDefn("SqlType", sqlType)
+	sharedData.SetDefn("DiffJson", diffJson)
+	if err = ChkSetMdlDir(mdldir); err != nil {
+		return err
+	}
+	ChkSetUserMdlDir()
+	if len(dataPath) > 0 {
+		sharedData.SetDataPath(dataPath)
+	}
 	sharedData.SetNoop(noop)
 	if len(outdir) > 0 {
 		sharedData.SetOutDir(outdir)
@@ -128,15 +181,29 @@ func SetupShared(execPath string, cmd string) error {
 		if wrk, ok = m["debug"]; ok {
 			sharedData.SetDebug(wrk.(bool))
 		}
+		if wrk, ok = execValue(m, "diffjson", "diffJson"); ok {
+			sharedData.SetDefn("DiffJson", wrk.(string))
+		}
+		if wrk, ok = m["dsn"]; ok {
+			sharedData.SetDefn("Dsn", wrk.(string))
+		}
 		if wrk, ok = m["force"]; ok {
 			sharedData.SetForce(wrk.(bool))
 		}
+		if wrk, ok = m["from"]; ok {
+			sharedData.SetDefn("From", wrk.(string))
+		}
+		if wrk, ok = execValue(m, "genauth", "genAuth"); ok {
+			sharedData.SetDefn("GenAuth", wrk.(string))
+		}
 		if wrk, ok = m["main"]; ok {
 			sharedData.SetMainPath(wrk.(string))
 		}
 		if wrk, ok = m["mdldir"]; ok {
 			wrkDir := wrk.(string)
-			ChkSetMdlDir(wrkDir)
+			if err = ChkSetMdlDir(wrkDir); err != nil {
+				return err
+			}
 		}
 		if wrk, ok = m["noop"]; ok {
 			sharedData.SetNoop(wrk.(bool))
@@ -150,6 +217,12 @@ func SetupShared(execPath string, cmd string) error {
 		if wrk, ok = m["replace"]; ok {
 			sharedData.SetReplace(wrk.(bool))
 		}
+		if wrk, ok = m["sqlType"]; ok {
+			sharedData.SetDefn("SqlType", wrk.(string))
+		}
+		if wrk, ok = m["to"]; ok {
+			sharedData.SetDefn("To", wrk.(string))
+		}
 		if wrk, ok = m["define"]; ok {
 			s := strings.Split(wrk.(string), ",")
 			for _, v := range s {
@@ -167,27 +240,72 @@ func SetupShared(execPath string, cmd string) error {
 	return nil
 }
 
+// Templates performs the templates sub-commands given by args:
+//	export <name>...	copies built-in models into the project model
+//						directory so that they can be customized
+//	list				lists the models and the layer each comes from
+func Templates(args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("Error: templates needs a sub-command of 'export' or 'list'!\n")
+	}
+	switch args[0] {
+	case "export":
+		if len(args) < 2 {
+			return fmt.Errorf("Error: templates export needs the name of a model!\n")
+		}
+		for _, name := range args[1:] {
+			out, err := genCmn.ExportModel(name, sharedData.Force())
+			if err != nil {
+				return err
+			}
+			if !sharedData.Quiet() {
+				log.Printf("\tExported %s to %s\n", name, out)
+			}
+		}
+	case "list":
+		list, err := genCmn.ListModels()
+		if err != nil {
+			return err
+		}
+		for _, m := range list {
+			fmt.Printf("%-8s %s\n", m.Layer, m.Model)
+		}
+	default:
+		return fmt.Errorf("Error: templates sub-command, %s, must be 'export' or 'list'!\n", args[0])
+	}
+
+	return nil
+}
+
 func main() {
 	var err error
 
 	flag.Usage = usage
+	flag.StringVar(&dataPath, "data", "", "set json data input path")
 	flag.BoolVar(&debug, "debug", true, "enable debugging")
+	flag.StringVar(&diffJson, "diffjson", "", "write the changes of -noop as json to the path (- is stdout)")
+	flag.StringVar(&dsn, "dsn", "", "set database path or connection (introspect only)")
 	flag.StringVar(&execPath, "exec", "", "exec json path (optional)")
 	flag.StringVar(&execPath, "x", "", "exec json path (optional)")
-	flag.BoolVar(&force, "force", false, "enable over-writes and deletions")
-	flag.BoolVar(&force, "f", false, "enable over-writes and deletions")
+	flag.BoolVar(&force, "force", false, "enable over-writes and deletions including files edited by hand")
+	flag.BoolVar(&force, "f", false, "enable over-writes and deletions including files edited by hand")
+	flag.StringVar(&fromPath, "from", "", "set json data path of the prior version (migrate only)")
+	flag.StringVar(&genAuth, "genAuth", "none", "generate authentication (none, basic, session or apikey)")
 	flag.BoolVar(&genDebugging, "genDebugging", true, "generate debugging output")
 	flag.BoolVar(&genHttps, "genHttps", true, "generate HTTPS support")
 	flag.BoolVar(&genLogging, "genLogging", true, "generate logging")
 	flag.BoolVar(&genMuxWrapper, "genMuxWrapper", true, "generate a wrapper around the mux")
 	flag.StringVar(&mainPath, "main", "", "set json main input path")
 	flag.StringVar(&jsonPath, "json", "", "set json main input path")
-	flag.StringVar(&mdldir, "mdldir", "./models", "set model input directory")
+	flag.StringVar(&mdldir, "mdldir", "", "set directory of project models overriding the user's and embedded ones\n(default ./models if it exists)")
 	flag.BoolVar(&noop, "noop", false, "execute program, but do not make real changes")
 	flag.StringVar(&outdir, "outdir", "/tmp", "set output directory")
 	flag.BoolVar(&quiet, "quiet", false, "enable quiet mode")
 	flag.BoolVar(&replace, "replace", true, "overwrite existing files")
 	flag.BoolVar(&quiet, "q", false, "enable quiet mode")
+	flag.StringVar(&sqlType, "sqlType", "sqlite", "set database type (introspect only)")
+	flag.StringVar(&toPath, "to", "", "set json data path of the new version (migrate only, default -data)")
 	flag.Var(&defnFlags, "define", "enter definitions (<name>=<string>)")
 	flag.Var(&defnFlags, "d", "enter definitions (<name>=<string>)")
 	flag.Parse()
@@ -205,15 +323,27 @@ func main() {
 		log.Println("\tcmd: '", sharedData.Cmd(), "'")
 	}
 	switch sharedData.Cmd() {
+	case "diff":
+		sharedData.SetNoop(true)
+		err = genSqlAppGo.Generate(defns)
 	case "cobj":
 		err = genCObj.Generate(defns)
+	case "introspect":
+		err = genSqlAppGo.Introspect(defns)
+	case "migrate":
+		err = genSqlAppGo.Migrate(defns)
 	case "sqlappgo":
 		err = genSqlAppGo.Generate(defns)
+	case "templates":
+		err = Templates(flag.Args()[1:])
+	case "validate":
+		err = genSqlAppGo.Validate(defns)
 	default:
-		fmt.Println("\nError: command must be 'cobj' or 'sqlapp'")
+		err = fmt.Errorf("Error: command must be 'cobj', 'diff', 'introspect', 'migrate', 'sqlappgo', 'templates' or 'validate'")
 	}
 	if err != nil {
-		log.Println("Error: generation failed:", err)
+		log.Println(sharedData.Cmd(), "failed:", err)
+		os.Exit(1)
 	}
 
 	if !quiet {
@@ -223,13 +353,50 @@ func main() {
 
 func usage() {
 	fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
-	fmt.Fprintf(flag.CommandLine.Output(), "Usage:\n\tgen [options] (cobj | sqlapp)\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage:\n\tgen [options] (cobj | diff | introspect | migrate | sqlappgo | validate)\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "\tgen [options] templates (export <name>... | list)\n")
 	fmt.Fprintf(flag.CommandLine.Output(), "\nOptions:\n")
 	flag.PrintDefaults()
 	fmt.Fprintf(flag.CommandLine.Output(), "\nNotes:\n")
 	fmt.Fprintf(flag.CommandLine.Output(), "'exec json' is a file that defines the command line parameters \n")
 	fmt.Fprintf(flag.CommandLine.Output(), "so that you can set them and then execute gen with -x or -exec\n")
 	fmt.Fprintf(flag.CommandLine.Output(), "option.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "'diff' is 'sqlappgo' with -noop. Nothing is written. Instead, a unified\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "diff of each file against what is on disk is given followed by a summary\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "of the files created, modified, unchanged, kept and deleted. -diffjson\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "also writes them as json.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "'validate' checks the data json file given by -data or the exec json\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "for problems and exits with a non-zero status if any errors are found.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "'introspect' reads the tables of the existing database given by -dsn\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "(only -sqlType sqlite is supported) and writes them as a data json file\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "to -data or to db.json.txt in -outdir.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "'migrate' compares the data json file given by -from with the one given\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "by -to (or -data) and writes numbered up and down sql migration files\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "to the migrations directory of -outdir. A table or field is renamed\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "by giving its prior name as OldName in the new data json file.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "'-genAuth' selects the authentication of the generated server. 'basic'\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "and 'session' check users in a users table (add one with the app's -user\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "name:password flag) and 'apikey' checks the X-API-Key header against\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "the app's -apiKeys flag.\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "The Perms of a table in the data json file give the operations that\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "each role may perform on it such as {\"clerk\": [\"list\", \"show\"]}.\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "A user's role is set with the app's -role flag and an API key's by\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "giving it as key:role.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "Fields may give the validation rules Required, Min, Max, Pattern and\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "Enum which are checked by the browser and by the generated server.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "The models are built into gen. They are overridden first by the project\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "models in '-mdldir' (default ./models) and then by the user's models in\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "$GENAPP_MODELS (default ~/.genapp/models). These only need to hold the\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "models to be overridden using their paths within models such as\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "sqlapp/form.html.tmpl.txt. 'templates export <name>' copies a built-in\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "model into the project models to be customized (-force replaces it) and\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "'templates list' shows the layer that each model comes from.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "Code between '<<user:name>>' and '<</user>>' marker lines in a generated\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "file is kept when it is regenerated. A block that is no longer in the\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "model is an error unless -force is given.\n\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "The manifest, .genapp/manifest.json in -outdir, records the files\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "generated. Later runs skip unchanged files, keep files edited by hand\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "unless -force is given and delete files that are no longer generated.\n\n")
 	fmt.Fprintf(flag.CommandLine.Output(), "'json path' is the json file that defines the data passed to the\n")
 	fmt.Fprintf(flag.CommandLine.Output(), "template engine which controls data within the generated files.\n")
 	fmt.Fprintf(flag.CommandLine.Output(), "'{{' and '}}' are not used in the basic templates.  Instead, '[['\n")
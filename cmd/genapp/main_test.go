@@ -7,6 +7,9 @@ package main
 
 import (
 	"genapp/pkg/sharedData"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -38,3 +41,34 @@ func TestSetupShared(t *testing.T) {
 	}
 
 }
+
+func TestSetupSharedKeys(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "genapp")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The keys are lower case, but the spelling of the flags is accepted.
+	tests := []string{
+		`{"cmd":"sqlappgo", "diffjson":"diff.json", "genauth":"basic"}`,
+		`{"cmd":"sqlappgo", "diffJson":"diff.json", "genAuth":"basic"}`,
+	}
+	for _, tst := range tests {
+		fn := filepath.Join(dir, "exec.json")
+		if err = ioutil.WriteFile(fn, []byte(tst), 0644); err != nil {
+			t.Fatalf("WriteFile() failed: %s\n", err)
+		}
+		if err = SetupShared(fn, "cmd"); err != nil {
+			t.Fatalf("SetupShared() failed: %s\n", err)
+		}
+		if d := sharedData.Defn("DiffJson"); d != "diff.json" {
+			t.Errorf("ERROR - %s: DiffJson should be 'diff.json', but is %v\n", tst, d)
+		}
+		if d := sharedData.Defn("GenAuth"); d != "basic" {
+			t.Errorf("ERROR - %s: GenAuth should be 'basic', but is %v\n", tst, d)
+		}
+	}
+
+}
@@ -0,0 +1,240 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+
+//****************************************************************
+//          ANOBJ  (anObj) Header
+//****************************************************************
+/*
+ * Program
+ *			Separate anObj (anObj)
+ * Purpose
+ *			This object provides a standardized way of handling
+ *          a separate anObj to run things without complications
+ *          of interfering with the main anObj. A anObj may be
+ *          called a anObj on other O/S's.
+ *
+ * Remarks
+ *	1.      None
+ *
+ * History
+ *  Generated Mon Jan  1, 2001 00:00
+ */
+
+
+
+/*
+ This is free and unencumbered software released into the public domain.
+ 
+ Anyone is free to copy, modify, publish, use, compile, sell, or
+ distribute this software, either in source code form or as a compiled
+ binary, for any purpose, commercial or non-commercial, and by any
+ means.
+ 
+ In jurisdictions that recognize copyright laws, the author or authors
+ of this software dedicate any and all copyright interest in the
+ software to the public domain. We make this dedication for the benefit
+ of the public at large and to the detriment of our heirs and
+ successors. We intend this dedication to be an overt act of
+ relinquishment in perpetuity of all present and future rights to this
+ software under copyright law.
+ 
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+ EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+ MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+ IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+ 
+ For more information, please refer to <http://unlicense.org/>
+ */
+
+
+
+
+
+#include        <cmn_defs.h>
+#include        <AStr.h>
+
+#include        <xyz.h>
+
+
+
+#ifndef         ANOBJ_H
+#define         ANOBJ_H
+
+
+//#define   ANOBJ_SINGLETON    1
+
+
+
+
+
+#ifdef	__cplusplus
+extern "C" {
+#endif
+    
+
+    //****************************************************************
+    //* * * * * * * * * * * *  Data Definitions  * * * * * * * * * * *
+    //****************************************************************
+
+
+
+    // anObj inherits from xyz
+
+    typedef struct anObj_data_s	ANOBJ_DATA;
+    typedef struct anObj_class_data_s ANOBJ_CLASS_DATA;
+
+    typedef struct anObj_vtbl_s	{
+        OBJ_IUNKNOWN    iVtbl;              // Inherited Vtbl.
+        // Put other methods below this as pointers and add their
+        // method names to the vtbl definition in $P_object.c.
+        // Properties:
+        // Methods:
+        //bool        (*pIsEnabled)(ANOBJ_DATA *);
+    } $Q_VTBL;
+
+    typedef struct anObj_class_vtbl_s	{
+        OBJ_IUNKNOWN    iVtbl;              // Inherited Vtbl.
+        // Put other methods below this as pointers and add their
+        // method names to the vtbl definition in $P_object.c.
+        // Properties:
+        // Methods:
+        //bool        (*pIsEnabled)(ANOBJ_DATA *);
+    } $Q_CLASS_VTBL;
+
+
+
+
+    /****************************************************************
+    * * * * * * * * * * *  Routine Definitions	* * * * * * * * * * *
+    ****************************************************************/
+
+
+    //---------------------------------------------------------------
+    //                      *** Class Methods ***
+    //---------------------------------------------------------------
+
+#ifdef  ANOBJ_SINGLETON
+    ANOBJ_DATA *     anObj_Shared (
+        void
+    );
+
+    bool            anObj_SharedReset (
+        void
+    );
+#endif
+
+
+   /*!
+     Allocate a new Object and partially initialize. Also, this sets an
+     indicator that the object was alloc'd which is tested when the object is
+     released.
+     @return    pointer to $P object if successful, otherwise OBJ_NIL.
+     */
+    ANOBJ_DATA *     anObj_Alloc (
+        void
+    );
+    
+    
+    OBJ_ID          anObj_Class (
+        void
+    );
+    
+    
+    ANOBJ_DATA *     anObj_New (
+        void
+    );
+    
+    
+
+    //---------------------------------------------------------------
+    //                      *** Properties ***
+    //---------------------------------------------------------------
+
+    
+        //abc - important data
+	uint32_t				anObj_getAbc(
+		ANOBJ_DATA	*this
+	);
+
+	bool				anObj_setAbc(
+		ANOBJ_DATA	*this,
+		uint32_t		value,
+	);
+
+
+
+        //ghi - another important object
+	NODE_DATA *				anObj_getGhi(
+		ANOBJ_DATA	*this
+	);
+
+
+
+        //jkl - another object
+	OBJ_ID				anObj_getJkl(
+		ANOBJ_DATA	*this
+	);
+
+	bool				anObj_setJkl(
+		ANOBJ_DATA	*this,
+		OBJ_ID		pValue,
+	);
+
+
+
+
+
+
+    
+    //---------------------------------------------------------------
+    //                      *** Methods ***
+    //---------------------------------------------------------------
+
+    ERESULT     anObj_Disable (
+        ANOBJ_DATA		*this
+    );
+
+
+    ERESULT     anObj_Enable (
+        ANOBJ_DATA		*this
+    );
+
+   
+    ANOBJ_DATA *   anObj_Init (
+        ANOBJ_DATA     *this
+    );
+
+
+    ERESULT     anObj_IsEnabled (
+        ANOBJ_DATA		*this
+    );
+    
+ 
+    /*!
+     Create a string that describes this object and the objects within it.
+     Example:
+     @code 
+        ASTR_DATA      *pDesc = anObj_ToDebugString(this,4);
+     @endcode 
+     @param     this    object pointer
+     @param     indent  number of characters to indent every line of output, can be 0
+     @return    If successful, an AStr object which must be released containing the
+                description, otherwise OBJ_NIL.
+     @warning   Remember to release the returned AStr object.
+     */
+    ASTR_DATA *    anObj_ToDebugString (
+        ANOBJ_DATA     *this,
+        int             indent
+    );
+    
+    
+
+    
+#ifdef	__cplusplus
+}
+#endif
+
+#endif	/* ANOBJ_H */
+
@@ -0,0 +1,179 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+/* 
+ * File:   anObj_internal.h
+ *	Generated Mon Jan  1, 2001 00:00
+ *
+ * Notes:
+ *  --	N/A
+ *
+ */
+
+
+/*
+ This is free and unencumbered software released into the public domain.
+ 
+ Anyone is free to copy, modify, publish, use, compile, sell, or
+ distribute this software, either in source code form or as a compiled
+ binary, for any purpose, commercial or non-commercial, and by any
+ means.
+ 
+ In jurisdictions that recognize copyright laws, the author or authors
+ of this software dedicate any and all copyright interest in the
+ software to the public domain. We make this dedication for the benefit
+ of the public at large and to the detriment of our heirs and
+ successors. We intend this dedication to be an overt act of
+ relinquishment in perpetuity of all present and future rights to this
+ software under copyright law.
+ 
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+ EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+ MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+ IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+ 
+ For more information, please refer to <http://unlicense.org/>
+ */
+
+
+
+
+#include        <anObj.h>
+#include        <jsonIn.h>
+
+#include        <xyz_internal.h>
+
+
+
+#ifndef ANOBJ_INTERNAL_H
+#define	ANOBJ_INTERNAL_H
+
+
+
+#define     PROPERTY_STR_OWNED 1
+
+
+
+#ifdef	__cplusplus
+extern "C" {
+#endif
+
+
+
+
+    //---------------------------------------------------------------
+    //                  Object Data Description
+    //---------------------------------------------------------------
+
+#pragma pack(push, 1)
+struct anObj_data_s	{
+
+    /* Warning - XYZ_DATA must be first in this object!
+     */
+    XYZ_DATA        super;
+
+    OBJ_IUNKNOWN    *pSuperVtbl;    // Needed for Inheritance
+
+    // Common Data
+    uint16_t        size;		    // maximum number of elements
+    uint16_t        rsvd16;
+    ASTR_DATA       *pStr;
+    	uint32_t		def;
+	NODE_DATA		*pGhi;
+	OBJ_ID		pJkl;
+
+
+};
+#pragma pack(pop)
+
+    extern
+    struct anObj_class_data_s  anObj_ClassObj;
+
+    extern
+    const
+    ANOBJ_VTBL         anObj_Vtbl;
+
+    extern
+    const
+    uint32_t        anObj_cProps;
+    extern
+    const
+    OBJ_PROP        anObj_pProps[];
+
+
+    //---------------------------------------------------------------
+    //              Class Object Method Forward Definitions
+    //---------------------------------------------------------------
+
+#ifdef  ANOBJ_SINGLETON
+    ANOBJ_DATA *     anObj_getSingleton (
+        void
+    );
+
+    bool            anObj_setSingleton (
+     ANOBJ_DATA       *pValue
+);
+#endif
+
+
+
+    //---------------------------------------------------------------
+    //              Internal Method Forward Definitions
+    //---------------------------------------------------------------
+
+    OBJ_IUNKNOWN *  anObj_getSuperVtbl (
+        ANOBJ_DATA     *this
+    );
+
+    
+        
+        	//ghi - another important object
+	bool				anObj_setGhi(
+		ANOBJ_DATA	*this,
+		NODE_DATA		value,
+	);
+
+
+
+        
+
+    void            anObj_Dealloc (
+        OBJ_ID          objId
+    );
+
+
+    ANOBJ_DATA *       anObj_ParseObject (
+        JSONIN_DATA     *pParser
+    );
+
+
+    void *          anObj_QueryInfo (
+        OBJ_ID          objId,
+        uint32_t        type,
+        void            *pData
+    );
+
+
+    ASTR_DATA *     anObj_ToJSON (
+        ANOBJ_DATA      *this
+    );
+
+
+
+
+#ifdef NDEBUG
+#else
+    bool			anObj_Validate (
+        ANOBJ_DATA       *this
+    );
+#endif
+
+
+
+#ifdef	__cplusplus
+}
+#endif
+
+#endif	/* ANOBJ_INTERNAL_H */
+
@@ -0,0 +1,427 @@
+// vi: nu:noai:ts=4:sw=4
+
+//	Class Object Metods and Tables for 'anObj'
+//	Generated Mon Jan  1, 2001 00:00
+
+
+
+/*
+ This is free and unencumbered software released into the public domain.
+ 
+ Anyone is free to copy, modify, publish, use, compile, sell, or
+ distribute this software, either in source code form or as a compiled
+ binary, for any purpose, commercial or non-commercial, and by any
+ means.
+ 
+ In jurisdictions that recognize copyright laws, the author or authors
+ of this software dedicate any and all copyright interest in the
+ software to the public domain. We make this dedication for the benefit
+ of the public at large and to the detriment of our heirs and
+ successors. We intend this dedication to be an overt act of
+ relinquishment in perpetuity of all present and future rights to this
+ software under copyright law.
+ 
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+ EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+ MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+ IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+ 
+ For more information, please refer to <http://unlicense.org/>
+ */
+
+
+
+
+#define			ANOBJ_OBJECT_C	    1
+#include        <anObj_internal.h>
+#ifdef  ANOBJ_SINGLETON
+#include        <psxLock.h>
+#endif
+
+
+
+//===========================================================
+//                  Class Object Definition
+//===========================================================
+
+struct anObj_class_data_s	{
+    // Warning - OBJ_DATA must be first in this object!
+    OBJ_DATA        super;
+    
+    // Common Data
+#ifdef  ANOBJ_SINGLETON
+    volatile
+    ANOBJ_DATA       *pSingleton;
+#endif
+    //uint32_t        misc;
+    //OBJ_ID          pObjCatalog;
+};
+
+
+
+
+//-----------------------------------------------------------
+//                  Class Methods
+//-----------------------------------------------------------
+
+
+
+static
+void *          anObjClass_QueryInfo (
+    OBJ_ID          objId,
+    uint32_t        type,
+    void            *pData
+);
+
+
+static
+const
+OBJ_INFO        anObj_Info;            // Forward Reference
+
+
+
+
+static
+bool            anObjClass_IsKindOf (
+    uint16_t		classID
+)
+{
+    if (OBJ_IDENT_ANOBJ_CLASS == classID) {
+       return true;
+    }
+    if (OBJ_IDENT_OBJ_CLASS == classID) {
+       return true;
+    }
+    return false;
+}
+
+
+static
+uint16_t		anObjClass_WhoAmI (
+    void
+)
+{
+    return OBJ_IDENT_ANOBJ_CLASS;
+}
+
+
+
+
+//===========================================================
+//                 Class Object Vtbl Definition
+//===========================================================
+
+static
+const
+ANOBJ_CLASS_VTBL    class_Vtbl = {
+    {
+        &anObj_Info,
+        anObjClass_IsKindOf,
+        obj_RetainNull,
+        obj_ReleaseNull,
+        NULL,
+        anObj_Class,
+        anObjClass_WhoAmI,
+        (P_OBJ_QUERYINFO)anObjClass_QueryInfo,
+        NULL                        // anObjClass_ToDebugString
+    },
+};
+
+
+
+//-----------------------------------------------------------
+//						Class Object
+//-----------------------------------------------------------
+
+ANOBJ_CLASS_DATA  anObj_ClassObj = {
+    {
+        (const OBJ_IUNKNOWN *)&class_Vtbl,      // pVtbl
+        sizeof(ANOBJ_CLASS_DATA),                  // cbSize
+        0,                                      // cbFlags
+        1,                                      // cbRetainCount
+        {0}                                     // cbMisc
+    },
+	//0
+};
+
+
+
+//---------------------------------------------------------------
+//          S i n g l e t o n  M e t h o d s
+//---------------------------------------------------------------
+
+#ifdef  ANOBJ_SINGLETON
+ANOBJ_DATA *     anObj_getSingleton (
+    void
+)
+{
+    return (OBJ_ID)(anObj_ClassObj.pSingleton);
+}
+
+
+bool            anObj_setSingleton (
+    ANOBJ_DATA       *pValue
+)
+{
+    PSXLOCK_DATA    *pLock = OBJ_NIL;
+    bool            fRc;
+    
+    pLock = psxLock_New( );
+    if (OBJ_NIL == pLock) {
+        DEBUG_BREAK();
+        return false;
+    }
+    fRc = psxLock_Lock(pLock);
+    if (!fRc) {
+        DEBUG_BREAK();
+        obj_Release(pLock);
+        pLock = OBJ_NIL;
+        return false;
+    }
+    
+    obj_Retain(pValue);
+    if (anObj_ClassObj.pSingleton) {
+        obj_Release((OBJ_ID)(anObj_ClassObj.pSingleton));
+    }
+    anObj_ClassObj.pSingleton = pValue;
+    
+    fRc = psxLock_Unlock(pLock);
+    obj_Release(pLock);
+    pLock = OBJ_NIL;
+    return true;
+}
+
+
+
+ANOBJ_DATA *     anObj_Shared (
+    void
+)
+{
+    ANOBJ_DATA       *this = (OBJ_ID)(anObj_ClassObj.pSingleton);
+    
+    if (NULL == this) {
+        this = anObj_New( );
+        anObj_setSingleton(this);
+        obj_Release(this);          // Shared controls object retention now.
+        // anObj_ClassObj.pSingleton = OBJ_NIL;
+    }
+    
+    return this;
+}
+
+
+
+void            anObj_SharedReset (
+    void
+)
+{
+    ANOBJ_DATA       *this = (OBJ_ID)(anObj_ClassObj.pSingleton);
+    
+    if (this) {
+        obj_Release(this);
+        anObj_ClassObj.pSingleton = OBJ_NIL;
+    }
+    
+}
+
+
+
+#endif
+
+
+
+//---------------------------------------------------------------
+//                     Q u e r y  I n f o
+//---------------------------------------------------------------
+
+static
+void *          anObjClass_QueryInfo (
+    OBJ_ID          objId,
+    uint32_t        type,
+    void            *pData
+)
+{
+    ANOBJ_CLASS_DATA *this = objId;
+    const
+    char            *pStr = pData;
+    
+    if (OBJ_NIL == this) {
+        return NULL;
+    }
+    
+    switch (type) {
+      
+        case OBJ_QUERYINFO_TYPE_OBJECT_SIZE:
+            return (void *)sizeof(ANOBJ_DATA);
+            break;
+            
+        case OBJ_QUERYINFO_TYPE_CLASS_OBJECT:
+            return this;
+            break;
+            
+        // Query for an address to specific data within the object.  
+        // This should be used very sparingly since it breaks the 
+        // object's encapsulation.                 
+        case OBJ_QUERYINFO_TYPE_DATA_PTR:
+            switch (*pStr) {
+ 
+                case 'C':
+                    if (str_Compare("ClassInfo", (char *)pStr) == 0) {
+                        return (void *)&anObj_Info;
+                    }
+                    break;
+                    
+                default:
+                    break;
+            }
+            break;
+            
+        case OBJ_QUERYINFO_TYPE_INFO:
+            return (void *)obj_getInfo(this);
+            break;
+            
+        case OBJ_QUERYINFO_TYPE_METHOD:
+            switch (*pStr) {
+                    
+                case 'N':
+                    if (str_Compare("New", (char *)pStr) == 0) {
+                        return anObj_New;
+                    }
+                    break;
+                    
+                 case 'W':
+                    if (str_Compare("WhoAmI", (char *)pStr) == 0) {
+                        return anObjClass_WhoAmI;
+                    }
+                    break;
+                    
+                default:
+                    break;
+            }
+            break;
+            
+        default:
+            break;
+    }
+    
+    return NULL;
+}
+
+
+
+
+static
+bool            anObj_IsKindOf (
+    uint16_t		classID
+)
+{
+    if (OBJ_IDENT_ANOBJ == classID) {
+       return true;
+    }
+    if (OBJ_IDENT_OBJ == classID) {
+       return true;
+    }
+    return false;
+}
+
+
+// Dealloc() should be put into the Internal Header as well
+// for classes that get inherited from.
+void            anObj_Dealloc (
+    OBJ_ID          objId
+);
+
+
+OBJ_ID          anObj_Class (
+    void
+)
+{
+    return (OBJ_ID)&anObj_ClassObj;
+}
+
+
+static
+uint16_t		anObj_WhoAmI (
+    void
+)
+{
+    return OBJ_IDENT_ANOBJ;
+}
+
+
+
+
+
+//===========================================================
+//                  Object Vtbl Definition
+//===========================================================
+
+const
+ANOBJ_VTBL     anObj_Vtbl = {
+    {
+        &anObj_Info,
+        anObj_IsKindOf,
+#ifdef  ANOBJ_IS_SINGLETON
+        obj_RetainNull,
+        obj_ReleaseNull,
+#else
+        obj_RetainStandard,
+        obj_ReleaseStandard,
+#endif
+        anObj_Dealloc,
+        anObj_Class,
+        anObj_WhoAmI,
+        (P_OBJ_QUERYINFO)anObj_QueryInfo,
+        (P_OBJ_TOSTRING)anObj_ToDebugString,
+        NULL,			// anObj_Enable,
+        NULL,			// anObj_Disable,
+        NULL,			// (P_OBJ_ASSIGN)anObj_Assign,
+        NULL,			// (P_OBJ_COMPARE)anObj_Compare,
+        NULL, 			// (P_OBJ_PTR)anObj_Copy,
+        NULL, 			// (P_OBJ_PTR)anObj_DeepCopy,
+        NULL 			// (P_OBJ_HASH)anObj_Hash,
+    },
+    // Put other object method names below this.
+    // Properties:
+    // Methods:
+    //anObj_IsEnabled,
+ 
+};
+
+
+
+
+const
+uint32_t        anObj_cProps = 3;
+const
+OBJ_PROP        anObj_pProps[] = {
+    	{ "abc","def","abc","important data","uint32_t","2000","public","ANOBJ_DATA",offsetof(ANOBJ_DATA,def),(sizeof(uint32_t) << 3),0	},
+	{ "ghi","pGhi","","another important object","NODE_DATA","OBJ_NIL","ro","ANOBJ_DATA",offsetof(ANOBJ_DATA,pGhi),(sizeof(NODE_DATA *) << 3),0	},
+	{ "jkl","pJkl","","another object","OBJ_ID","OBJ_NIL","public","ANOBJ_DATA",offsetof(ANOBJ_DATA,pJkl),(sizeof(OBJ_ID) << 3),0	},
+
+    {NULL}
+};
+
+
+
+static
+const
+OBJ_INFO        anObj_Info = {
+    "anObj",                               // Class Name
+    "anObj",	                            // Class Description
+    (OBJ_DATA *)&anObj_ClassObj,           // Our ClassObj
+
+    (OBJ_DATA *)&xyz_ClassObj,                    // Super's ClassObj
+
+    (OBJ_IUNKNOWN *)&anObj_Vtbl,
+    sizeof(ANOBJ_DATA)
+};
+#warning -- Fill in class description above
+
+
+
+
+
@@ -0,0 +1,25 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// ioApp01sq contains all the functions
+// and data to interact with the SQL Database.
+
+// Generated: Mon Jan  1, 2001 00:00 for sqlite Database
+
+package main
+
+import (
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//                              Docker Run - sqlite
+//----------------------------------------------------------------------------
+
+// DockerRun executes the dbs/sqlite/run.sh to create a fresh SQL Server.
+func DockerRun(t *testing.T) {
+
+	t.Logf("DockerRun()...\n")
+
+	t.Logf("DockerRun() - End\n\n\n")
+}
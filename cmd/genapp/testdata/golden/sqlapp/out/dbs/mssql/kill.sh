@@ -0,0 +1,5 @@
+#!/usr/bin/env bash
+
+docker container rm -f  mssql1
+
+
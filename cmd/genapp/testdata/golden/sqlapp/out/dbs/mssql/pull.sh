@@ -0,0 +1,4 @@
+#!/usr/bin/env bash
+
+docker image pull mcr.microsoft.com/mssql/server:2017-latest
+
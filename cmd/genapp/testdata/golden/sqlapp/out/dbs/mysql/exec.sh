@@ -0,0 +1,5 @@
+#!/usr/bin/env bash
+
+docker container exec -it  mysql1 bash
+
+
@@ -0,0 +1,5 @@
+#!/usr/bin/env bash
+
+docker container exec -it  postgres1 bash
+
+
@@ -0,0 +1,3 @@
+module app01sq
+
+go 1.12
@@ -0,0 +1,951 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+//  Struct and Methods for App01sqCustomer
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package App01sqCustomer
+
+import (
+	"database/sql"
+
+	"encoding/json"
+	"fmt"
+
+	"log"
+
+	"net/http"
+
+	"sort"
+	"strconv"
+	"strings"
+
+	"net/url"
+
+	"github.com/2kranki/go_util"
+	"github.com/shopspring/decimal"
+)
+
+//============================================================================
+//                             Database Interfaces
+//============================================================================
+
+type App01sqCustomerDbRowDeleter interface {
+	// RowDelete deletes the row with keys from the provided record, rcd.
+	RowDelete(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowFinder interface {
+	// RowFind searches the Database for a matching row for the keys found in
+	// the given record and returns the output in that same record.
+	RowFind(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowFirster interface {
+	// RowFirst returns the first row in the table, Customer.
+	// If there are no rows in the table, then a blank/null record is returned
+	// without error.
+	RowFirst(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowInserter interface {
+	RowInsert(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowLaster interface {
+	// RowLast returns the last row in the table, Customer.
+	// If there are no rows in the table, then a blank/null record is returned
+	// without error.
+	RowLast(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowNexter interface {
+	// RowNext returns the next row from the row given. If row after the current
+	// one does not exist, then the first row is returned.
+	RowNext(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowPager interface {
+	// RowPage returns a page of rows where a page size is the 'limit' parameter and
+	// 'offset' is the offset into the result set ordered by the main index. Both
+	// 'limit' and 'offset' are relative to 1. We return an address to the array
+	// rows (structs) so that we don't have the overhead of copying them everwhere.
+	RowPage(offset int, limit int) ([]App01sqCustomer, error)
+}
+
+type App01sqCustomerDbRowPrever interface {
+	RowPrev(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbRowUpdater interface {
+	RowUpdate(rcd *App01sqCustomer) error
+}
+
+type App01sqCustomerDbTableCounter interface {
+	TableCount() (int, error)
+}
+
+type App01sqCustomerDbTableCreater interface {
+	TableCreate() error
+}
+
+type App01sqCustomerDbTableDeleter interface {
+	TableDelete() error
+}
+
+type App01sqCustomerDbTableScanner interface {
+	// TableScan reads all the rows in the table applying a function to each of
+	// them.
+	TableScan(apply func(rcd App01sqCustomer) error) error
+}
+
+//============================================================================
+//                              Table Struct
+//============================================================================
+
+type App01sqCustomer struct {
+	Num     int64               `json:"num,omitempty"`
+	Name    sql.NullString      `json:"name"`
+	Addr1   sql.NullString      `json:"addr1"`
+	Addr2   sql.NullString      `json:"addr2"`
+	City    sql.NullString      `json:"city"`
+	State   sql.NullString      `json:"state"`
+	Zip     sql.NullString      `json:"zip"`
+	Country sql.NullString      `json:"country"`
+	Curbal  decimal.NullDecimal `json:"curbal"`
+}
+
+type App01sqCustomers []*App01sqCustomer
+
+type Key struct {
+	Num int64 `json:"num,omitempty"`
+}
+
+type App01sqCustomerIndex map[Key]*App01sqCustomer
+
+// NOTE: For JsonMarshal() and JsonUnmarshal() to work properly, the JSON
+//  names must be defined above.
+
+//----------------------------------------------------------------------------
+//                          JSON NULL Fields
+//----------------------------------------------------------------------------
+
+// MarshalJSON gives the NULL fields as null instead of as their sql.Null
+// structs. The fields of rcd are hidden by the ones of the same name.
+func (s App01sqCustomer) MarshalJSON() ([]byte, error) {
+	type rcd App01sqCustomer
+	j := struct {
+		rcd
+		Name    *string          `json:"name"`
+		Addr1   *string          `json:"addr1"`
+		Addr2   *string          `json:"addr2"`
+		City    *string          `json:"city"`
+		State   *string          `json:"state"`
+		Zip     *string          `json:"zip"`
+		Country *string          `json:"country"`
+		Curbal  *decimal.Decimal `json:"curbal"`
+	}{rcd: rcd(s)}
+
+	if s.Name.Valid {
+		j.Name = &s.Name.String
+	}
+	if s.Addr1.Valid {
+		j.Addr1 = &s.Addr1.String
+	}
+	if s.Addr2.Valid {
+		j.Addr2 = &s.Addr2.String
+	}
+	if s.City.Valid {
+		j.City = &s.City.String
+	}
+	if s.State.Valid {
+		j.State = &s.State.String
+	}
+	if s.Zip.Valid {
+		j.Zip = &s.Zip.String
+	}
+	if s.Country.Valid {
+		j.Country = &s.Country.String
+	}
+	if s.Curbal.Valid {
+		j.Curbal = &s.Curbal.Decimal
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON sets the NULL fields from null or their values.
+func (s *App01sqCustomer) UnmarshalJSON(text []byte) error {
+	type rcd App01sqCustomer
+	j := struct {
+		*rcd
+		Name    *string          `json:"name"`
+		Addr1   *string          `json:"addr1"`
+		Addr2   *string          `json:"addr2"`
+		City    *string          `json:"city"`
+		State   *string          `json:"state"`
+		Zip     *string          `json:"zip"`
+		Country *string          `json:"country"`
+		Curbal  *decimal.Decimal `json:"curbal"`
+	}{rcd: (*rcd)(s)}
+
+	if err := json.Unmarshal(text, &j); err != nil {
+		return err
+	}
+	s.Name = sql.NullString{}
+	if j.Name != nil {
+		s.Name = sql.NullString{String: *j.Name, Valid: true}
+	}
+	s.Addr1 = sql.NullString{}
+	if j.Addr1 != nil {
+		s.Addr1 = sql.NullString{String: *j.Addr1, Valid: true}
+	}
+	s.Addr2 = sql.NullString{}
+	if j.Addr2 != nil {
+		s.Addr2 = sql.NullString{String: *j.Addr2, Valid: true}
+	}
+	s.City = sql.NullString{}
+	if j.City != nil {
+		s.City = sql.NullString{String: *j.City, Valid: true}
+	}
+	s.State = sql.NullString{}
+	if j.State != nil {
+		s.State = sql.NullString{String: *j.State, Valid: true}
+	}
+	s.Zip = sql.NullString{}
+	if j.Zip != nil {
+		s.Zip = sql.NullString{String: *j.Zip, Valid: true}
+	}
+	s.Country = sql.NullString{}
+	if j.Country != nil {
+		s.Country = sql.NullString{String: *j.Country, Valid: true}
+	}
+	s.Curbal = decimal.NullDecimal{}
+	if j.Curbal != nil {
+		s.Curbal = decimal.NullDecimal{Decimal: *j.Curbal, Valid: true}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                              Compare
+//----------------------------------------------------------------------------
+
+// Compare compares our struct to another returning
+// 0, 1 for equal and not equal.
+func (s *App01sqCustomer) Compare(r *App01sqCustomer) int {
+	// Accumulate the key value(s) in KeyNum order.
+	if s.Num != r.Num {
+		return 1
+	}
+	if s.Name.Valid != r.Name.Valid || s.Name.Valid && s.Name.String != r.Name.String {
+		return 1
+	}
+	if s.Addr1.Valid != r.Addr1.Valid || s.Addr1.Valid && s.Addr1.String != r.Addr1.String {
+		return 1
+	}
+	if s.Addr2.Valid != r.Addr2.Valid || s.Addr2.Valid && s.Addr2.String != r.Addr2.String {
+		return 1
+	}
+	if s.City.Valid != r.City.Valid || s.City.Valid && s.City.String != r.City.String {
+		return 1
+	}
+	if s.State.Valid != r.State.Valid || s.State.Valid && s.State.String != r.State.String {
+		return 1
+	}
+	if s.Zip.Valid != r.Zip.Valid || s.Zip.Valid && s.Zip.String != r.Zip.String {
+		return 1
+	}
+	if s.Country.Valid != r.Country.Valid || s.Country.Valid && s.Country.String != r.Country.String {
+		return 1
+	}
+	if s.Curbal.Valid != r.Curbal.Valid || s.Curbal.Valid && !s.Curbal.Decimal.Equal(r.Curbal.Decimal) {
+		return 1
+	}
+	return 0
+}
+
+// CompareKeys compares our struct to another using keys returning the normal
+// -1, 0, 1 for less than, equal and greater than.
+func (s *App01sqCustomer) CompareKeys(r *App01sqCustomer) int {
+	// Accumulate the key value(s) in KeyNum order.
+	// Field: Num
+	if s.Num != r.Num {
+		if s.Num < r.Num {
+			return -1
+		} else {
+			return 1
+		}
+	}
+	return 0
+}
+
+//----------------------------------------------------------------------------
+//                             Empty
+//----------------------------------------------------------------------------
+
+// Empty resets the struct values to their null values.
+func (s *App01sqCustomer) Empty() {
+	s.Num = 0
+	s.Name = sql.NullString{}
+	s.Addr1 = sql.NullString{}
+	s.Addr2 = sql.NullString{}
+	s.City = sql.NullString{}
+	s.State = sql.NullString{}
+	s.Zip = sql.NullString{}
+	s.Country = sql.NullString{}
+	s.Curbal = decimal.NullDecimal{}
+
+}
+
+//----------------------------------------------------------------------------
+//                      Fields to URL Value String
+//----------------------------------------------------------------------------
+
+// FieldsToValue creates a URL Value map from the the table's field(s).
+func (s *App01sqCustomer) FieldsToValue() string {
+	var wrk string
+
+	v := url.Values{}
+	// Accumulate the value(s) from the fields.
+	// Field: Num
+	wrk = fmt.Sprintf("%d", s.Num)
+	v.Add("Num", wrk)
+	// Field: Name
+	if s.Name.Valid {
+		wrk = s.Name.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Name", wrk)
+	// Field: Addr1
+	if s.Addr1.Valid {
+		wrk = s.Addr1.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Addr1", wrk)
+	// Field: Addr2
+	if s.Addr2.Valid {
+		wrk = s.Addr2.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Addr2", wrk)
+	// Field: City
+	if s.City.Valid {
+		wrk = s.City.String
+	} else {
+		wrk = ""
+	}
+	v.Add("City", wrk)
+	// Field: State
+	if s.State.Valid {
+		wrk = s.State.String
+	} else {
+		wrk = ""
+	}
+	v.Add("State", wrk)
+	// Field: Zip
+	if s.Zip.Valid {
+		wrk = s.Zip.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Zip", wrk)
+	// Field: Country
+	if s.Country.Valid {
+		wrk = s.Country.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Country", wrk)
+	// Field: Curbal
+	if s.Curbal.Valid {
+		wrk = s.Curbal.Decimal.StringFixed(2)
+	} else {
+		wrk = ""
+	}
+	v.Add("Curbal", wrk)
+	return v.Encode()
+}
+
+//----------------------------------------------------------------------------
+//                  		JSON Marshal
+//----------------------------------------------------------------------------
+
+func (d *App01sqCustomer) JsonMarshal() ([]byte, error) {
+	var err error
+	var text []byte
+
+	if text, err = json.Marshal(d); err != nil {
+		return nil, fmt.Errorf("Error: marshalling json: %s : %v", err, d)
+	}
+
+	return text, err
+}
+
+//----------------------------------------------------------------------------
+//                             JSON Unmarshal
+//----------------------------------------------------------------------------
+
+func (d *App01sqCustomer) JsonUnmarshal(text []byte) error {
+	var err error
+
+	if err = json.Unmarshal(text, d); err != nil {
+		return fmt.Errorf("Error: unmarshalling json: %s : %s", err, text)
+	}
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                      Set Keys from a Slice of Strings
+//----------------------------------------------------------------------------
+
+// SetKeysFromStrings creates a URL Value map from the table's key(s). The slice
+// is in field order within the struct, not sorted by field name.
+func (s *App01sqCustomer) Key() Key {
+	var k Key
+
+	k.Num = s.Num
+	return k
+}
+
+//----------------------------------------------------------------------------
+//                      Keys to URL Value String
+//----------------------------------------------------------------------------
+
+// KeysToValue creates a URL Value map from the table's key(s).
+func (s *App01sqCustomer) KeysToValue() string {
+	var wrk string
+
+	v := url.Values{}
+	// Accumulate the key value(s) in KeyNum order.
+	// Field: Num
+	wrk = fmt.Sprintf("%d", s.Num)
+	v.Add(fmt.Sprintf("key%d", 1-1), wrk)
+	return v.Encode()
+}
+
+//----------------------------------------------------------------------------
+//                             List Output
+//----------------------------------------------------------------------------
+
+func (s *App01sqCustomer) ListOutput() string {
+	var str strings.Builder
+	var wrk string
+
+	if s == nil {
+		return ""
+	}
+
+	// Field: Num
+	str.WriteString("<td>")
+	wrk = fmt.Sprintf("<a href=\"/Customer/find?%s\">", s.KeysToValue())
+	str.WriteString(wrk)
+	wrk = fmt.Sprintf("%d", s.Num)
+	str.WriteString(wrk)
+	//str.WriteString("\n")
+	str.WriteString("</a>")
+	str.WriteString("</td>\n")
+	// Field: Name
+	str.WriteString("<td>")
+	if s.Name.Valid {
+		wrk = s.Name.String
+	} else {
+		wrk = ""
+	}
+	str.WriteString(wrk)
+	//str.WriteString("\n")
+	str.WriteString("</td>\n")
+	return str.String()
+}
+
+//----------------------------------------------------------------------------
+//                             Validation
+//----------------------------------------------------------------------------
+
+// FieldErrors is the error returned when fields of a record are not valid.
+// It maps the TitledName of each field in error to its message.
+type FieldErrors map[string]string
+
+// Error returns the messages of the fields in field name order.
+func (e FieldErrors) Error() string {
+	var msgs []string
+
+	for _, fn := range e.Names() {
+		msgs = append(msgs, fn+" "+e[fn])
+	}
+
+	return "Error: " + strings.Join(msgs, ", ") + "!"
+}
+
+// Names returns the names of the fields in error in sorted order.
+func (e FieldErrors) Names() []string {
+	var names []string
+
+	for fn := range e {
+		names = append(names, fn)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate checks the record against the rules of its fields returning
+// FieldErrors if any of them are broken.
+func (s *App01sqCustomer) Validate() error {
+	errs := FieldErrors{}
+
+	if s.Name.Valid && len([]rune(s.Name.String)) > 30 {
+		errs["Name"] = "must be at most 30 characters"
+	}
+	if s.Addr1.Valid && len([]rune(s.Addr1.String)) > 30 {
+		errs["Addr1"] = "must be at most 30 characters"
+	}
+	if s.Addr2.Valid && len([]rune(s.Addr2.String)) > 30 {
+		errs["Addr2"] = "must be at most 30 characters"
+	}
+	if s.City.Valid && len([]rune(s.City.String)) > 20 {
+		errs["City"] = "must be at most 20 characters"
+	}
+	if s.State.Valid && len([]rune(s.State.String)) > 10 {
+		errs["State"] = "must be at most 10 characters"
+	}
+	if s.Zip.Valid && len([]rune(s.Zip.String)) > 20 {
+		errs["Zip"] = "must be at most 20 characters"
+	}
+	if s.Country.Valid && len([]rune(s.Country.String)) > 30 {
+		errs["Country"] = "must be at most 30 characters"
+	}
+	if s.Curbal.Valid && !s.Curbal.Decimal.Equal(s.Curbal.Decimal.Truncate(2)) {
+		errs["Curbal"] = "must have at most 2 decimal places"
+	} else if s.Curbal.Valid && s.Curbal.Decimal.Abs().Cmp(decimal.New(1, 13)) >= 0 {
+		errs["Curbal"] = "must have at most 13 digits before the decimal point"
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                  Request Form Value(s) to Struct
+//----------------------------------------------------------------------------
+
+// CustomerRequest2Struct converts the form values to a struct. FormValue(s) are available
+// for both, GET and POST.  It is just that all your parameters are present in the URL if you use
+// GET.  In general, you should use POST with this function for security reasons.
+// If any of the values can not be converted or are not valid, FieldErrors is
+// returned.
+func (s *App01sqCustomer) Request2Struct(r *http.Request) error {
+	var err error
+	var str string
+	errs := FieldErrors{}
+
+	log.Printf("Customer.Request2Struct()\n")
+	log.Printf("\tr.FormValue: %q\n", r.Form)
+
+	s.Empty()
+	str = r.FormValue("Num")
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Num, err = strconv.ParseInt(str, 0, 64); err != nil {
+			errs["Num"] = "must be a whole number"
+		}
+	}
+	str = r.FormValue("Name")
+	if len(str) == 0 {
+		s.Name = sql.NullString{}
+	} else {
+		s.Name.String = str
+		s.Name.Valid = true
+	}
+	str = r.FormValue("Addr1")
+	if len(str) == 0 {
+		s.Addr1 = sql.NullString{}
+	} else {
+		s.Addr1.String = str
+		s.Addr1.Valid = true
+	}
+	str = r.FormValue("Addr2")
+	if len(str) == 0 {
+		s.Addr2 = sql.NullString{}
+	} else {
+		s.Addr2.String = str
+		s.Addr2.Valid = true
+	}
+	str = r.FormValue("City")
+	if len(str) == 0 {
+		s.City = sql.NullString{}
+	} else {
+		s.City.String = str
+		s.City.Valid = true
+	}
+	str = r.FormValue("State")
+	if len(str) == 0 {
+		s.State = sql.NullString{}
+	} else {
+		s.State.String = str
+		s.State.Valid = true
+	}
+	str = r.FormValue("Zip")
+	if len(str) == 0 {
+		s.Zip = sql.NullString{}
+	} else {
+		s.Zip.String = str
+		s.Zip.Valid = true
+	}
+	str = r.FormValue("Country")
+	if len(str) == 0 {
+		s.Country = sql.NullString{}
+	} else {
+		s.Country.String = str
+		s.Country.Valid = true
+	}
+	str = r.FormValue("Curbal")
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Curbal = decimal.NullDecimal{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Curbal.Decimal, err = decimal.NewFromString(str); err != nil {
+			errs["Curbal"] = "must be a number"
+		}
+		s.Curbal.Valid = true
+	}
+
+	// Fields which could not be converted keep their conversion message.
+	if err = s.Validate(); err != nil {
+		for fn, msg := range err.(FieldErrors) {
+			if _, ok := errs[fn]; !ok {
+				errs[fn] = msg
+			}
+		}
+	}
+	err = nil
+	if len(errs) > 0 {
+		err = errs
+	}
+
+	log.Printf("...end CustomerRequest2Struct(%+v, %s)\n", s, util.ErrorString(err))
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                  Slice of Strings to Struct
+//----------------------------------------------------------------------------
+
+// Strings2Struct converts a slice of strings in field order, such as a CSV
+// record written from ToStrings(), to a struct. If any of the values can
+// not be converted or are not valid, FieldErrors is returned.
+func (s *App01sqCustomer) Strings2Struct(strs []string) error {
+	var err error
+	var str string
+	errs := FieldErrors{}
+
+	log.Printf("Customer.Strings2Struct(%q)\n", strs)
+
+	if len(strs) != 9 {
+		return fmt.Errorf("Error: %d values were given, but needed 9!\n", len(strs))
+	}
+
+	s.Empty()
+	str = strs[0]
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Num, err = strconv.ParseInt(str, 0, 64); err != nil {
+			errs["Num"] = "must be a whole number"
+		}
+	}
+	str = strs[1]
+	if len(str) == 0 {
+		s.Name = sql.NullString{}
+	} else {
+		s.Name.String = str
+		s.Name.Valid = true
+	}
+	str = strs[2]
+	if len(str) == 0 {
+		s.Addr1 = sql.NullString{}
+	} else {
+		s.Addr1.String = str
+		s.Addr1.Valid = true
+	}
+	str = strs[3]
+	if len(str) == 0 {
+		s.Addr2 = sql.NullString{}
+	} else {
+		s.Addr2.String = str
+		s.Addr2.Valid = true
+	}
+	str = strs[4]
+	if len(str) == 0 {
+		s.City = sql.NullString{}
+	} else {
+		s.City.String = str
+		s.City.Valid = true
+	}
+	str = strs[5]
+	if len(str) == 0 {
+		s.State = sql.NullString{}
+	} else {
+		s.State.String = str
+		s.State.Valid = true
+	}
+	str = strs[6]
+	if len(str) == 0 {
+		s.Zip = sql.NullString{}
+	} else {
+		s.Zip.String = str
+		s.Zip.Valid = true
+	}
+	str = strs[7]
+	if len(str) == 0 {
+		s.Country = sql.NullString{}
+	} else {
+		s.Country.String = str
+		s.Country.Valid = true
+	}
+	str = strs[8]
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Curbal = decimal.NullDecimal{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Curbal.Decimal, err = decimal.NewFromString(str); err != nil {
+			errs["Curbal"] = "must be a number"
+		}
+		s.Curbal.Valid = true
+	}
+
+	// Fields which could not be converted keep their conversion message.
+	if err = s.Validate(); err != nil {
+		for fn, msg := range err.(FieldErrors) {
+			if _, ok := errs[fn]; !ok {
+				errs[fn] = msg
+			}
+		}
+	}
+	err = nil
+	if len(errs) > 0 {
+		err = errs
+	}
+
+	log.Printf("...end Customer.Strings2Struct(%+v, %s)\n", s, util.ErrorString(err))
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                      Set Keys from a Slice of Strings
+//----------------------------------------------------------------------------
+
+// SetKeysFromStrings creates a URL Value map from the table's key(s). The slice
+// is in field order within the struct, not sorted by field name.
+func (s *App01sqCustomer) SetKeysFromStrings(strs []string) error {
+
+	if len(strs) != 1 {
+		return fmt.Errorf("Error - Invalid key count of %d, need %d!\n", len(strs), 1)
+	}
+
+	// Accumulate the key value(s) in KeyNum order.
+	s.Num, _ = strconv.ParseInt(strs[0], 0, 64)
+
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                             Test Data
+//----------------------------------------------------------------------------
+
+// TestData takes the given integer and uses it to fill most of the fields in
+// with data derived from it. 'i' is relative to zero.
+func (s *App01sqCustomer) TestData(i int) {
+	var chr rune
+	var i64 int64
+	var str string
+
+	if i < 27 {
+		chr = rune(65 + i) // A
+	} else if i < 55 {
+		chr = rune(97 + i) // a
+	} else {
+		chr = rune(65) // A
+	}
+
+	i64 = int64(i)
+	str = string(chr)
+
+	s.Num = i64
+	s.Name = sql.NullString{String: str, Valid: true}
+	s.Addr1 = sql.NullString{String: str, Valid: true}
+	s.Addr2 = sql.NullString{String: str, Valid: true}
+	s.City = sql.NullString{String: str, Valid: true}
+	s.State = sql.NullString{String: str, Valid: true}
+	s.Zip = sql.NullString{String: str, Valid: true}
+	s.Country = sql.NullString{String: str, Valid: true}
+	s.Curbal = decimal.NullDecimal{Decimal: decimal.New(int64(i), -2), Valid: true}
+
+}
+
+//----------------------------------------------------------------------------
+//                             To String
+//----------------------------------------------------------------------------
+
+// ToString converts a record's field to a string.
+func (s *App01sqCustomer) ToString(TitledName string) string {
+	var str string
+
+	switch TitledName {
+
+	case "Num":
+		str = fmt.Sprintf("%d", s.Num)
+
+	case "Name":
+		if s.Name.Valid {
+			str = s.Name.String
+		} else {
+			str = ""
+		}
+
+	case "Addr1":
+		if s.Addr1.Valid {
+			str = s.Addr1.String
+		} else {
+			str = ""
+		}
+
+	case "Addr2":
+		if s.Addr2.Valid {
+			str = s.Addr2.String
+		} else {
+			str = ""
+		}
+
+	case "City":
+		if s.City.Valid {
+			str = s.City.String
+		} else {
+			str = ""
+		}
+
+	case "State":
+		if s.State.Valid {
+			str = s.State.String
+		} else {
+			str = ""
+		}
+
+	case "Zip":
+		if s.Zip.Valid {
+			str = s.Zip.String
+		} else {
+			str = ""
+		}
+
+	case "Country":
+		if s.Country.Valid {
+			str = s.Country.String
+		} else {
+			str = ""
+		}
+
+	case "Curbal":
+		if s.Curbal.Valid {
+			str = s.Curbal.Decimal.StringFixed(2)
+		} else {
+			str = ""
+		}
+
+	default:
+		str = ""
+	}
+
+	return str
+}
+
+//----------------------------------------------------------------------------
+//                             To Strings
+//----------------------------------------------------------------------------
+
+// ToStrings converts a record to an array of strings acceptable to CSV and
+// other conversion packages.
+func (s *App01sqCustomer) ToStrings() []string {
+	var strs []string
+	var str string
+
+	str = fmt.Sprintf("%d", s.Num)
+
+	strs = append(strs, str)
+	if s.Name.Valid {
+		str = s.Name.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Addr1.Valid {
+		str = s.Addr1.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Addr2.Valid {
+		str = s.Addr2.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.City.Valid {
+		str = s.City.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.State.Valid {
+		str = s.State.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Zip.Valid {
+		str = s.Zip.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Country.Valid {
+		str = s.Country.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Curbal.Valid {
+		str = s.Curbal.Decimal.StringFixed(2)
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+
+	return strs
+}
+
+//----------------------------------------------------------------------------
+//                             New Struct
+//----------------------------------------------------------------------------
+
+// NewApp01sqCustomer creates a new empty struct.
+func NewApp01sqCustomer() *App01sqCustomer {
+	return &App01sqCustomer{}
+}
+
+func NewApp01sqCustomers() *App01sqCustomers {
+	return &App01sqCustomers{}
+}
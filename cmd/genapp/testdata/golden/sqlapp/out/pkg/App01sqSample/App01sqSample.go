@@ -0,0 +1,1041 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+//  Struct and Methods for App01sqSample
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package App01sqSample
+
+import (
+	"bytes"
+	"encoding/base64"
+
+	"database/sql"
+
+	"encoding/json"
+	"fmt"
+
+	"io/ioutil"
+
+	"log"
+
+	"net/http"
+
+	"regexp"
+
+	"sort"
+	"strconv"
+	"strings"
+
+	"time"
+
+	"net/url"
+
+	"github.com/2kranki/go_util"
+	"github.com/shopspring/decimal"
+)
+
+//============================================================================
+//                             Database Interfaces
+//============================================================================
+
+type App01sqSampleDbRowDeleter interface {
+	// RowDelete deletes the row with keys from the provided record, rcd.
+	RowDelete(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowFinder interface {
+	// RowFind searches the Database for a matching row for the keys found in
+	// the given record and returns the output in that same record.
+	RowFind(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowFirster interface {
+	// RowFirst returns the first row in the table, Sample.
+	// If there are no rows in the table, then a blank/null record is returned
+	// without error.
+	RowFirst(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowInserter interface {
+	RowInsert(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowLaster interface {
+	// RowLast returns the last row in the table, Sample.
+	// If there are no rows in the table, then a blank/null record is returned
+	// without error.
+	RowLast(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowNexter interface {
+	// RowNext returns the next row from the row given. If row after the current
+	// one does not exist, then the first row is returned.
+	RowNext(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowPager interface {
+	// RowPage returns a page of rows where a page size is the 'limit' parameter and
+	// 'offset' is the offset into the result set ordered by the main index. Both
+	// 'limit' and 'offset' are relative to 1. We return an address to the array
+	// rows (structs) so that we don't have the overhead of copying them everwhere.
+	RowPage(offset int, limit int) ([]App01sqSample, error)
+}
+
+type App01sqSampleDbRowPrever interface {
+	RowPrev(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbRowUpdater interface {
+	RowUpdate(rcd *App01sqSample) error
+}
+
+type App01sqSampleDbTableCounter interface {
+	TableCount() (int, error)
+}
+
+type App01sqSampleDbTableCreater interface {
+	TableCreate() error
+}
+
+type App01sqSampleDbTableDeleter interface {
+	TableDelete() error
+}
+
+type App01sqSampleDbTableScanner interface {
+	// TableScan reads all the rows in the table applying a function to each of
+	// them.
+	TableScan(apply func(rcd App01sqSample) error) error
+}
+
+//============================================================================
+//                              Table Struct
+//============================================================================
+
+type App01sqSample struct {
+	Id     int64
+	Flag   bool
+	Opt    sql.NullBool
+	Memo   sql.NullString
+	Ratio  sql.NullFloat64
+	Big    int64
+	Ident  sql.NullString
+	Stamp  sql.NullTime
+	Doc    string
+	Part   string
+	Pct    decimal.Decimal
+	Status string
+	Data   []byte
+}
+
+type App01sqSamples []*App01sqSample
+
+type Key struct {
+	Id int64
+}
+
+type App01sqSampleIndex map[Key]*App01sqSample
+
+// NOTE: For JsonMarshal() and JsonUnmarshal() to work properly, the JSON
+//  names must be defined above.
+
+//----------------------------------------------------------------------------
+//                          JSON NULL Fields
+//----------------------------------------------------------------------------
+
+// MarshalJSON gives the NULL fields as null instead of as their sql.Null
+// structs. The fields of rcd are hidden by the ones of the same name.
+func (s App01sqSample) MarshalJSON() ([]byte, error) {
+	type rcd App01sqSample
+	j := struct {
+		rcd
+		Opt   *bool
+		Memo  *string
+		Ratio *float64
+		Ident *string
+		Stamp *time.Time
+	}{rcd: rcd(s)}
+
+	if s.Opt.Valid {
+		j.Opt = &s.Opt.Bool
+	}
+	if s.Memo.Valid {
+		j.Memo = &s.Memo.String
+	}
+	if s.Ratio.Valid {
+		j.Ratio = &s.Ratio.Float64
+	}
+	if s.Ident.Valid {
+		j.Ident = &s.Ident.String
+	}
+	if s.Stamp.Valid {
+		j.Stamp = &s.Stamp.Time
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON sets the NULL fields from null or their values.
+func (s *App01sqSample) UnmarshalJSON(text []byte) error {
+	type rcd App01sqSample
+	j := struct {
+		*rcd
+		Opt   *bool
+		Memo  *string
+		Ratio *float64
+		Ident *string
+		Stamp *time.Time
+	}{rcd: (*rcd)(s)}
+
+	if err := json.Unmarshal(text, &j); err != nil {
+		return err
+	}
+	s.Opt = sql.NullBool{}
+	if j.Opt != nil {
+		s.Opt = sql.NullBool{Bool: *j.Opt, Valid: true}
+	}
+	s.Memo = sql.NullString{}
+	if j.Memo != nil {
+		s.Memo = sql.NullString{String: *j.Memo, Valid: true}
+	}
+	s.Ratio = sql.NullFloat64{}
+	if j.Ratio != nil {
+		s.Ratio = sql.NullFloat64{Float64: *j.Ratio, Valid: true}
+	}
+	s.Ident = sql.NullString{}
+	if j.Ident != nil {
+		s.Ident = sql.NullString{String: *j.Ident, Valid: true}
+	}
+	s.Stamp = sql.NullTime{}
+	if j.Stamp != nil {
+		s.Stamp = sql.NullTime{Time: *j.Stamp, Valid: true}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                              Compare
+//----------------------------------------------------------------------------
+
+// Compare compares our struct to another returning
+// 0, 1 for equal and not equal.
+func (s *App01sqSample) Compare(r *App01sqSample) int {
+	// Accumulate the key value(s) in KeyNum order.
+	if s.Id != r.Id {
+		return 1
+	}
+	if s.Flag != r.Flag {
+		return 1
+	}
+	if s.Opt.Valid != r.Opt.Valid || s.Opt.Valid && s.Opt.Bool != r.Opt.Bool {
+		return 1
+	}
+	if s.Memo.Valid != r.Memo.Valid || s.Memo.Valid && s.Memo.String != r.Memo.String {
+		return 1
+	}
+	if s.Ratio.Valid != r.Ratio.Valid || s.Ratio.Valid && s.Ratio.Float64 != r.Ratio.Float64 {
+		return 1
+	}
+	if s.Big != r.Big {
+		return 1
+	}
+	if s.Ident.Valid != r.Ident.Valid || s.Ident.Valid && s.Ident.String != r.Ident.String {
+		return 1
+	}
+	if s.Stamp.Valid != r.Stamp.Valid || s.Stamp.Valid && !s.Stamp.Time.Equal(r.Stamp.Time) {
+		return 1
+	}
+	if s.Doc != r.Doc {
+		return 1
+	}
+	if s.Part != r.Part {
+		return 1
+	}
+	if !s.Pct.Equal(r.Pct) {
+		return 1
+	}
+	if s.Status != r.Status {
+		return 1
+	}
+	if !bytes.Equal(s.Data, r.Data) {
+		return 1
+	}
+	return 0
+}
+
+// CompareKeys compares our struct to another using keys returning the normal
+// -1, 0, 1 for less than, equal and greater than.
+func (s *App01sqSample) CompareKeys(r *App01sqSample) int {
+	// Accumulate the key value(s) in KeyNum order.
+	// Field: Id
+	if s.Id != r.Id {
+		if s.Id < r.Id {
+			return -1
+		} else {
+			return 1
+		}
+	}
+	return 0
+}
+
+//----------------------------------------------------------------------------
+//                             Empty
+//----------------------------------------------------------------------------
+
+// Empty resets the struct values to their null values.
+func (s *App01sqSample) Empty() {
+	s.Id = 0
+	s.Flag = false
+	s.Opt = sql.NullBool{}
+	s.Memo = sql.NullString{}
+	s.Ratio = sql.NullFloat64{}
+	s.Big = 0
+	s.Ident = sql.NullString{}
+	s.Stamp = sql.NullTime{}
+	s.Doc = ""
+	s.Part = ""
+	s.Pct = decimal.Zero
+	s.Status = ""
+	s.Data = nil
+
+}
+
+//----------------------------------------------------------------------------
+//                      Fields to URL Value String
+//----------------------------------------------------------------------------
+
+// FieldsToValue creates a URL Value map from the the table's field(s).
+func (s *App01sqSample) FieldsToValue() string {
+	var wrk string
+
+	v := url.Values{}
+	// Accumulate the value(s) from the fields.
+	// Field: Id
+	wrk = fmt.Sprintf("%d", s.Id)
+	v.Add("Id", wrk)
+	// Field: Flag
+	wrk = strconv.FormatBool(s.Flag)
+	v.Add("Flag", wrk)
+	// Field: Opt
+	if s.Opt.Valid {
+		wrk = strconv.FormatBool(s.Opt.Bool)
+	} else {
+		wrk = ""
+	}
+	v.Add("Opt", wrk)
+	// Field: Memo
+	if s.Memo.Valid {
+		wrk = s.Memo.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Memo", wrk)
+	// Field: Ratio
+	if s.Ratio.Valid {
+		{
+			s := fmt.Sprintf("%.4f", s.Ratio.Float64)
+			wrk = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+	} else {
+		wrk = ""
+	}
+	v.Add("Ratio", wrk)
+	// Field: Big
+	wrk = fmt.Sprintf("%d", s.Big)
+	v.Add("Big", wrk)
+	// Field: Ident
+	if s.Ident.Valid {
+		wrk = s.Ident.String
+	} else {
+		wrk = ""
+	}
+	v.Add("Ident", wrk)
+	// Field: Stamp
+	if s.Stamp.Valid {
+		wrk = s.Stamp.Time.Format(time.RFC3339Nano)
+	} else {
+		wrk = ""
+	}
+	v.Add("Stamp", wrk)
+	// Field: Doc
+	wrk = s.Doc
+	v.Add("Doc", wrk)
+	// Field: Part
+	wrk = s.Part
+	v.Add("Part", wrk)
+	// Field: Pct
+	wrk = s.Pct.StringFixed(2)
+	v.Add("Pct", wrk)
+	// Field: Status
+	wrk = s.Status
+	v.Add("Status", wrk)
+	// Field: Data
+	wrk = base64.StdEncoding.EncodeToString(s.Data)
+	v.Add("Data", wrk)
+	return v.Encode()
+}
+
+//----------------------------------------------------------------------------
+//                  		JSON Marshal
+//----------------------------------------------------------------------------
+
+func (d *App01sqSample) JsonMarshal() ([]byte, error) {
+	var err error
+	var text []byte
+
+	if text, err = json.Marshal(d); err != nil {
+		return nil, fmt.Errorf("Error: marshalling json: %s : %v", err, d)
+	}
+
+	return text, err
+}
+
+//----------------------------------------------------------------------------
+//                             JSON Unmarshal
+//----------------------------------------------------------------------------
+
+func (d *App01sqSample) JsonUnmarshal(text []byte) error {
+	var err error
+
+	if err = json.Unmarshal(text, d); err != nil {
+		return fmt.Errorf("Error: unmarshalling json: %s : %s", err, text)
+	}
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                      Set Keys from a Slice of Strings
+//----------------------------------------------------------------------------
+
+// SetKeysFromStrings creates a URL Value map from the table's key(s). The slice
+// is in field order within the struct, not sorted by field name.
+func (s *App01sqSample) Key() Key {
+	var k Key
+
+	k.Id = s.Id
+	return k
+}
+
+//----------------------------------------------------------------------------
+//                      Keys to URL Value String
+//----------------------------------------------------------------------------
+
+// KeysToValue creates a URL Value map from the table's key(s).
+func (s *App01sqSample) KeysToValue() string {
+	var wrk string
+
+	v := url.Values{}
+	// Accumulate the key value(s) in KeyNum order.
+	// Field: Id
+	wrk = fmt.Sprintf("%d", s.Id)
+	v.Add(fmt.Sprintf("key%d", 1-1), wrk)
+	return v.Encode()
+}
+
+//----------------------------------------------------------------------------
+//                             List Output
+//----------------------------------------------------------------------------
+
+func (s *App01sqSample) ListOutput() string {
+	var str strings.Builder
+	var wrk string
+
+	if s == nil {
+		return ""
+	}
+
+	// Field: Id
+	str.WriteString("<td>")
+	wrk = fmt.Sprintf("<a href=\"/Sample/find?%s\">", s.KeysToValue())
+	str.WriteString(wrk)
+	wrk = fmt.Sprintf("%d", s.Id)
+	str.WriteString(wrk)
+	//str.WriteString("\n")
+	str.WriteString("</a>")
+	str.WriteString("</td>\n")
+	// Field: Flag
+	str.WriteString("<td>")
+	wrk = strconv.FormatBool(s.Flag)
+	str.WriteString(wrk)
+	//str.WriteString("\n")
+	str.WriteString("</td>\n")
+	return str.String()
+}
+
+//----------------------------------------------------------------------------
+//                             Enumerated Values
+//----------------------------------------------------------------------------
+
+// App01sqSampleStatus is a value of the Status field.
+type App01sqSampleStatus string
+
+const (
+	App01sqSampleStatusOpen   App01sqSampleStatus = "open"
+	App01sqSampleStatusClosed App01sqSampleStatus = "closed"
+	App01sqSampleStatusOnHold App01sqSampleStatus = "on hold"
+)
+
+// App01sqSampleStatusValues are the values of the Status field in order.
+var App01sqSampleStatusValues = []App01sqSampleStatus{App01sqSampleStatusOpen, App01sqSampleStatusClosed, App01sqSampleStatusOnHold}
+
+// String returns the value as a string.
+func (v App01sqSampleStatus) String() string {
+	return string(v)
+}
+
+// ParseApp01sqSampleStatus returns the App01sqSampleStatus of the string or an error if it is not one
+// of the values.
+func ParseApp01sqSampleStatus(str string) (App01sqSampleStatus, error) {
+	for _, v := range App01sqSampleStatusValues {
+		if string(v) == str {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("Error: %q is not one of open, closed, on hold!\n", str)
+}
+
+//----------------------------------------------------------------------------
+//                             Validation
+//----------------------------------------------------------------------------
+
+// FieldErrors is the error returned when fields of a record are not valid.
+// It maps the TitledName of each field in error to its message.
+type FieldErrors map[string]string
+
+// Error returns the messages of the fields in field name order.
+func (e FieldErrors) Error() string {
+	var msgs []string
+
+	for _, fn := range e.Names() {
+		msgs = append(msgs, fn+" "+e[fn])
+	}
+
+	return "Error: " + strings.Join(msgs, ", ") + "!"
+}
+
+// Names returns the names of the fields in error in sorted order.
+func (e FieldErrors) Names() []string {
+	var names []string
+
+	for fn := range e {
+		names = append(names, fn)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+var patternPart = regexp.MustCompile("^(?:[A-Za-z0-9]+)$")
+
+// Validate checks the record against the rules of its fields returning
+// FieldErrors if any of them are broken.
+func (s *App01sqSample) Validate() error {
+	errs := FieldErrors{}
+
+	if s.Memo.Valid && len([]rune(s.Memo.String)) > 20 {
+		errs["Memo"] = "must be at most 20 characters"
+	}
+	if len(strings.TrimSpace(s.Doc)) > 0 && !json.Valid([]byte(s.Doc)) {
+		errs["Doc"] = "must be JSON"
+	}
+	if len([]rune(s.Part)) > 8 {
+		errs["Part"] = "must be at most 8 characters"
+	} else if len(s.Part) > 0 && !patternPart.MatchString(s.Part) {
+		errs["Part"] = "is not in the required format"
+	}
+	if !s.Pct.Equal(s.Pct.Truncate(2)) {
+		errs["Pct"] = "must have at most 2 decimal places"
+	} else if s.Pct.Abs().Cmp(decimal.New(1, 3)) >= 0 {
+		errs["Pct"] = "must have at most 3 digits before the decimal point"
+	} else if s.Pct.LessThan(decimal.RequireFromString("0")) {
+		errs["Pct"] = "must be at least 0"
+	} else if s.Pct.GreaterThan(decimal.RequireFromString("100")) {
+		errs["Pct"] = "must be at most 100"
+	}
+	if len([]rune(s.Status)) > 10 {
+		errs["Status"] = "must be at most 10 characters"
+	} else if len(s.Status) > 0 && !(s.Status == "open" || s.Status == "closed" || s.Status == "on hold") {
+		errs["Status"] = "must be one of open, closed, on hold"
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                  Request Form Value(s) to Struct
+//----------------------------------------------------------------------------
+
+// SampleRequest2Struct converts the form values to a struct. FormValue(s) are available
+// for both, GET and POST.  It is just that all your parameters are present in the URL if you use
+// GET.  In general, you should use POST with this function for security reasons.
+// If any of the values can not be converted or are not valid, FieldErrors is
+// returned.
+func (s *App01sqSample) Request2Struct(r *http.Request) error {
+	var err error
+	var str string
+	errs := FieldErrors{}
+
+	log.Printf("Sample.Request2Struct()\n")
+	log.Printf("\tr.FormValue: %q\n", r.Form)
+
+	s.Empty()
+	str = r.FormValue("Id")
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Id, err = strconv.ParseInt(str, 0, 64); err != nil {
+			errs["Id"] = "must be a whole number"
+		}
+	}
+	str = r.FormValue("Flag")
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Flag, err = strconv.ParseBool(str); err != nil {
+			errs["Flag"] = "must be true or false"
+		}
+	}
+	str = r.FormValue("Opt")
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Opt = sql.NullBool{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Opt.Bool, err = strconv.ParseBool(str); err != nil {
+			errs["Opt"] = "must be true or false"
+		}
+		s.Opt.Valid = true
+	}
+	str = r.FormValue("Memo")
+	if len(str) == 0 {
+		s.Memo = sql.NullString{}
+	} else {
+		s.Memo.String = str
+		s.Memo.Valid = true
+	}
+	str = r.FormValue("Ratio")
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Ratio = sql.NullFloat64{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Ratio.Float64, err = strconv.ParseFloat(str, 64); err != nil {
+			errs["Ratio"] = "must be a number"
+		}
+		s.Ratio.Valid = true
+	}
+	str = r.FormValue("Big")
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Big, err = strconv.ParseInt(str, 0, 64); err != nil {
+			errs["Big"] = "must be a whole number"
+		}
+	}
+	str = r.FormValue("Ident")
+	if len(str) == 0 {
+		s.Ident = sql.NullString{}
+	} else {
+		s.Ident.String = str
+		s.Ident.Valid = true
+	}
+	str = r.FormValue("Stamp")
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Stamp = sql.NullTime{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Stamp.Time, err = time.Parse(time.RFC3339, str); err != nil {
+			if s.Stamp.Time, err = time.Parse("2006-01-02T15:04", str); err != nil {
+				errs["Stamp"] = "must be a date"
+			}
+		}
+		s.Stamp.Valid = true
+	}
+	str = r.FormValue("Doc")
+	s.Doc = str
+	str = r.FormValue("Part")
+	s.Part = str
+	str = r.FormValue("Pct")
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Pct, err = decimal.NewFromString(str); err != nil {
+			errs["Pct"] = "must be a number"
+		}
+	}
+	str = r.FormValue("Status")
+	s.Status = str
+	if file, _, err := r.FormFile("Data"); err == nil {
+		s.Data, err = ioutil.ReadAll(file)
+		file.Close()
+		if err != nil {
+			errs["Data"] = "could not be read"
+		}
+	} else {
+		str = r.FormValue("Data")
+		if str = strings.TrimSpace(str); len(str) > 0 {
+			if s.Data, err = base64.StdEncoding.DecodeString(str); err != nil {
+				errs["Data"] = "must be base64"
+			}
+		}
+	}
+
+	// Fields which could not be converted keep their conversion message.
+	if err = s.Validate(); err != nil {
+		for fn, msg := range err.(FieldErrors) {
+			if _, ok := errs[fn]; !ok {
+				errs[fn] = msg
+			}
+		}
+	}
+	err = nil
+	if len(errs) > 0 {
+		err = errs
+	}
+
+	log.Printf("...end SampleRequest2Struct(%+v, %s)\n", s, util.ErrorString(err))
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                  Slice of Strings to Struct
+//----------------------------------------------------------------------------
+
+// Strings2Struct converts a slice of strings in field order, such as a CSV
+// record written from ToStrings(), to a struct. If any of the values can
+// not be converted or are not valid, FieldErrors is returned.
+func (s *App01sqSample) Strings2Struct(strs []string) error {
+	var err error
+	var str string
+	errs := FieldErrors{}
+
+	log.Printf("Sample.Strings2Struct(%q)\n", strs)
+
+	if len(strs) != 13 {
+		return fmt.Errorf("Error: %d values were given, but needed 13!\n", len(strs))
+	}
+
+	s.Empty()
+	str = strs[0]
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Id, err = strconv.ParseInt(str, 0, 64); err != nil {
+			errs["Id"] = "must be a whole number"
+		}
+	}
+	str = strs[1]
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Flag, err = strconv.ParseBool(str); err != nil {
+			errs["Flag"] = "must be true or false"
+		}
+	}
+	str = strs[2]
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Opt = sql.NullBool{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Opt.Bool, err = strconv.ParseBool(str); err != nil {
+			errs["Opt"] = "must be true or false"
+		}
+		s.Opt.Valid = true
+	}
+	str = strs[3]
+	if len(str) == 0 {
+		s.Memo = sql.NullString{}
+	} else {
+		s.Memo.String = str
+		s.Memo.Valid = true
+	}
+	str = strs[4]
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Ratio = sql.NullFloat64{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Ratio.Float64, err = strconv.ParseFloat(str, 64); err != nil {
+			errs["Ratio"] = "must be a number"
+		}
+		s.Ratio.Valid = true
+	}
+	str = strs[5]
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Big, err = strconv.ParseInt(str, 0, 64); err != nil {
+			errs["Big"] = "must be a whole number"
+		}
+	}
+	str = strs[6]
+	if len(str) == 0 {
+		s.Ident = sql.NullString{}
+	} else {
+		s.Ident.String = str
+		s.Ident.Valid = true
+	}
+	str = strs[7]
+	if len(strings.TrimSpace(str)) == 0 {
+		s.Stamp = sql.NullTime{}
+	} else {
+		str = strings.TrimSpace(str)
+		if s.Stamp.Time, err = time.Parse(time.RFC3339, str); err != nil {
+			if s.Stamp.Time, err = time.Parse("2006-01-02T15:04", str); err != nil {
+				errs["Stamp"] = "must be a date"
+			}
+		}
+		s.Stamp.Valid = true
+	}
+	str = strs[8]
+	s.Doc = str
+	str = strs[9]
+	s.Part = str
+	str = strs[10]
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Pct, err = decimal.NewFromString(str); err != nil {
+			errs["Pct"] = "must be a number"
+		}
+	}
+	str = strs[11]
+	s.Status = str
+	str = strs[12]
+	if str = strings.TrimSpace(str); len(str) > 0 {
+		if s.Data, err = base64.StdEncoding.DecodeString(str); err != nil {
+			errs["Data"] = "must be base64"
+		}
+	}
+
+	// Fields which could not be converted keep their conversion message.
+	if err = s.Validate(); err != nil {
+		for fn, msg := range err.(FieldErrors) {
+			if _, ok := errs[fn]; !ok {
+				errs[fn] = msg
+			}
+		}
+	}
+	err = nil
+	if len(errs) > 0 {
+		err = errs
+	}
+
+	log.Printf("...end Sample.Strings2Struct(%+v, %s)\n", s, util.ErrorString(err))
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                      Set Keys from a Slice of Strings
+//----------------------------------------------------------------------------
+
+// SetKeysFromStrings creates a URL Value map from the table's key(s). The slice
+// is in field order within the struct, not sorted by field name.
+func (s *App01sqSample) SetKeysFromStrings(strs []string) error {
+
+	if len(strs) != 1 {
+		return fmt.Errorf("Error - Invalid key count of %d, need %d!\n", len(strs), 1)
+	}
+
+	// Accumulate the key value(s) in KeyNum order.
+	s.Id, _ = strconv.ParseInt(strs[0], 0, 64)
+
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                             Test Data
+//----------------------------------------------------------------------------
+
+// TestData takes the given integer and uses it to fill most of the fields in
+// with data derived from it. 'i' is relative to zero.
+func (s *App01sqSample) TestData(i int) {
+	var chr rune
+	var date time.Time
+	var i64 int64
+	var f64 float64
+	var str string
+
+	if i < 27 {
+		chr = rune(65 + i) // A
+	} else if i < 55 {
+		chr = rune(97 + i) // a
+	} else {
+		chr = rune(65) // A
+	}
+
+	i64 = int64(i)
+	f64 = float64(i)
+	str = string(chr)
+
+	s.Id = i64
+	s.Id++ // auto-increment fields are relative to one not zero
+	s.Flag = (i%2 == 1)
+	s.Opt = sql.NullBool{Bool: (i%2 == 1), Valid: true}
+	s.Memo = sql.NullString{String: str, Valid: true}
+	s.Ratio = sql.NullFloat64{Float64: f64, Valid: true}
+	s.Big = i64
+	s.Ident = sql.NullString{String: fmt.Sprintf("00000000-0000-0000-0000-%012d", i), Valid: true}
+	s.Stamp = sql.NullTime{Time: date, Valid: true}
+	s.Doc = fmt.Sprintf(`{"n": %d}`, i)
+	s.Part = str
+	s.Pct = decimal.New(int64(i), -2)
+	s.Status = []string{"open", "closed", "on hold"}[i%3]
+	s.Data = []byte(fmt.Sprintf("blob %d", i))
+
+}
+
+//----------------------------------------------------------------------------
+//                             To String
+//----------------------------------------------------------------------------
+
+// ToString converts a record's field to a string.
+func (s *App01sqSample) ToString(TitledName string) string {
+	var str string
+
+	switch TitledName {
+
+	case "Id":
+		str = fmt.Sprintf("%d", s.Id)
+
+	case "Flag":
+		str = strconv.FormatBool(s.Flag)
+
+	case "Opt":
+		if s.Opt.Valid {
+			str = strconv.FormatBool(s.Opt.Bool)
+		} else {
+			str = ""
+		}
+
+	case "Memo":
+		if s.Memo.Valid {
+			str = s.Memo.String
+		} else {
+			str = ""
+		}
+
+	case "Ratio":
+		if s.Ratio.Valid {
+			{
+				s := fmt.Sprintf("%.4f", s.Ratio.Float64)
+				str = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+			}
+		} else {
+			str = ""
+		}
+
+	case "Big":
+		str = fmt.Sprintf("%d", s.Big)
+
+	case "Ident":
+		if s.Ident.Valid {
+			str = s.Ident.String
+		} else {
+			str = ""
+		}
+
+	case "Stamp":
+		if s.Stamp.Valid {
+			str = s.Stamp.Time.Format(time.RFC3339Nano)
+		} else {
+			str = ""
+		}
+
+	case "Doc":
+		str = s.Doc
+
+	case "Part":
+		str = s.Part
+
+	case "Pct":
+		str = s.Pct.StringFixed(2)
+
+	case "Status":
+		str = s.Status
+
+	case "Data":
+		str = base64.StdEncoding.EncodeToString(s.Data)
+
+	default:
+		str = ""
+	}
+
+	return str
+}
+
+//----------------------------------------------------------------------------
+//                             To Strings
+//----------------------------------------------------------------------------
+
+// ToStrings converts a record to an array of strings acceptable to CSV and
+// other conversion packages.
+func (s *App01sqSample) ToStrings() []string {
+	var strs []string
+	var str string
+
+	str = fmt.Sprintf("%d", s.Id)
+
+	strs = append(strs, str)
+	str = strconv.FormatBool(s.Flag)
+
+	strs = append(strs, str)
+	if s.Opt.Valid {
+		str = strconv.FormatBool(s.Opt.Bool)
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Memo.Valid {
+		str = s.Memo.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Ratio.Valid {
+		{
+			s := fmt.Sprintf("%.4f", s.Ratio.Float64)
+			str = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	str = fmt.Sprintf("%d", s.Big)
+
+	strs = append(strs, str)
+	if s.Ident.Valid {
+		str = s.Ident.String
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	if s.Stamp.Valid {
+		str = s.Stamp.Time.Format(time.RFC3339Nano)
+	} else {
+		str = ""
+	}
+
+	strs = append(strs, str)
+	str = s.Doc
+
+	strs = append(strs, str)
+	str = s.Part
+
+	strs = append(strs, str)
+	str = s.Pct.StringFixed(2)
+
+	strs = append(strs, str)
+	str = s.Status
+
+	strs = append(strs, str)
+	str = base64.StdEncoding.EncodeToString(s.Data)
+
+	strs = append(strs, str)
+
+	return strs
+}
+
+//----------------------------------------------------------------------------
+//                             New Struct
+//----------------------------------------------------------------------------
+
+// NewApp01sqSample creates a new empty struct.
+func NewApp01sqSample() *App01sqSample {
+	return &App01sqSample{}
+}
+
+func NewApp01sqSamples() *App01sqSamples {
+	return &App01sqSamples{}
+}
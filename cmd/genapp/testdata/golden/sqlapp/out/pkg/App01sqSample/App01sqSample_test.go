@@ -0,0 +1,508 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// ioApp01sq contains all the functions
+// and data to interact with the SQL Database.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package App01sqSample
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//============================================================================
+//                              Tests
+//============================================================================
+
+func TestTestDataApp01sqSample(t *testing.T) {
+	var chr rune
+	var str string
+	var i64 int64
+	var f64 float64
+
+	t.Logf("Test.TestData()...\n")
+	i64 = 1
+	f64 = float64(i64)
+	chr = rune(i64 + 65)
+	str = string(chr)
+	t.Logf("\t i64 = %d\n", i64)
+	t.Logf("\t chr = %c\n", chr)
+	t.Logf("\t str = (%d)%s\n", len(str), str)
+
+	rcd := NewApp01sqSample()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+
+	if rcd.Id != i64+1 {
+		t.Fatalf("Error: Invalid data for rcd.Id of %d!\n\n\n", rcd.Id)
+	}
+
+	if rcd.Flag != (1%2 == 1) {
+
+		t.Fatalf("Error: Invalid data for rcd.Flag of %v!\n\n\n", rcd.Flag)
+	}
+
+	if !rcd.Opt.Valid || rcd.Opt.Bool != (1%2 == 1) {
+
+		t.Fatalf("Error: Invalid data for rcd.Opt of %v!\n\n\n", rcd.Opt)
+	}
+
+	if !rcd.Memo.Valid || rcd.Memo.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.Memo of %v!\n\n\n", rcd.Memo)
+	}
+
+	if !rcd.Ratio.Valid || rcd.Ratio.Float64 != f64 {
+		t.Fatalf("Error: Invalid data for rcd.Ratio of %v!\n\n\n", rcd.Ratio)
+	}
+
+	if rcd.Big != i64 {
+		t.Fatalf("Error: Invalid data for rcd.Big of %v!\n\n\n", rcd.Big)
+	}
+
+	if !rcd.Ident.Valid || rcd.Ident.String != fmt.Sprintf("00000000-0000-0000-0000-%012d", 1) {
+
+		t.Fatalf("Error: Invalid data for rcd.Ident of %v!\n\n\n", rcd.Ident)
+	}
+
+	if rcd.Doc != fmt.Sprintf(`{"n": %d}`, 1) {
+
+		t.Fatalf("Error: Invalid data for rcd.Doc of %v!\n\n\n", rcd.Doc)
+	}
+
+	if rcd.Part != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.Part of %v!\n\n\n", rcd.Part)
+	}
+
+	if !rcd.Pct.Equal(decimal.New(int64(1), -2)) {
+		t.Fatalf("Error: Invalid data for rcd.Pct of %v!\n\n\n", rcd.Pct)
+	}
+
+	if rcd.Status != []string{"open", "closed", "on hold"}[1%3] {
+		t.Fatalf("Error: Invalid data for rcd.Status of %v!\n\n\n", rcd.Status)
+	}
+
+	if !bytes.Equal(rcd.Data, []byte(fmt.Sprintf("blob %d", 1))) {
+
+		t.Fatalf("Error: Invalid data for rcd.Data of %v!\n\n\n", rcd.Data)
+	}
+
+	t.Logf("Test.TestData() - End of Test\n\n\n")
+}
+
+// checkInvalid checks that the record is not valid because of the given field.
+func checkInvalidApp01sqSample(t *testing.T, rcd *App01sqSample, fn string) {
+
+	err := rcd.Validate()
+	if errs, ok := err.(FieldErrors); !ok || len(errs[fn]) == 0 {
+		t.Errorf("Error: %s should not be valid, but got: %v\n\n\n", fn, err)
+	}
+}
+
+func TestValidateApp01sqSample(t *testing.T) {
+	var err error
+
+	t.Logf("Test.Validate()...\n")
+
+	rcd := NewApp01sqSample()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+	if err = rcd.Validate(); err != nil {
+		t.Fatalf("Error: Test data should be valid: %s\n\n\n", err)
+	}
+
+	rcd.TestData(1)
+	rcd.Memo = sql.NullString{String: fmt.Sprintf("%0*d", 20+1, 0), Valid: true}
+	checkInvalidApp01sqSample(t, rcd, "Memo")
+	rcd.TestData(1)
+	rcd.Part = fmt.Sprintf("%0*d", 8+1, 0)
+	checkInvalidApp01sqSample(t, rcd, "Part")
+	rcd.TestData(1)
+	rcd.Pct = decimal.RequireFromString("0").Sub(decimal.New(1, 0))
+
+	checkInvalidApp01sqSample(t, rcd, "Pct")
+	rcd.TestData(1)
+	rcd.Pct = decimal.RequireFromString("100").Add(decimal.New(1, 0))
+
+	checkInvalidApp01sqSample(t, rcd, "Pct")
+	rcd.TestData(1)
+	rcd.Pct = decimal.New(1, -3)
+	checkInvalidApp01sqSample(t, rcd, "Pct")
+	rcd.TestData(1)
+	rcd.Pct = decimal.New(1, 3)
+	checkInvalidApp01sqSample(t, rcd, "Pct")
+	rcd.TestData(1)
+	rcd.Status = fmt.Sprintf("%0*d", 10+1, 0)
+	checkInvalidApp01sqSample(t, rcd, "Status")
+	rcd.TestData(1)
+	rcd.Status = "\x01"
+	checkInvalidApp01sqSample(t, rcd, "Status")
+
+	t.Logf("Test.Validate() - End of Test\n\n\n")
+}
+
+func TestToStringApp01sqSample(t *testing.T) {
+	var str string
+	var strRcd string
+
+	t.Logf("Test.ToStrings()...\n")
+
+	rcd := NewApp01sqSample()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+
+	strRcd = rcd.ToString("Id")
+	str = fmt.Sprintf("%d", rcd.Id)
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Id")
+	}
+	strRcd = rcd.ToString("Flag")
+	str = strconv.FormatBool(rcd.Flag)
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Flag")
+	}
+	strRcd = rcd.ToString("Opt")
+	if rcd.Opt.Valid {
+		str = strconv.FormatBool(rcd.Opt.Bool)
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Opt")
+	}
+	strRcd = rcd.ToString("Memo")
+	if rcd.Memo.Valid {
+		str = rcd.Memo.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Memo")
+	}
+	strRcd = rcd.ToString("Ratio")
+	if rcd.Ratio.Valid {
+		{
+			s := fmt.Sprintf("%.4f", rcd.Ratio.Float64)
+			str = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Ratio")
+	}
+	strRcd = rcd.ToString("Big")
+	str = fmt.Sprintf("%d", rcd.Big)
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Big")
+	}
+	strRcd = rcd.ToString("Ident")
+	if rcd.Ident.Valid {
+		str = rcd.Ident.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Ident")
+	}
+	strRcd = rcd.ToString("Stamp")
+	if rcd.Stamp.Valid {
+		str = rcd.Stamp.Time.Format(time.RFC3339Nano)
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Stamp")
+	}
+	strRcd = rcd.ToString("Doc")
+	str = rcd.Doc
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Doc")
+	}
+	strRcd = rcd.ToString("Part")
+	str = rcd.Part
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Part")
+	}
+	strRcd = rcd.ToString("Pct")
+	str = rcd.Pct.StringFixed(2)
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Pct")
+	}
+	strRcd = rcd.ToString("Status")
+	str = rcd.Status
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Status")
+	}
+	strRcd = rcd.ToString("Data")
+	str = base64.StdEncoding.EncodeToString(rcd.Data)
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Data")
+	}
+
+	t.Logf("Test.ToStrings() - End of Test\n\n\n")
+}
+
+func TestToStringsApp01sqSample(t *testing.T) {
+	var strs []string
+	var str string
+	var offset int
+
+	t.Logf("Test.ToStrings()...\n")
+
+	rcd := NewApp01sqSample()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+
+	strs = rcd.ToStrings()
+
+	offset = 0
+	str = fmt.Sprintf("%d", rcd.Id)
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Id", strs[offset])
+	}
+
+	offset = 1
+	str = strconv.FormatBool(rcd.Flag)
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Flag", strs[offset])
+	}
+
+	offset = 2
+	if rcd.Opt.Valid {
+		str = strconv.FormatBool(rcd.Opt.Bool)
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Opt", strs[offset])
+	}
+
+	offset = 3
+	if rcd.Memo.Valid {
+		str = rcd.Memo.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Memo", strs[offset])
+	}
+
+	offset = 4
+	if rcd.Ratio.Valid {
+		{
+			s := fmt.Sprintf("%.4f", rcd.Ratio.Float64)
+			str = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Ratio", strs[offset])
+	}
+
+	offset = 5
+	str = fmt.Sprintf("%d", rcd.Big)
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Big", strs[offset])
+	}
+
+	offset = 6
+	if rcd.Ident.Valid {
+		str = rcd.Ident.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Ident", strs[offset])
+	}
+
+	offset = 7
+	if rcd.Stamp.Valid {
+		str = rcd.Stamp.Time.Format(time.RFC3339Nano)
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Stamp", strs[offset])
+	}
+
+	offset = 8
+	str = rcd.Doc
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Doc", strs[offset])
+	}
+
+	offset = 9
+	str = rcd.Part
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Part", strs[offset])
+	}
+
+	offset = 10
+	str = rcd.Pct.StringFixed(2)
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Pct", strs[offset])
+	}
+
+	offset = 11
+	str = rcd.Status
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Status", strs[offset])
+	}
+
+	offset = 12
+	str = base64.StdEncoding.EncodeToString(rcd.Data)
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Data", strs[offset])
+	}
+
+	t.Logf("Test.ToStrings() - End of Test\n\n\n")
+}
+
+func TestNullApp01sqSample(t *testing.T) {
+	var err error
+	var text []byte
+
+	t.Logf("Test.Null()...\n")
+
+	// NULL is given as empty strings for CSV and null for JSON.
+	rcd := NewApp01sqSample()
+	rcd.TestData(1)
+	rcd.Opt = sql.NullBool{}
+	rcd.Memo = sql.NullString{}
+	rcd.Ratio = sql.NullFloat64{}
+	rcd.Ident = sql.NullString{}
+	rcd.Stamp = sql.NullTime{}
+	rcd.Data = nil
+	if err = rcd.Validate(); err != nil {
+		t.Fatalf("Error: NULL fields should be valid: %s\n\n\n", err)
+	}
+	strs := rcd.ToStrings()
+	if strs[2] != "" {
+		t.Fatalf("Error: NULL Opt should be empty, but is %s!\n\n\n", strs[2])
+	}
+	if strs[3] != "" {
+		t.Fatalf("Error: NULL Memo should be empty, but is %s!\n\n\n", strs[3])
+	}
+	if strs[4] != "" {
+		t.Fatalf("Error: NULL Ratio should be empty, but is %s!\n\n\n", strs[4])
+	}
+	if strs[6] != "" {
+		t.Fatalf("Error: NULL Ident should be empty, but is %s!\n\n\n", strs[6])
+	}
+	if strs[7] != "" {
+		t.Fatalf("Error: NULL Stamp should be empty, but is %s!\n\n\n", strs[7])
+	}
+	if text, err = rcd.JsonMarshal(); err != nil {
+		t.Fatalf("Error: JSON Marshal failed: %s\n\n\n", err)
+	}
+	if !strings.Contains(string(text), `"Opt":null`) {
+		t.Fatalf("Error: NULL Opt should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Memo":null`) {
+		t.Fatalf("Error: NULL Memo should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Ratio":null`) {
+		t.Fatalf("Error: NULL Ratio should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Ident":null`) {
+		t.Fatalf("Error: NULL Ident should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Stamp":null`) {
+		t.Fatalf("Error: NULL Stamp should be null in %s!\n\n\n", text)
+	}
+	rcd2 := NewApp01sqSample()
+	rcd2.TestData(2)
+	if err = rcd2.JsonUnmarshal(text); err != nil {
+		t.Fatalf("Error: JSON Unmarshal failed: %s\n\n\n", err)
+	}
+	if rcd.Compare(rcd2) != 0 {
+		t.Fatalf("Error: Record Mismatch: needed:%+v have:%+v\n", rcd, rcd2)
+	}
+	if rcd2.Opt.Valid {
+		t.Fatalf("Error: Opt should be NULL, but is %v!\n\n\n", rcd2.Opt)
+	}
+	if rcd2.Memo.Valid {
+		t.Fatalf("Error: Memo should be NULL, but is %v!\n\n\n", rcd2.Memo)
+	}
+	if rcd2.Ratio.Valid {
+		t.Fatalf("Error: Ratio should be NULL, but is %v!\n\n\n", rcd2.Ratio)
+	}
+	if rcd2.Ident.Valid {
+		t.Fatalf("Error: Ident should be NULL, but is %v!\n\n\n", rcd2.Ident)
+	}
+	if rcd2.Stamp.Valid {
+		t.Fatalf("Error: Stamp should be NULL, but is %v!\n\n\n", rcd2.Stamp)
+	}
+	// The values of the NULL fields are also kept.
+	rcd.TestData(1)
+	if text, err = rcd.JsonMarshal(); err != nil {
+		t.Fatalf("Error: JSON Marshal failed: %s\n\n\n", err)
+	}
+	if err = rcd2.JsonUnmarshal(text); err != nil {
+		t.Fatalf("Error: JSON Unmarshal failed: %s\n\n\n", err)
+	}
+	if rcd.Compare(rcd2) != 0 {
+		t.Fatalf("Error: Record Mismatch: needed:%+v have:%+v\n", rcd, rcd2)
+	}
+
+	t.Logf("Test.Null() - End of Test\n\n\n")
+}
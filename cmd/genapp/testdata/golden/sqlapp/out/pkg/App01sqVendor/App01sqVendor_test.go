@@ -0,0 +1,441 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// ioApp01sq contains all the functions
+// and data to interact with the SQL Database.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package App01sqVendor
+
+import (
+	"database/sql"
+
+	"fmt"
+
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+//============================================================================
+//                              Tests
+//============================================================================
+
+func TestTestDataApp01sqVendor(t *testing.T) {
+	var chr rune
+	var str string
+	var i64 int64
+
+	t.Logf("Test.TestData()...\n")
+	i64 = 1
+
+	chr = rune(i64 + 65)
+	str = string(chr)
+	t.Logf("\t i64 = %d\n", i64)
+	t.Logf("\t chr = %c\n", chr)
+	t.Logf("\t str = (%d)%s\n", len(str), str)
+
+	rcd := NewApp01sqVendor()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+
+	if rcd.Id != i64+1 {
+		t.Fatalf("Error: Invalid data for rcd.Id of %d!\n\n\n", rcd.Id)
+	}
+
+	if !rcd.Name.Valid || rcd.Name.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.Name of %v!\n\n\n", rcd.Name)
+	}
+
+	if !rcd.Addr1.Valid || rcd.Addr1.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.Addr1 of %v!\n\n\n", rcd.Addr1)
+	}
+
+	if !rcd.Addr2.Valid || rcd.Addr2.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.Addr2 of %v!\n\n\n", rcd.Addr2)
+	}
+
+	if !rcd.City.Valid || rcd.City.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.City of %v!\n\n\n", rcd.City)
+	}
+
+	if !rcd.State.Valid || rcd.State.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.State of %v!\n\n\n", rcd.State)
+	}
+
+	if !rcd.Zip.Valid || rcd.Zip.String != string(chr) {
+		t.Fatalf("Error: Invalid data for rcd.Zip of %v!\n\n\n", rcd.Zip)
+	}
+
+	if !rcd.Curbal.Valid || !rcd.Curbal.Decimal.Equal(decimal.New(int64(1), -2)) {
+		t.Fatalf("Error: Invalid data for rcd.Curbal of %v!\n\n\n", rcd.Curbal)
+	}
+
+	t.Logf("Test.TestData() - End of Test\n\n\n")
+}
+
+// checkInvalid checks that the record is not valid because of the given field.
+func checkInvalidApp01sqVendor(t *testing.T, rcd *App01sqVendor, fn string) {
+
+	err := rcd.Validate()
+	if errs, ok := err.(FieldErrors); !ok || len(errs[fn]) == 0 {
+		t.Errorf("Error: %s should not be valid, but got: %v\n\n\n", fn, err)
+	}
+}
+
+func TestValidateApp01sqVendor(t *testing.T) {
+	var err error
+
+	t.Logf("Test.Validate()...\n")
+
+	rcd := NewApp01sqVendor()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+	if err = rcd.Validate(); err != nil {
+		t.Fatalf("Error: Test data should be valid: %s\n\n\n", err)
+	}
+
+	rcd.TestData(1)
+	rcd.Name = sql.NullString{String: fmt.Sprintf("%0*d", 30+1, 0), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "Name")
+	rcd.TestData(1)
+	rcd.Addr1 = sql.NullString{String: fmt.Sprintf("%0*d", 30+1, 0), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "Addr1")
+	rcd.TestData(1)
+	rcd.Addr2 = sql.NullString{String: fmt.Sprintf("%0*d", 30+1, 0), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "Addr2")
+	rcd.TestData(1)
+	rcd.City = sql.NullString{String: fmt.Sprintf("%0*d", 20+1, 0), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "City")
+	rcd.TestData(1)
+	rcd.State = sql.NullString{String: fmt.Sprintf("%0*d", 10+1, 0), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "State")
+	rcd.TestData(1)
+	rcd.Zip = sql.NullString{String: fmt.Sprintf("%0*d", 15+1, 0), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "Zip")
+	rcd.TestData(1)
+	rcd.Curbal = decimal.NullDecimal{Decimal: decimal.New(1, -3), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "Curbal")
+	rcd.TestData(1)
+	rcd.Curbal = decimal.NullDecimal{Decimal: decimal.New(1, 13), Valid: true}
+	checkInvalidApp01sqVendor(t, rcd, "Curbal")
+
+	t.Logf("Test.Validate() - End of Test\n\n\n")
+}
+
+func TestToStringApp01sqVendor(t *testing.T) {
+	var str string
+	var strRcd string
+
+	t.Logf("Test.ToStrings()...\n")
+
+	rcd := NewApp01sqVendor()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+
+	strRcd = rcd.ToString("Id")
+	str = fmt.Sprintf("%d", rcd.Id)
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Id")
+	}
+	strRcd = rcd.ToString("Name")
+	if rcd.Name.Valid {
+		str = rcd.Name.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Name")
+	}
+	strRcd = rcd.ToString("Addr1")
+	if rcd.Addr1.Valid {
+		str = rcd.Addr1.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Addr1")
+	}
+	strRcd = rcd.ToString("Addr2")
+	if rcd.Addr2.Valid {
+		str = rcd.Addr2.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Addr2")
+	}
+	strRcd = rcd.ToString("City")
+	if rcd.City.Valid {
+		str = rcd.City.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "City")
+	}
+	strRcd = rcd.ToString("State")
+	if rcd.State.Valid {
+		str = rcd.State.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "State")
+	}
+	strRcd = rcd.ToString("Zip")
+	if rcd.Zip.Valid {
+		str = rcd.Zip.String
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Zip")
+	}
+	strRcd = rcd.ToString("Curbal")
+	if rcd.Curbal.Valid {
+		str = rcd.Curbal.Decimal.StringFixed(2)
+	} else {
+		str = ""
+	}
+
+	if str != strRcd {
+		t.Fatalf("Error: Invalid data for %s!\n\n\n", "Curbal")
+	}
+
+	t.Logf("Test.ToStrings() - End of Test\n\n\n")
+}
+
+func TestToStringsApp01sqVendor(t *testing.T) {
+	var strs []string
+	var str string
+	var offset int
+
+	t.Logf("Test.ToStrings()...\n")
+
+	rcd := NewApp01sqVendor()
+	if rcd == nil {
+		t.Fatalf("Error: Could not create rcd!\n\n\n")
+	}
+	rcd.TestData(1)
+
+	strs = rcd.ToStrings()
+
+	offset = 0
+	str = fmt.Sprintf("%d", rcd.Id)
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Id", strs[offset])
+	}
+
+	offset = 1
+	if rcd.Name.Valid {
+		str = rcd.Name.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Name", strs[offset])
+	}
+
+	offset = 2
+	if rcd.Addr1.Valid {
+		str = rcd.Addr1.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Addr1", strs[offset])
+	}
+
+	offset = 3
+	if rcd.Addr2.Valid {
+		str = rcd.Addr2.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Addr2", strs[offset])
+	}
+
+	offset = 4
+	if rcd.City.Valid {
+		str = rcd.City.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"City", strs[offset])
+	}
+
+	offset = 5
+	if rcd.State.Valid {
+		str = rcd.State.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"State", strs[offset])
+	}
+
+	offset = 6
+	if rcd.Zip.Valid {
+		str = rcd.Zip.String
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Zip", strs[offset])
+	}
+
+	offset = 7
+	if rcd.Curbal.Valid {
+		str = rcd.Curbal.Decimal.StringFixed(2)
+	} else {
+		str = ""
+	}
+
+	if str != strs[offset] {
+		t.Fatalf("Error: Invalid data for %s of %s!\n\n\n",
+			"Curbal", strs[offset])
+	}
+
+	t.Logf("Test.ToStrings() - End of Test\n\n\n")
+}
+
+func TestNullApp01sqVendor(t *testing.T) {
+	var err error
+	var text []byte
+
+	t.Logf("Test.Null()...\n")
+
+	// NULL is given as empty strings for CSV and null for JSON.
+	rcd := NewApp01sqVendor()
+	rcd.TestData(1)
+	rcd.Name = sql.NullString{}
+	rcd.Addr1 = sql.NullString{}
+	rcd.Addr2 = sql.NullString{}
+	rcd.City = sql.NullString{}
+	rcd.State = sql.NullString{}
+	rcd.Zip = sql.NullString{}
+	rcd.Curbal = decimal.NullDecimal{}
+	if err = rcd.Validate(); err != nil {
+		t.Fatalf("Error: NULL fields should be valid: %s\n\n\n", err)
+	}
+	strs := rcd.ToStrings()
+	if strs[1] != "" {
+		t.Fatalf("Error: NULL Name should be empty, but is %s!\n\n\n", strs[1])
+	}
+	if strs[2] != "" {
+		t.Fatalf("Error: NULL Addr1 should be empty, but is %s!\n\n\n", strs[2])
+	}
+	if strs[3] != "" {
+		t.Fatalf("Error: NULL Addr2 should be empty, but is %s!\n\n\n", strs[3])
+	}
+	if strs[4] != "" {
+		t.Fatalf("Error: NULL City should be empty, but is %s!\n\n\n", strs[4])
+	}
+	if strs[5] != "" {
+		t.Fatalf("Error: NULL State should be empty, but is %s!\n\n\n", strs[5])
+	}
+	if strs[6] != "" {
+		t.Fatalf("Error: NULL Zip should be empty, but is %s!\n\n\n", strs[6])
+	}
+	if strs[7] != "" {
+		t.Fatalf("Error: NULL Curbal should be empty, but is %s!\n\n\n", strs[7])
+	}
+	if text, err = rcd.JsonMarshal(); err != nil {
+		t.Fatalf("Error: JSON Marshal failed: %s\n\n\n", err)
+	}
+	if !strings.Contains(string(text), `"Name":null`) {
+		t.Fatalf("Error: NULL Name should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Addr1":null`) {
+		t.Fatalf("Error: NULL Addr1 should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Addr2":null`) {
+		t.Fatalf("Error: NULL Addr2 should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"City":null`) {
+		t.Fatalf("Error: NULL City should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"State":null`) {
+		t.Fatalf("Error: NULL State should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Zip":null`) {
+		t.Fatalf("Error: NULL Zip should be null in %s!\n\n\n", text)
+	}
+	if !strings.Contains(string(text), `"Curbal":null`) {
+		t.Fatalf("Error: NULL Curbal should be null in %s!\n\n\n", text)
+	}
+	rcd2 := NewApp01sqVendor()
+	rcd2.TestData(2)
+	if err = rcd2.JsonUnmarshal(text); err != nil {
+		t.Fatalf("Error: JSON Unmarshal failed: %s\n\n\n", err)
+	}
+	if rcd.Compare(rcd2) != 0 {
+		t.Fatalf("Error: Record Mismatch: needed:%+v have:%+v\n", rcd, rcd2)
+	}
+	if rcd2.Name.Valid {
+		t.Fatalf("Error: Name should be NULL, but is %v!\n\n\n", rcd2.Name)
+	}
+	if rcd2.Addr1.Valid {
+		t.Fatalf("Error: Addr1 should be NULL, but is %v!\n\n\n", rcd2.Addr1)
+	}
+	if rcd2.Addr2.Valid {
+		t.Fatalf("Error: Addr2 should be NULL, but is %v!\n\n\n", rcd2.Addr2)
+	}
+	if rcd2.City.Valid {
+		t.Fatalf("Error: City should be NULL, but is %v!\n\n\n", rcd2.City)
+	}
+	if rcd2.State.Valid {
+		t.Fatalf("Error: State should be NULL, but is %v!\n\n\n", rcd2.State)
+	}
+	if rcd2.Zip.Valid {
+		t.Fatalf("Error: Zip should be NULL, but is %v!\n\n\n", rcd2.Zip)
+	}
+	if rcd2.Curbal.Valid {
+		t.Fatalf("Error: Curbal should be NULL, but is %v!\n\n\n", rcd2.Curbal)
+	}
+	// The values of the NULL fields are also kept.
+	rcd.TestData(1)
+	if text, err = rcd.JsonMarshal(); err != nil {
+		t.Fatalf("Error: JSON Marshal failed: %s\n\n\n", err)
+	}
+	if err = rcd2.JsonUnmarshal(text); err != nil {
+		t.Fatalf("Error: JSON Unmarshal failed: %s\n\n\n", err)
+	}
+	if rcd.Compare(rcd2) != 0 {
+		t.Fatalf("Error: Record Mismatch: needed:%+v have:%+v\n", rcd, rcd2)
+	}
+
+	t.Logf("Test.Null() - End of Test\n\n\n")
+}
@@ -0,0 +1,66 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Authentication Package
+
+// This package authenticates each request before it is passed
+// to the mux. The mode of authentication is chosen when the
+// application is generated and is one of:
+//  none        all requests are accepted
+//  basic       HTTP Basic authentication against the users table
+//  session     a login page which sets a session cookie checked
+//              against the users table
+//  apikey      an API key in the X-API-Key header
+
+// Each user or API key is given a role which is passed on to the
+// handlers in the request's context. Role() returns it so that the
+// handlers can check what the role is permitted to do.
+
+// An example of how to use this package is:
+//  a := auth.NewAuth(...)
+//  h.Use(a.Middleware)     // h is the httpServer.HttpServer
+//  NOTE: Any code here will never be executed.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package auth
+
+import (
+	"net/http"
+)
+
+//----------------------------------------------------------------------------
+//                              Authentication
+//----------------------------------------------------------------------------
+
+// Auth authenticates the requests to the server.
+type Auth struct {
+}
+
+// Middleware returns the handler unchanged since no authentication
+// was generated.
+func (a *Auth) Middleware(next http.Handler) http.Handler {
+	return next
+}
+
+//----------------------------------------------------------------------------
+//                                  Roles
+//----------------------------------------------------------------------------
+
+// roleKey is the key of the role in the request's context.
+type roleKey struct{}
+
+// Role returns the role of the user who made the request. It is
+// empty if the request was not authenticated.
+func Role(r *http.Request) string {
+	role, _ := r.Context().Value(roleKey{}).(string)
+	return role
+}
+
+//----------------------------------------------------------------------------
+//                                  N e w
+//----------------------------------------------------------------------------
+
+func NewAuth() *Auth {
+	return &Auth{}
+}
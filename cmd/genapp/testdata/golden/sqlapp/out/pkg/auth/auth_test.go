@@ -0,0 +1,65 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Functions to test the auth package.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//                         Test Support Functions
+//----------------------------------------------------------------------------
+
+// hndlrOK answers every request which gets past the authentication
+// with the role of the request.
+func hndlrOK(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, Role(r))
+}
+
+// role returns the role answered by hndlrOK.
+func role(resp *http.Response) string {
+	body, _ := ioutil.ReadAll(resp.Body)
+	return string(body)
+}
+
+// serve executes the request returning its response.
+func serve(h http.Handler, r *http.Request) *http.Response {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Result()
+}
+
+//============================================================================
+//                              Tests
+//============================================================================
+
+//----------------------------------------------------------------------------
+//                              Middleware
+//----------------------------------------------------------------------------
+
+func TestMiddleware(t *testing.T) {
+	var resp *http.Response
+
+	t.Logf("TestMiddleware()...\n")
+	h := NewAuth().Middleware(http.HandlerFunc(hndlrOK))
+
+	resp = serve(h, httptest.NewRequest(http.MethodGet, "/", nil))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Error: Invalid Status Code of %d, needed 200\n", resp.StatusCode)
+	}
+	if r := role(resp); r != "" {
+		t.Fatalf("Error: Invalid role of %q, needed none\n", r)
+	}
+
+	t.Logf("TestMiddleware() - End of Test\n\n\n")
+}
@@ -0,0 +1,175 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+//  Handle HTTP Events
+
+// Notes:
+//  *   All static (ie non-changing) files should be served from the 'static'
+//      subdirectory.
+//  *   Perms is only generated if the requests are authenticated since
+//      the role of the user comes from the authentication.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package hndlrApp01sq
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	_ "io/ioutil"
+	"log"
+	"net/http"
+	_ "os"
+	"sort"
+	"strings"
+
+	"app01sq/pkg/auth"
+	"github.com/2kranki/go_util"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//----------------------------------------------------------------------------
+//                             Permissions
+//----------------------------------------------------------------------------
+
+// Ops are the operations which may be permitted on a table.
+var Ops = []string{"list", "show", "insert", "update", "delete", "load", "save"}
+
+// Perms are the operations that each role is permitted indexed by
+// table and then role. A table which is not present may be used by
+// everyone.
+var Perms = map[string]map[string][]string{}
+
+// Tables are the names of the tables.
+var Tables = []string{"Customer", "Vendor", "Sample"}
+
+// Permitted returns true if the role may perform the operation on
+// the table.
+func Permitted(table, role, op string) bool {
+
+	roles, ok := Perms[table]
+	if !ok {
+		return true
+	}
+	for _, o := range roles[role] {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// TablePerms returns the operations permitted to the role indexed by
+// table and then operation for use in the templates.
+func TablePerms(role string) map[string]map[string]bool {
+
+	perms := map[string]map[string]bool{}
+	for _, t := range Tables {
+		perms[t] = map[string]bool{}
+		for _, op := range Ops {
+			perms[t][op] = Permitted(t, role, op)
+		}
+	}
+	return perms
+}
+
+//----------------------------------------------------------------------------
+//                     App01sq Templates
+//----------------------------------------------------------------------------
+
+type TmplsApp01sq struct {
+	tmplsDir string
+	Tmpls    *template.Template
+}
+
+func (TmplsApp01sq) Title(i interface{}) string {
+	return "Title() - NOT Implemented"
+}
+
+func (TmplsApp01sq) Body(i interface{}) string {
+	return "Body() - NOT Implemented"
+}
+
+func (t *TmplsApp01sq) SetTmplsDir(d string) {
+	t.tmplsDir = d
+} //----------------------------------------------------------------------------
+//                             Main Display
+//----------------------------------------------------------------------------
+
+// Display the main menu with any needed messages. Only the actions
+// permitted to the role of the request are shown.
+func (h *TmplsApp01sq) MainDisplay(w http.ResponseWriter, r *http.Request, msg string) {
+	var err error
+	var name = "App01sq.main.menu.gohtml"
+
+	var str strings.Builder
+
+	log.Printf("App01sq.MainDisplay(%s)\n", msg)
+	log.Printf("\tname: %s\n", name)
+	w2 := io.MultiWriter(w, &str)
+
+	data := struct {
+		Msg   string
+		Perms map[string]map[string]bool
+	}{msg, TablePerms(auth.Role(r))}
+
+	log.Printf("\tData: %+v\n", data)
+
+	log.Printf("\tExecuting template: %s\n", name)
+	err = h.Tmpls.ExecuteTemplate(w2, name, data)
+	if err != nil {
+		fmt.Fprintf(w, err.Error())
+	}
+
+	log.Printf("\t output: %s\n", str.String())
+	log.Printf("...end App01sq.MainDisplay(%s)\n", util.ErrorString(err))
+
+}
+
+//----------------------------------------------------------------------------
+//                           Setup Templates
+//----------------------------------------------------------------------------
+
+// SetupTmpls initializes the functions used in the templates
+// and loads them.
+func (t *TmplsApp01sq) SetupTmpls() {
+
+	var templates []*template.Template
+	var tt *template.Template
+	var names []string
+	var name string
+
+	log.Printf("\tSetupTmpls(%s/*.gohtml)\n", t.tmplsDir)
+
+	funcs := map[string]interface{}{"Title": t.Title, "Body": t.Body}
+	path := t.tmplsDir + "/*.gohtml"
+	t.Tmpls = template.Must(template.New("tmpls").Funcs(funcs).ParseGlob(path))
+	templates = t.Tmpls.Templates()
+	for _, tt = range templates {
+		names = append(names, tt.Name())
+	}
+	sort.Strings(names)
+	for _, name = range names {
+		log.Printf("\t\t template: %s\n", name)
+	}
+	log.Printf("\tend of SetupTmpls()\n")
+}
+
+//----------------------------------------------------------------------------
+//                                  N e w
+//----------------------------------------------------------------------------
+
+func NewTmplsApp01sq(dir string) *TmplsApp01sq {
+	t := &TmplsApp01sq{}
+	if dir == "" {
+		t.tmplsDir = "./tmpl"
+	} else {
+		t.tmplsDir = dir
+	}
+	return t
+}
+
+func init() {
+
+}
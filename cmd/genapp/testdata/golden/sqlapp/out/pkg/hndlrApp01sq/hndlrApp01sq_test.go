@@ -0,0 +1,52 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// ioApp01sq contains all the functions
+// and data to interact with the SQL Database.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package hndlrApp01sq
+
+import (
+	"testing"
+)
+
+//============================================================================
+//                              Tests
+//============================================================================
+
+func TestSomething(t *testing.T) {
+
+	t.Logf("TestSomething()...\n")
+
+	t.Logf("TestSomething() - End of Test\n\n\n")
+}
+
+func TestPermitted(t *testing.T) {
+
+	t.Logf("TestPermitted()...\n")
+	Perms["Test"] = map[string][]string{"clerk": {"list", "show"}}
+	Tables = append(Tables, "Test")
+	defer func() {
+		delete(Perms, "Test")
+		Tables = Tables[:len(Tables)-1]
+	}()
+
+	if !Permitted("Test", "clerk", "list") || Permitted("Test", "clerk", "delete") {
+		t.Fatalf("Error: clerk should only be permitted list and show\n")
+	}
+	if Permitted("Test", "", "list") || Permitted("Test", "manager", "show") {
+		t.Fatalf("Error: Other roles should not be permitted anything\n")
+	}
+	if !Permitted("Missing", "", "delete") {
+		t.Fatalf("Error: Tables without permissions should be permitted everything\n")
+	}
+
+	perms := TablePerms("clerk")
+	if len(perms) != len(Tables) || !perms["Test"]["show"] || perms["Test"]["insert"] {
+		t.Fatalf("Error: Invalid table permissions: %v\n", perms)
+	}
+
+	t.Logf("TestPermitted() - End of Test\n\n\n")
+}
@@ -0,0 +1,1418 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+//  Handle HTTP Events
+
+// Notes:
+//  *   All static (ie non-changing) files should be served from the 'static'
+//      subdirectory.
+
+// Generated: Mon Jan  1, 2001 00:00
+
+package hndlrApp01sqVendor
+
+import (
+	"database/sql"
+
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/2kranki/go_util"
+
+	"app01sq/pkg/App01sqVendor"
+	"app01sq/pkg/auth"
+	"app01sq/pkg/hndlrApp01sq"
+	_ "github.com/mattn/go-sqlite3"
+
+	"app01sq/pkg/ioApp01sqVendor"
+	// <<user:imports>>
+	// <</user>>
+)
+
+//============================================================================
+//                              Miscellaneous
+//============================================================================
+
+// apiRowOps and apiTableOps are the operations of the JSON API methods.
+var apiRowOps = map[string]string{"GET": "show", "PUT": "update", "DELETE": "delete"}
+var apiTableOps = map[string]string{"GET": "list", "POST": "insert"}
+
+//============================================================================
+//                        Handlers for App01sq.Vendor
+//============================================================================
+
+type HandlersApp01sqVendor struct {
+	mu          sync.Mutex
+	db          *ioApp01sqVendor.IO_App01sqVendor
+	rowsPerPage int
+	Tmpls       *hndlrApp01sq.TmplsApp01sq
+}
+
+//----------------------------------------------------------------------------
+//                             Accessors
+//----------------------------------------------------------------------------
+
+func (h *HandlersApp01sqVendor) DB() *ioApp01sqVendor.IO_App01sqVendor {
+	return h.db
+}
+
+func (h *HandlersApp01sqVendor) SetDB(db *ioApp01sqVendor.IO_App01sqVendor) {
+	h.db = db
+}
+
+func (h *HandlersApp01sqVendor) RowsPerPage() int {
+	return h.rowsPerPage
+}
+
+func (h *HandlersApp01sqVendor) SetRowsPerPage(r int) {
+	h.rowsPerPage = r
+}
+
+//----------------------------------------------------------------------------
+//                             Permitted
+//----------------------------------------------------------------------------
+
+// Permitted returns true if the role of the request may perform the
+// operation on the table. Otherwise, it responds with 403 Forbidden.
+func (h *HandlersApp01sqVendor) Permitted(w http.ResponseWriter, r *http.Request, op string) bool {
+
+	if hndlrApp01sq.Permitted("Vendor", auth.Role(r), op) {
+		return true
+	}
+
+	log.Printf("\thndlrVendor.Permitted(%s) - %s denied\n", op, auth.Role(r))
+
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		h.ApiError(w, http.StatusForbidden, http.StatusText(403))
+	} else {
+		http.Error(w, http.StatusText(403), http.StatusForbidden)
+	}
+	return false
+}
+
+//----------------------------------------------------------------------------
+//                           Setup Handlers
+//----------------------------------------------------------------------------
+
+// SetupHandlers creates a Handler object and sets up each of the handlers
+// with it given a mux.
+func (h *HandlersApp01sqVendor) SetupHandlers(mux *http.ServeMux) {
+
+	log.Printf("\thndlrVendor.SetupHandlers()\n")
+
+	mux.HandleFunc("/Vendor/list/first", h.ListFirst)
+	mux.HandleFunc("/Vendor", h.ListFirst)
+	mux.HandleFunc("/Vendor/list/last", h.ListLast)
+	mux.HandleFunc("/Vendor/list/next", h.ListNext)
+	mux.HandleFunc("/Vendor/list/prev", h.ListPrev)
+	mux.HandleFunc("/Vendor/delete", h.RowDelete)
+	mux.HandleFunc("/Vendor/empty", h.RowEmpty)
+	mux.HandleFunc("/Vendor/find", h.RowFind)
+	mux.HandleFunc("/Vendor/first", h.RowFirst)
+	mux.HandleFunc("/Vendor/form", h.RowForm)
+	mux.HandleFunc("/Vendor/insert", h.RowInsert)
+	mux.HandleFunc("/Vendor/last", h.RowLast)
+	mux.HandleFunc("/Vendor/next", h.RowNext)
+	mux.HandleFunc("/Vendor/prev", h.RowPrev)
+	mux.HandleFunc("/Vendor/show", h.RowShow)
+	mux.HandleFunc("/Vendor/update", h.RowUpdate)
+	mux.HandleFunc("/Vendor/table/create", h.TableCreate)
+	mux.HandleFunc("/Vendor/table/load/csv", h.TableLoadCSV)
+	mux.HandleFunc("/Vendor/table/load/test", h.TableLoadTestData)
+	mux.HandleFunc("/Vendor/table/save/csv", h.TableSaveCSV)
+	mux.HandleFunc("/api/Vendor", h.ApiTable)
+	mux.HandleFunc("/api/Vendor/", h.ApiRow)
+
+	log.Printf("\tend of hndlrVendor.SetupHandlers()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                                  New
+//----------------------------------------------------------------------------
+
+// New creates a new Handlers object given the parameters needed by the handlers
+// and returns it to the caller if successful.  If it fails to properly create
+// the handlers then it must fail rather than return an error indicator.
+func NewHandlersApp01sqVendor(db *ioApp01sqVendor.IO_App01sqVendor, rowsPerPage int, mux *http.ServeMux) *HandlersApp01sqVendor {
+	var h *HandlersApp01sqVendor
+
+	h = &HandlersApp01sqVendor{db: db, rowsPerPage: rowsPerPage}
+	if h == nil {
+		log.Fatalf("Error: Unable to allocate Handlers for hndlrApp01sqVendor!\n")
+	}
+	h.SetupHandlers(mux)
+
+	return h
+}
+
+//============================================================================
+//                              JSON API Handlers
+//============================================================================
+
+// The JSON API provides the same maintenance as the forms for front-ends
+// such as React or Angular. A row is addressed by its key(s) which are
+// given in key order as the path segments following "/api/Vendor/".
+// All errors are returned as a JSON object, {"error":"<message>"}.
+
+//----------------------------------------------------------------------------
+//                             API Error
+//----------------------------------------------------------------------------
+
+// ApiError writes a JSON error response with the given status.
+func (h *HandlersApp01sqVendor) ApiError(w http.ResponseWriter, status int, msg string) {
+
+	log.Printf("\thndlrVendor.ApiError(%d) - %s\n", status, msg)
+
+	text, err := json.Marshal(map[string]string{"error": msg})
+	if err != nil {
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+	h.ApiWrite(w, status, text)
+}
+
+//----------------------------------------------------------------------------
+//                             API Keys
+//----------------------------------------------------------------------------
+
+// ApiKeys sets the key(s) of rcd from the path of the URL.
+func (h *HandlersApp01sqVendor) ApiKeys(r *http.Request, rcd *App01sqVendor.App01sqVendor) error {
+	var err error
+
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/Vendor/")
+	keys := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	for i, key := range keys {
+		if keys[i], err = url.PathUnescape(key); err != nil {
+			return err
+		}
+	}
+
+	return rcd.SetKeysFromStrings(keys)
+}
+
+//----------------------------------------------------------------------------
+//                             API Write
+//----------------------------------------------------------------------------
+
+// ApiWrite writes a JSON response with the given status.
+func (h *HandlersApp01sqVendor) ApiWrite(w http.ResponseWriter, status int, text []byte) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if text != nil {
+		w.Write(text)
+	}
+}
+
+//----------------------------------------------------------------------------
+//                             API Row
+//----------------------------------------------------------------------------
+
+// ApiRow handles the requests for one row of the table given its key(s).
+// GET returns the row, PUT replaces it from the JSON body and DELETE
+// deletes it.
+func (h *HandlersApp01sqVendor) ApiRow(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var rcd App01sqVendor.App01sqVendor
+	var body []byte
+	var text []byte
+
+	log.Printf("hndlrVendor.ApiRow(%s, %s)\n", r.Method, r.URL.Path)
+
+	if r.Method != "GET" && r.Method != "PUT" && r.Method != "DELETE" {
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		h.ApiError(w, http.StatusMethodNotAllowed, http.StatusText(405))
+		return
+	}
+
+	if !h.Permitted(w, r, apiRowOps[r.Method]) {
+		return
+	}
+
+	// Get the key(s).
+	if err = h.ApiKeys(r, &rcd); err != nil {
+		h.ApiError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The row must not change between finding it and updating it.
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// All of the methods require that the row exist.
+	err = h.db.RowFind(&rcd)
+	if err == sql.ErrNoRows {
+		h.ApiError(w, http.StatusNotFound, "Row NOT Found!")
+		return
+	}
+	if err != nil {
+		h.ApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	switch r.Method {
+	case "PUT":
+		// Fields missing from the body are left as they are and the key(s)
+		// can not be changed.
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			h.ApiError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		key := rcd.Key()
+		if err = rcd.JsonUnmarshal(body); err != nil {
+			h.ApiError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if rcd.Key() != key {
+			h.ApiError(w, http.StatusBadRequest, "Row keys can not be changed!")
+			return
+		}
+		if err = rcd.Validate(); err != nil {
+			h.ApiError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err = h.db.RowUpdate(&rcd); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	case "DELETE":
+		if err = h.db.RowDelete(&rcd); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.ApiWrite(w, http.StatusNoContent, nil)
+
+		log.Printf("...end hndlrVendor.ApiRow(204)\n")
+
+		return
+	}
+
+	if text, err = rcd.JsonMarshal(); err != nil {
+		h.ApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.ApiWrite(w, http.StatusOK, text)
+
+	log.Printf("...end hndlrVendor.ApiRow(200)\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             API Table
+//----------------------------------------------------------------------------
+
+// ApiTable handles the requests for the table as a whole. GET returns
+// a page of rows given by the optional "offset" and "limit" query
+// parameters which default to the first page. POST adds the row given
+// in the JSON body and returns it.
+func (h *HandlersApp01sqVendor) ApiTable(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var rcd App01sqVendor.App01sqVendor
+	var rcds []App01sqVendor.App01sqVendor
+	var body []byte
+	var text []byte
+	var offset int
+	var limit = h.rowsPerPage
+
+	log.Printf("hndlrVendor.ApiTable(%s)\n", r.Method)
+
+	if op, ok := apiTableOps[r.Method]; ok && !h.Permitted(w, r, op) {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		if s := r.FormValue("offset"); s != "" {
+			if offset, err = strconv.Atoi(s); err != nil || offset < 0 {
+				h.ApiError(w, http.StatusBadRequest, "Invalid offset!")
+				return
+			}
+		}
+		if s := r.FormValue("limit"); s != "" {
+			if limit, err = strconv.Atoi(s); err != nil || limit < 1 {
+				h.ApiError(w, http.StatusBadRequest, "Invalid limit!")
+				return
+			}
+		}
+		if rcds, err = h.db.RowPage(offset, limit); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if text, err = json.Marshal(rcds); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.ApiWrite(w, http.StatusOK, text)
+
+	case "POST":
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			h.ApiError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err = rcd.JsonUnmarshal(body); err != nil {
+			h.ApiError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err = rcd.Validate(); err != nil {
+			h.ApiError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if err = h.db.RowInsert(&rcd); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		// RowInsert() returns any key assigned by the database.
+		if err = h.db.RowFind(&rcd); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if text, err = rcd.JsonMarshal(); err != nil {
+			h.ApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		h.ApiWrite(w, http.StatusCreated, text)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		h.ApiError(w, http.StatusMethodNotAllowed, http.StatusText(405))
+		return
+	}
+
+	log.Printf("...end hndlrVendor.ApiTable()\n")
+
+}
+
+//============================================================================
+//                              List Form Handlers
+//============================================================================
+
+//----------------------------------------------------------------------------
+//                             List First
+//----------------------------------------------------------------------------
+
+// ListFirst displays the first page of rows.
+func (h *HandlersApp01sqVendor) ListFirst(w http.ResponseWriter, r *http.Request) {
+
+	log.Printf("hndlrVendor.ListFirst(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.ListFirst(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "list") {
+		return
+	}
+
+	// Display the row in the form.
+	h.ListShow(w, 0, "")
+
+	log.Printf("...end hndlrVendor.ListFirst()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             List Last
+//----------------------------------------------------------------------------
+
+// ListLast displays the last page of rows.
+func (h *HandlersApp01sqVendor) ListLast(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var offset int
+
+	log.Printf("hndlrVendor.ListLast(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.ListLast(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "list") {
+		return
+	}
+
+	// Calculate the offset.
+	offset, err = h.db.TableCount()
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.ListLast(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+	}
+	offset -= h.rowsPerPage
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Display the row in the form.
+	h.ListShow(w, offset, "")
+
+	log.Printf("...end hndlrVendor.ListLast()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             List Next
+//----------------------------------------------------------------------------
+
+// ListNext displays the next page of rows.
+func (h *HandlersApp01sqVendor) ListNext(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var offset int
+	var cTable int
+
+	log.Printf("hndlrVendor.ListNext(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.ListNext(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "list") {
+		return
+	}
+
+	// Calculate the offset.
+	cTable, err = h.db.TableCount()
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.ListLast(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+	}
+	offset, _ = strconv.Atoi(r.FormValue("offset"))
+	offset += h.rowsPerPage
+	if offset < 0 || offset > cTable {
+		offset = 0
+	}
+
+	// Display the row in the form.
+	h.ListShow(w, offset, "")
+
+	log.Printf("...end hndlrVendor.ListNext()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             List Prev
+//----------------------------------------------------------------------------
+
+// ListPrev displays the next page of rows.
+func (h *HandlersApp01sqVendor) ListPrev(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var offset int
+	var begin int
+	var cTable int
+
+	log.Printf("hndlrVendor.ListPrev(%s, %s)\n", r.Method, r.FormValue("offset"))
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.ListPrev(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "list") {
+		return
+	}
+
+	// Calculate the offset.
+	cTable, err = h.db.TableCount()
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.ListLast(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+	}
+	begin, _ = strconv.Atoi(r.FormValue("offset"))
+	offset = begin - h.rowsPerPage
+	if offset < 0 {
+		if begin > 0 {
+			offset = 0
+		} else {
+			offset = cTable - h.rowsPerPage
+			if offset < 0 {
+				offset = 0
+			}
+		}
+	}
+
+	// Display the row in the form.
+	h.ListShow(w, offset, "")
+
+	log.Printf("...end hndlrVendor.ListPrev()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             List Show
+//----------------------------------------------------------------------------
+
+// ListShow displays a list page given a starting offset.
+func (h *HandlersApp01sqVendor) ListShow(w http.ResponseWriter, offset int, msg string) {
+	var err error
+	var rcds []App01sqVendor.App01sqVendor
+	var name = "App01sq.Vendor.list.gohtml"
+	var str strings.Builder
+
+	log.Printf("hndlrVendor.ListShow(%d)\n", offset)
+	log.Printf("\tname: %s\n", name)
+	w2 := io.MultiWriter(w, &str)
+
+	// Get the records to display
+	rcds, err = h.db.RowPage(offset, h.rowsPerPage)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.ListShow(Error:400) - No Key\n")
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	data := struct {
+		Rcds   []App01sqVendor.App01sqVendor
+		Offset int
+		Msg    string
+	}{rcds, offset, msg}
+
+	log.Printf("\tData: %+v\n", data)
+
+	log.Printf("\tExecuting template: %s\n", name)
+	err = h.Tmpls.Tmpls.ExecuteTemplate(w2, name, data)
+	if err != nil {
+		fmt.Fprintf(w, err.Error())
+	}
+
+	log.Printf("\t output: %s\n", str.String())
+	log.Printf("...end hndlrVendor.ListShow(%s)\n", util.ErrorString(err))
+}
+
+//============================================================================
+//                             Row Form Handlers
+//============================================================================
+
+//----------------------------------------------------------------------------
+//                             Row Delete
+//----------------------------------------------------------------------------
+
+// RowDelete handles an delete request which comes from the row display form.
+func (h *HandlersApp01sqVendor) RowDelete(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var rcd App01sqVendor.App01sqVendor
+	var i int
+	var key string
+
+	log.Printf("hndlrVendor.RowDelete(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.RowDelete(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "delete") {
+		return
+	}
+
+	// Get the key(s).
+	i = 0
+	key = r.FormValue(fmt.Sprintf("key%d", i))
+	rcd.Id, _ = strconv.ParseInt(key, 0, 64)
+
+	i++
+
+	log.Printf("\t rcd: %+v\n", rcd)
+
+	// Delete the row with data given.
+	err = h.db.RowDelete(&rcd)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowDelete(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Get the next row in the form with status message and display it.
+	err = h.db.RowNext(&rcd)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowDelete(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+	h.RowDisplay(w, r, &rcd, "Row deleted!")
+
+	log.Printf("...end hndlrVendor.RowDelete(%s)\n", util.ErrorString(err))
+
+}
+
+//----------------------------------------------------------------------------
+//                                Row Display
+//----------------------------------------------------------------------------
+
+// RowDisplay displays the given record with only the actions permitted
+// to the role of the request.
+func (h *HandlersApp01sqVendor) RowDisplay(w http.ResponseWriter, r *http.Request, rcd *App01sqVendor.App01sqVendor, msg string) {
+	h.RowDisplayErrors(w, r, rcd, msg, nil)
+}
+
+// RowDisplayErrors displays the given record the same as RowDisplay along
+// with the message of each field which is not valid. If there are any
+// field errors, the response status is 400.
+func (h *HandlersApp01sqVendor) RowDisplayErrors(w http.ResponseWriter, r *http.Request, rcd *App01sqVendor.App01sqVendor, msg string,
+	errs App01sqVendor.FieldErrors) {
+	var err error
+	var str strings.Builder
+
+	log.Printf("hndlrVendor.RowDisplayErrors(%+v, %s, %v)\n", rcd, msg, errs)
+	w2 := io.MultiWriter(w, &str)
+
+	if len(errs) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if h.Tmpls != nil {
+		perms := hndlrApp01sq.TablePerms(auth.Role(r))["Vendor"]
+		data := struct {
+			Rcd    *App01sqVendor.App01sqVendor
+			Msg    string
+			Perms  map[string]bool
+			Errors App01sqVendor.FieldErrors
+		}{rcd, msg, perms, errs}
+		name := "App01sq.Vendor.form.gohtml"
+		log.Printf("\tRcd: %+v\n", data.Rcd)
+		log.Printf("\tMsg: %s\n", data.Msg)
+		log.Printf("\tname: %s\n", name)
+		log.Printf("\tExecuting template: %s\n", name)
+		err = h.Tmpls.Tmpls.ExecuteTemplate(w2, name, data)
+		if err != nil {
+			fmt.Fprintf(w, err.Error())
+		}
+	}
+
+	log.Printf("\t output: %s\n", str.String())
+	log.Printf("...end hndlrVendor.RowDisplayErrors(%s)\n", util.ErrorString(err))
+}
+
+//----------------------------------------------------------------------------
+//                             Row Empty
+//----------------------------------------------------------------------------
+
+// RowEmpty displays the table row form with an empty row.
+func (h *HandlersApp01sqVendor) RowEmpty(w http.ResponseWriter, r *http.Request) {
+	var rcd App01sqVendor.App01sqVendor
+
+	log.Printf("hndlrVendor.RowEmpty(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.RowEmpty(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Get the row to display and display it.
+	h.RowDisplay(w, r, &rcd, "")
+
+	log.Printf("...end hndlrVendor.RowEmpty()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Find
+//----------------------------------------------------------------------------
+
+// RowFind handles displaying of the table row form display.
+func (h *HandlersApp01sqVendor) RowFind(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var rcd App01sqVendor.App01sqVendor
+	var msg string
+	var i int
+	var key string
+
+	log.Printf("hndlrVendor.RowFind(%s, %s)\n", r.Method, r.FormValue("key"))
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.RowFind(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Get the key(s).
+	i = 0
+	key = r.FormValue(fmt.Sprintf("key%d", i))
+	rcd.Id, _ = strconv.ParseInt(key, 0, 64)
+
+	i++
+
+	// Get the row and display it.
+	err = h.db.RowFind(&rcd)
+	if err != nil {
+		msg = "Row NOT Found!"
+		err = h.db.RowFirst(&rcd)
+	}
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowFind(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+	h.RowDisplay(w, r, &rcd, msg)
+
+	log.Printf("...end hndlrVendor.RowFind()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row First
+//----------------------------------------------------------------------------
+
+// RowFirst displays the first row.
+func (h *HandlersApp01sqVendor) RowFirst(w http.ResponseWriter, r *http.Request) {
+	var rcd App01sqVendor.App01sqVendor
+	var err error
+
+	log.Printf("hndlrVendor.RowFirst(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.RowFirst(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Get the next row and display it.
+	err = h.db.RowFirst(&rcd)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowFirst(Error:400) - No Key\n")
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+	h.RowDisplay(w, r, &rcd, "")
+
+	log.Printf("...end hndlrVendor.RowFirst()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Form
+//----------------------------------------------------------------------------
+
+// RowForm displays the raw table row form without data.
+func (h *HandlersApp01sqVendor) RowForm(w http.ResponseWriter, r *http.Request) {
+
+	log.Printf("hndlrVendor.RowForm(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.RowForm(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Verify any fields that need it.
+
+	// Get the row to display.
+
+	// Display the row in the form.
+	http.ServeFile(w, r, "./tmpl/App01sq.Vendor.form.gohtml")
+
+	log.Printf("...end hndlrVendor.RowForm()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Insert
+//----------------------------------------------------------------------------
+
+// RowInsert handles an add row request which comes from the row display form.
+func (h *HandlersApp01sqVendor) RowInsert(w http.ResponseWriter, r *http.Request) {
+	var rcd App01sqVendor.App01sqVendor
+	var err error
+
+	log.Printf("hndlrVendor.RowInsert(%s)\n", r.Method)
+
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "insert") {
+		return
+	}
+
+	// Create a record from the data given redisplaying it if it is not valid.
+	err = rcd.Request2Struct(r)
+	if errs, ok := err.(App01sqVendor.FieldErrors); ok {
+		h.RowDisplayErrors(w, r, &rcd, "Row was not added!", errs)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Add the row.
+	err = h.db.RowInsert(&rcd)
+	if err != nil {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Get the row as inserted, RowInsert() returns any key assigned by the
+	// database, and display it.
+	err = h.db.RowFind(&rcd)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowInsert(Error:500) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+	h.RowDisplay(w, r, &rcd, "Row added!")
+
+	log.Printf("...end hndlrVendor.RowInsert(%s)\n", util.ErrorString(err))
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Last
+//----------------------------------------------------------------------------
+
+// RowLast displays the first row.
+func (h *HandlersApp01sqVendor) RowLast(w http.ResponseWriter, r *http.Request) {
+	var rcd App01sqVendor.App01sqVendor
+	var err error
+
+	log.Printf("hndlrVendor.RowLast(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendor.RowLast(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Get the next row to display.
+	err = h.db.RowLast(&rcd)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowLast(Error:400) - No Key\n")
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Display the row in the form.
+	h.RowDisplay(w, r, &rcd, "")
+
+	log.Printf("...end hndlrVendor.RowLast()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Next
+//----------------------------------------------------------------------------
+
+// RowNext handles an next request which comes from the row display form and
+// should display the next row from the current one.
+func (h *HandlersApp01sqVendor) RowNext(w http.ResponseWriter, r *http.Request) {
+	var rcd App01sqVendor.App01sqVendor
+	var err error
+	var i int
+	var key string
+
+	log.Printf("hndlrVendor.RowNext(%s)\n", r.Method)
+	log.Printf("\tURL: %q\n", r.URL)
+
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Get the prior key(s).
+	i = 0
+	key = r.FormValue(fmt.Sprintf("key%d", i))
+	rcd.Id, _ = strconv.ParseInt(key, 0, 64)
+
+	i++
+
+	// Get the next row and display it.
+	err = h.db.RowNext(&rcd)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowNext(Error:400) - No Key\n")
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+	h.RowDisplay(w, r, &rcd, "")
+
+	log.Printf("...end hndlrVendor.RowNext()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Prev
+//----------------------------------------------------------------------------
+
+// RowPrev handles an previous request which comes from the row display form
+// and should display the previous row from the current one.
+func (h *HandlersApp01sqVendor) RowPrev(w http.ResponseWriter, r *http.Request) {
+	var rcd App01sqVendor.App01sqVendor
+	var err error
+	var i int
+	var key string
+
+	log.Printf("hndlrVendor.RowPrev(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Get the prior key(s).
+	i = 0
+	key = r.FormValue(fmt.Sprintf("key%d", i))
+	rcd.Id, _ = strconv.ParseInt(key, 0, 64)
+
+	i++
+
+	// Get the next row and display it.
+	err = h.db.RowPrev(&rcd)
+	if err != nil {
+
+		log.Printf("...end Vendor.RowNext(Error:400) - No Key\n")
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+	h.RowDisplay(w, r, &rcd, "")
+
+	log.Printf("...end hndlrVendor.RowPrev()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Show
+//----------------------------------------------------------------------------
+
+// RowShow handles displaying of the table row form display.
+func (h *HandlersApp01sqVendor) RowShow(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var key string
+	var rcd App01sqVendor.App01sqVendor
+
+	log.Printf("hndlrVendor.RowShow(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+
+		log.Printf("...end hndlrVendorHndlrShow(Error:405) - Not GET\n")
+
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "show") {
+		return
+	}
+
+	// Verify any fields that need it.
+	//TODO: key = r.FormValue("[.Table.PrimaryKey.Name]")
+	//TODO: if key is not present, assume first record.
+
+	//TODO: log.Printf("\tkey: %s\n", key)
+
+	// Get the row to display.
+	if key == "" {
+		err = h.db.RowFirst(&rcd)
+	} else {
+		err = h.db.RowFind(&rcd)
+	}
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowShow(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Display the row in the form.
+	h.RowDisplay(w, r, &rcd, "")
+
+	log.Printf("...end hndlrVendor.RowShow()\n")
+
+}
+
+//----------------------------------------------------------------------------
+//                             Row Update
+//----------------------------------------------------------------------------
+
+// RowUpdate handles an update request which comes from the row display form.
+func (h *HandlersApp01sqVendor) RowUpdate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var key string
+	var rcd App01sqVendor.App01sqVendor
+	var old App01sqVendor.App01sqVendor
+	var i int
+
+	log.Printf("hndlrVendor.RowUpdate(%s)\n", r.Method)
+
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "update") {
+		return
+	}
+
+	// Create a record from the data given redisplaying it if it is not valid
+	// before the prior row is touched.
+	err = rcd.Request2Struct(r)
+	if errs, ok := err.(App01sqVendor.FieldErrors); ok {
+		h.RowDisplayErrors(w, r, &rcd, "Record was not updated!", errs)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Get the prior key(s).
+	i = 0
+	key = r.FormValue(fmt.Sprintf("key%d", i))
+	old.Id, _ = strconv.ParseInt(key, 0, 64)
+
+	i++
+
+	// Delete the row.
+	err = h.db.RowDelete(&old)
+	if err != nil {
+
+		log.Printf("...end hndlrVendor.RowUpdate(Error:400) - %s\n", util.ErrorString(err))
+
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Add the row.
+	err = h.db.RowInsert(&rcd)
+	if err != nil {
+		http.Error(w, http.StatusText(400), http.StatusBadRequest)
+		return
+	}
+
+	// Display the next row in the form.
+	h.RowDisplay(w, r, &rcd, "Record updated")
+
+	log.Printf("...end hndlrVendor.RowUpdate()\n")
+
+}
+
+//============================================================================
+//                             Table Form Handlers
+//============================================================================
+
+//----------------------------------------------------------------------------
+//                             Table Create
+//----------------------------------------------------------------------------
+
+// TableCreate creates the table deleting any current ones.
+func (h *HandlersApp01sqVendor) TableCreate(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	log.Printf("hndlrVendor.TableCreate(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "load") {
+		return
+	}
+
+	// Create the table.
+	err = h.db.TableCreate()
+	if err == nil {
+		//h.ListShow(w, 0, "Table was created")
+		w.Write([]byte("Table was created"))
+	} else {
+		w.Write([]byte("Table creation had an error of:" + err.Error()))
+	}
+
+	log.Printf("...end hndlrVendor.TableCreate(%s)\n", util.ErrorString(err))
+
+}
+
+//----------------------------------------------------------------------------
+//                            Table Load CSV
+//----------------------------------------------------------------------------
+
+// TableLoadCSV creates the table deleting any current ones and loads in
+// data from a CSV file.
+func (h *HandlersApp01sqVendor) TableLoadCSV(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var rcd App01sqVendor.App01sqVendor
+	var fileIn multipart.File
+	var cnt int
+	var maxMem int64
+	var handler *multipart.FileHeader
+
+	log.Printf("hndlrVendor.TableLoadCSV(%s)\n", r.Method)
+	if r.Method != "POST" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "load") {
+		return
+	}
+
+	// ParseMultipartForm parses a request body as multipart/form-data.
+	// The whole request body is parsed and up to a total of maxMemory
+	// bytes of its file parts are stored in memory, with the remainder
+	// stored on disk in temporary files. ParseMultipartForm calls ParseForm
+	// if necessary. After one call to ParseMultipartForm, subsequent
+	// calls have no effect.
+	name := "csvFile" // Must match Name parameter of Form's "<input type=file name=???>"
+	maxMem = 64 << 20 // 64mb
+	r.ParseMultipartForm(maxMem)
+
+	// FormFile returns the first file for the given key which was
+	// specified on the Form Input Type=file Name parameter.
+	// it also returns the FileHeader so we can get the Filename,
+	// the Header and the size of the file
+	fileIn, handler, err = r.FormFile(name)
+	if err != nil {
+		log.Printf("...end hndlrVendor.TableLoadCSV(Error:500) - %s\n", util.ErrorString(err))
+		http.Error(w, http.StatusText(500), http.StatusInternalServerError)
+		return
+	}
+	defer fileIn.Close() //close the file when we finish
+	log.Printf("\tUploaded File: %+v\n", handler.Filename)
+	log.Printf("\tFile Size: %+v\n", handler.Size)
+	log.Printf("\tMIME Header: %+v\n", handler.Header)
+	rdr := csv.NewReader(fileIn)
+
+	// Create the table.
+	err = h.db.TableCreate()
+	if err != nil {
+		w.Write([]byte("Table creation had an error of:" + util.ErrorString(err)))
+	}
+
+	log.Printf("\tLoading data...\n")
+	for {
+		record, err := rdr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			str := fmt.Sprintf("ERROR: Reading row %d from csv - %s\n", cnt, util.ErrorString(err))
+			w.Write([]byte(str))
+			return
+		}
+
+		if err = rcd.Strings2Struct(record); err != nil {
+			if errs, ok := err.(App01sqVendor.FieldErrors); ok {
+				for _, fn := range errs.Names() {
+					str := fmt.Sprintf("ERROR: Row %d field %s - %s\n", cnt+1, fn, errs[fn])
+					w.Write([]byte(str))
+				}
+			} else {
+				str := fmt.Sprintf("ERROR: Row %d - %s\n", cnt+1, util.ErrorString(err))
+				w.Write([]byte(str))
+			}
+			return
+		}
+
+		err = h.db.RowInsert(&rcd)
+		if err != nil {
+			str := fmt.Sprintf("ERROR: Table creation had an error of: %s\n", util.ErrorString(err))
+			w.Write([]byte(str))
+			return
+		}
+		cnt++
+		log.Printf("\t...Added row %d\n", cnt)
+	}
+	for i := 1; i > 0; i-- {
+		str := fmt.Sprintf("Added %d rows\n", cnt)
+		w.Write([]byte(str))
+	}
+
+	log.Printf("...end hndlrVendor.TableLoadCSV(ok) - %d\n", cnt)
+
+}
+
+//----------------------------------------------------------------------------
+//                             Table Load Test Data
+//----------------------------------------------------------------------------
+
+// TableLoadTestData creates the table deleting any current ones and loads
+// in some test rows.
+func (h *HandlersApp01sqVendor) TableLoadTestData(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var rcd App01sqVendor.App01sqVendor
+
+	log.Printf("hndlrVendor.TableLoadTestData(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "load") {
+		return
+	}
+
+	// Create the table.
+	err = h.db.TableCreate()
+	if err == nil {
+		w.Write([]byte("Table was created\n"))
+	} else {
+		w.Write([]byte("Table creation had an error of:" + util.ErrorString(err)))
+	}
+
+	// Load the test rows.
+	// Now add some records.
+	for i := 0; i < 26; i++ {
+		chr := 'A' + i
+		rcd.TestData(i)
+		err = h.db.RowInsert(&rcd)
+		if err == nil {
+			str := fmt.Sprintf("Added row: %c\n", chr)
+			w.Write([]byte(str))
+		} else {
+			str := fmt.Sprintf("Table creation had an error of: %c\n", chr)
+			w.Write([]byte(str))
+		}
+	}
+
+	log.Printf("...end hndlrVendor.TableLoadTestData(%s)\n", util.ErrorString(err))
+
+}
+
+//----------------------------------------------------------------------------
+//                            Table Save CSV
+//----------------------------------------------------------------------------
+
+// TableSaveCSV creates the table deleting any current ones and loads in
+// data from a CSV file.
+func (h *HandlersApp01sqVendor) TableSaveCSV(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var cntGood int
+	var cntTotal int
+
+	log.Printf("hndlrVendor.TableSaveCSV(%s)\n", r.Method)
+
+	if r.Method != "GET" {
+		http.Error(w, http.StatusText(405), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Permitted(w, r, "save") {
+		return
+	}
+
+	// Set up to write the CSV file.
+	fileName := "Vendor.csv"
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s", fileName))
+	wtr := csv.NewWriter(w)
+
+	// Write the CSV file.
+	if wtr != nil {
+		apply := func(rcd App01sqVendor.App01sqVendor) error {
+			log.Printf("\tRow: %v\n", rcd.ToStrings())
+			err2 := wtr.Write(rcd.ToStrings())
+			cntTotal++
+			if err2 == nil {
+				cntGood++
+			}
+			return err2
+		}
+		err = h.db.TableScan(apply)
+		wtr.Flush()
+	} else {
+		err = fmt.Errorf("Error: Could not create CSV Writer\n")
+		log.Printf("\t%s - for App01sqVendor table!\n", util.ErrorString(err))
+	}
+
+	log.Printf("...end hndlrVendor.TableSaveCSV(%s)\n", util.ErrorString(err))
+}
+
+//============================================================================
+//                              User Handlers
+//============================================================================
+
+// Anything between the user markers is kept when this file is regenerated.
+// <<user:handlers>>
+// <</user>>
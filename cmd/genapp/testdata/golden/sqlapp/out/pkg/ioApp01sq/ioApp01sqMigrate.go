@@ -0,0 +1,240 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// ioApp01sqMigrate applies the migration files generated by
+// "genapp migrate" to the database and records the versions applied in the
+// schema_migrations table.
+
+// Remarks:
+//  *   Migration files are named NNNN_<name>.up.sql and NNNN_<name>.down.sql
+//      where NNNN is the version. They are applied in version order.
+//  *   The statements of a file are separated by a ';' at the end of a line.
+//      They are executed one at a time on a single connection so that any
+//      connection settings such as SQLite's PRAGMA foreign_keys apply to the
+//      statements which follow them.
+//  *   Since not all database servers can roll back changes to the tables,
+//      transactions are not used. A migration which fails part way must be
+//      corrected by hand.
+
+// Generated: Mon Jan  1, 2001 00:00 for sqlite Database
+
+package ioApp01sq
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+
+	"log"
+
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationTable is the table which records the versions applied.
+const migrationTable = "schema_migrations"
+
+// migrationFile matches the names of the migration files.
+var migrationFile = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+//============================================================================
+//                              Migration
+//============================================================================
+
+// Migration is one version of the database definitions.
+type Migration struct {
+	Version int
+	Name    string
+	Up      []string // Statements to apply the version
+	Down    []string // Statements to revert the version
+}
+
+// MigrationSplit splits the text of a migration file into its statements
+// without the comments.
+func MigrationSplit(text string) []string {
+	var stmts []string
+	var str strings.Builder
+
+	for _, l := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(l), "--") {
+			continue
+		}
+		str.WriteString(l)
+		str.WriteString("\n")
+	}
+	for _, s := range strings.Split(str.String(), ";\n") {
+		s = strings.TrimRight(strings.TrimSpace(s), ";")
+		if len(s) > 0 {
+			stmts = append(stmts, s)
+		}
+	}
+
+	return stmts
+}
+
+// MigrationsRead reads the migration files in the given directory and
+// returns them in version order.
+func MigrationsRead(dir string) ([]*Migration, error) {
+	var migs []*Migration
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error: Reading migrations %s - %s\n", dir, err)
+	}
+	vers := map[int]*Migration{}
+	for _, f := range files {
+		m := migrationFile.FindStringSubmatch(f.Name())
+		if m == nil {
+			continue
+		}
+		num, _ := strconv.Atoi(m[1])
+		mig := vers[num]
+		if mig == nil {
+			mig = &Migration{Version: num, Name: m[2]}
+			vers[num] = mig
+			migs = append(migs, mig)
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("Error: Migration %d has more than one name!\n", num)
+		}
+		text, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("Error: Reading migration %s - %s\n", f.Name(), err)
+		}
+		if m[3] == "up" {
+			mig.Up = MigrationSplit(string(text))
+		} else {
+			mig.Down = MigrationSplit(string(text))
+		}
+	}
+	sort.Slice(migs, func(i, j int) bool { return migs[i].Version < migs[j].Version })
+
+	return migs, nil
+}
+
+//============================================================================
+//                              Migration Methods
+//============================================================================
+
+// migrationsApplied creates the migration table if needed and returns the
+// versions recorded in it.
+func (io *IO_App01sq) migrationsApplied(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	var version int
+
+	stmt := "CREATE TABLE IF NOT EXISTS " + migrationTable +
+		" (version INTEGER NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL)"
+	if err := io.migrationExec(ctx, conn, stmt); err != nil {
+		return nil, err
+	}
+
+	applied := map[int]bool{}
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM "+migrationTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// migrationExec executes one statement on the connection.
+func (io *IO_App01sq) migrationExec(ctx context.Context, conn *sql.Conn, stmt string) error {
+
+	log.Printf("\tMigration Exec(%s)\n", stmt)
+	_, err := conn.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// migrationRun executes the statements of a migration and then the
+// statement which records it.
+func (io *IO_App01sq) migrationRun(ctx context.Context, conn *sql.Conn, m *Migration, stmts []string, record string) error {
+
+	for _, s := range append(stmts, record) {
+		if err := io.migrationExec(ctx, conn, s); err != nil {
+			return fmt.Errorf("Error: Migration %04d_%s failed: %s\n", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// MigrateUp applies the migrations in the given directory which have not
+// been applied yet in version order.
+func (io *IO_App01sq) MigrateUp(dir string) error {
+	ctx := context.Background()
+
+	log.Printf("MigrateUp(%s)\n", dir)
+	migs, err := MigrationsRead(dir)
+	if err != nil {
+		return err
+	}
+	conn, err := io.dbSql.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	applied, err := io.migrationsApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migs {
+		if applied[m.Version] {
+			continue
+		}
+		record := fmt.Sprintf("INSERT INTO %s (version, name) VALUES (%d, '%s')", migrationTable,
+			m.Version, m.Name)
+		if err = io.migrationRun(ctx, conn, m, m.Up, record); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("...end MigrateUp()\n")
+	return nil
+}
+
+// MigrateDown reverts the last n migrations applied from the given
+// directory in reverse version order.
+func (io *IO_App01sq) MigrateDown(dir string, n int) error {
+	ctx := context.Background()
+
+	log.Printf("MigrateDown(%s, %d)\n", dir, n)
+	migs, err := MigrationsRead(dir)
+	if err != nil {
+		return err
+	}
+	conn, err := io.dbSql.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	applied, err := io.migrationsApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	for i := len(migs) - 1; i >= 0 && n > 0; i-- {
+		m := migs[i]
+		if !applied[m.Version] {
+			continue
+		}
+		record := fmt.Sprintf("DELETE FROM %s WHERE version = %d", migrationTable, m.Version)
+		if err = io.migrationRun(ctx, conn, m, m.Down, record); err != nil {
+			return err
+		}
+		n--
+	}
+
+	log.Printf("...end MigrateDown()\n")
+	return nil
+}
@@ -0,0 +1,86 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// ioApp01sqMigrate contains the functions which apply the
+// migration files to the SQL Database.
+
+// Generated: Mon Jan  1, 2001 00:00 for sqlite Database
+
+package ioApp01sq
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//============================================================================
+//                              Tests
+//============================================================================
+
+//----------------------------------------------------------------------------
+//                              MigrationSplit
+//----------------------------------------------------------------------------
+
+func TestApp01sqMigrationSplit(t *testing.T) {
+
+	t.Logf("TestMigrationSplit()...\n")
+
+	stmts := MigrationSplit("-- Migration 0001\n\n-- add field\nALTER TABLE a ADD COLUMN b INTEGER;\n" +
+		"CREATE INDEX IX_a_1\n ON a(b);\n")
+	if len(stmts) != 2 {
+		t.Fatalf("Error: should have 2 statements but has %d: %q\n\n", len(stmts), stmts)
+	}
+	if stmts[0] != "ALTER TABLE a ADD COLUMN b INTEGER" {
+		t.Fatalf("Error: invalid statement: %q\n\n", stmts[0])
+	}
+	if stmts[1] != "CREATE INDEX IX_a_1\n ON a(b)" {
+		t.Fatalf("Error: invalid statement: %q\n\n", stmts[1])
+	}
+
+	t.Logf("TestMigrationSplit() - End of Test\n\n\n")
+}
+
+//----------------------------------------------------------------------------
+//                              MigrationsRead
+//----------------------------------------------------------------------------
+
+func TestApp01sqMigrationsRead(t *testing.T) {
+
+	t.Logf("TestMigrationsRead()...\n")
+
+	dir, err := ioutil.TempDir("", "migrations")
+	if err != nil {
+		t.Fatalf("Error: %s\n\n", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"0002_drop_b.up.sql":   "ALTER TABLE a DROP COLUMN b;\n",
+		"0002_drop_b.down.sql": "ALTER TABLE a ADD COLUMN b INTEGER;\n",
+		"0001_add_a.up.sql":    "CREATE TABLE a (b INTEGER);\n",
+		"0001_add_a.down.sql":  "DROP TABLE a;\n",
+		"notes.txt":            "not a migration\n",
+	}
+	for fn, text := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, fn), []byte(text), 0644); err != nil {
+			t.Fatalf("Error: %s\n\n", err)
+		}
+	}
+
+	migs, err := MigrationsRead(dir)
+	if err != nil {
+		t.Fatalf("Error: %s\n\n", err)
+	}
+	if len(migs) != 2 {
+		t.Fatalf("Error: should have 2 migrations but has %d\n\n", len(migs))
+	}
+	if migs[0].Version != 1 || migs[0].Name != "add_a" || migs[1].Version != 2 {
+		t.Fatalf("Error: invalid order: %+v %+v\n\n", migs[0], migs[1])
+	}
+	if len(migs[1].Up) != 1 || migs[1].Down[0] != "ALTER TABLE a ADD COLUMN b INTEGER" {
+		t.Fatalf("Error: invalid statements: %+v\n\n", migs[1])
+	}
+
+	t.Logf("TestMigrationsRead() - End of Test\n\n\n")
+}
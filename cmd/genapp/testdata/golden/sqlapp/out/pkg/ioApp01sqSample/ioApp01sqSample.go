@@ -0,0 +1,378 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// This portion of ioApp01sq handles all the
+// i/o and manipulation for the sample table. Any
+// table manipulation should be added to this package as
+// methods in IO_sample
+
+// Notes:
+//  1. Any Database Query that returns "rows" must have an associated
+//      rows.Close(). The best way to handle this is to do the query
+//      immediately followed by "defer rows.Close()".  Queries that
+//      return a "row" need not be closed.
+
+// 2.   SQL requires OFFSET to follow LIMIT optionally (ie LIMIT n [OFFSET n])
+// Generated: Mon Jan  1, 2001 00:00 for sqlite Database
+
+package ioApp01sqSample
+
+import (
+	"database/sql"
+	"fmt"
+	_ "github.com/shopspring/decimal"
+	"log"
+	_ "strconv"
+
+	"app01sq/pkg/App01sqSample"
+	"app01sq/pkg/ioApp01sq"
+	"github.com/2kranki/go_util"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//============================================================================
+//                            IO_Sample
+//============================================================================
+
+type IO_App01sqSample struct {
+	io *ioApp01sq.IO_App01sq
+}
+
+//----------------------------------------------------------------------------
+//                             Row Delete
+//----------------------------------------------------------------------------
+
+// RowDelete deletes the row with keys from the provided record, rcd.
+func (io *IO_App01sqSample) RowDelete(rcd *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "DELETE FROM sample WHERE id = ?;\n"
+
+	log.Printf("ioSample.RowDelete()\n")
+
+	err = io.io.Exec(sqlStmt, rcd.Id)
+	if err != nil {
+		log.Printf("...end ioSample.RowDelete(Error:500) - Internal Error\n")
+		return fmt.Errorf("500. Internal Server Error")
+	}
+
+	log.Printf("...end ioSample.RowDelete()\n")
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//                             Row Find
+//----------------------------------------------------------------------------
+
+// RowFind searches the Database for a matching row for the keys found in
+// the given record and returns the output in that same record.
+func (io *IO_App01sqSample) RowFind(rcd *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "SELECT * FROM sample WHERE id = ?;\n"
+
+	log.Printf("ioSample.RowFind(%+v)\n", rcd)
+
+	row := io.io.QueryRow(sqlStmt, rcd.Id)
+
+	err = row.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+
+	log.Printf("...end ioSample.RowFind(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Row First
+//----------------------------------------------------------------------------
+
+// RowFirst returns the first row in the table, Sample.
+// If there are no rows in the table, then a blank/null record is returned
+// without error.
+func (io *IO_App01sqSample) RowFirst(rcd *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "SELECT * FROM sample ORDER BY id ASC LIMIT 1;\n"
+
+	log.Printf("ioSample.RowFirst()\n")
+
+	row := io.io.QueryRow(sqlStmt)
+
+	err = row.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+	if err == sql.ErrNoRows {
+		log.Printf("\tNo Rows found!\n")
+		err = nil
+	}
+
+	log.Printf("...end ioSample.RowFirst(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Row Insert
+//----------------------------------------------------------------------------
+
+func (io *IO_App01sqSample) RowInsert(d *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "INSERT INTO sample (flag, opt, memo, ratio, big, ident, stamp, doc, part, pct, status, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);\n"
+
+	log.Printf("ioSample.RowInsert(%+v)\n", d)
+	log.Printf("\tSQL:\n%s\n", sqlStmt)
+
+	// Validate the input record.
+
+	// Add it to the table. The key assigned to the auto-increment field
+	// is returned in the record.
+	var id int64
+	id, err = io.io.ExecInsert(sqlStmt, d.Flag, d.Opt, d.Memo, d.Ratio, d.Big, d.Ident, d.Stamp, d.Doc, d.Part, d.Pct, d.Status, d.Data)
+	if err == nil {
+		d.Id = int64(id)
+	}
+	if err != nil {
+		log.Printf("...end ioSample.RowInsert(Error:500) - Internal Error\n")
+		err = fmt.Errorf("500. Internal Server Error. %s\n", err.Error())
+	}
+
+	log.Printf("...end ioSample.RowInsert(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Row Last
+//----------------------------------------------------------------------------
+
+func (io *IO_App01sqSample) RowLast(rcd *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "SELECT * FROM sample ORDER BY id DESC LIMIT 1;\n"
+
+	log.Printf("ioSample.RowLast()\n")
+	row := io.io.QueryRow(sqlStmt)
+
+	err = row.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+	if err == sql.ErrNoRows {
+		log.Printf("\tNo Rows found!\n")
+		err = nil
+	}
+
+	log.Printf("...end ioSample.RowLast(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Row Next
+//----------------------------------------------------------------------------
+
+// RowNext returns the next row from the row given. If row after the current
+// one does not exist, then the first row is returned.
+func (io *IO_App01sqSample) RowNext(rcd *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "SELECT * FROM sample WHERE id > ? ORDER BY id ASC LIMIT 1;\n"
+
+	log.Printf("ioSample.RowNext(%+v)\n", rcd)
+
+	row := io.io.QueryRow(sqlStmt, rcd.Id)
+
+	err = row.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+	if err != nil {
+		err = io.RowFirst(rcd)
+	}
+
+	log.Printf("...end ioSample.RowNext(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Row Page
+//----------------------------------------------------------------------------
+
+// RowPage returns a page of rows where a page size is the 'limit' parameter and
+// 'offset' is the offset into the result set ordered by the main index. Both
+// 'limit' and 'offset' are relative to 1. We return an address to the array
+// rows (structs) so that we don't have the overhead of copying them everwhere.
+func (io *IO_App01sqSample) RowPage(offset int, limit int) ([]App01sqSample.App01sqSample, error) {
+	var err error
+	var sqlStmt = "SELECT * FROM sample ORDER BY id ASC LIMIT ? OFFSET ?;\n"
+	data := []App01sqSample.App01sqSample{}
+
+	log.Printf("ioSample.RowPage(%d,%d)\n", offset, limit)
+
+	err = io.io.Query(
+		sqlStmt,
+		func(r *sql.Rows) {
+			var rcd App01sqSample.App01sqSample
+			err = r.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+			if err != nil {
+				log.Fatal(err)
+			} else {
+				data = append(data, rcd)
+			}
+		},
+		limit,
+		offset)
+
+	log.Printf("...end ioSample.RowPage(%s)\n", util.ErrorString(err))
+	return data, err
+}
+
+//----------------------------------------------------------------------------
+//                             Row Prev
+//----------------------------------------------------------------------------
+
+func (io *IO_App01sqSample) RowPrev(rcd *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "SELECT * FROM sample WHERE id < ? ORDER BY id DESC LIMIT 1;\n"
+
+	log.Printf("ioSample.RowPrev(%+v)\n", rcd)
+
+	row := io.io.QueryRow(sqlStmt, rcd.Id)
+
+	err = row.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+	if err != nil {
+		err = io.RowLast(rcd)
+	}
+
+	log.Printf("...end ioSample.RowPrev(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Row Update
+//----------------------------------------------------------------------------
+
+// RowUpdate replaces the row with the keys of d with d.
+func (io *IO_App01sqSample) RowUpdate(d *App01sqSample.App01sqSample) error {
+	var err error
+	var sqlStmt = "UPDATE sample SET flag = ?, opt = ?, memo = ?, ratio = ?, big = ?, ident = ?, stamp = ?, doc = ?, part = ?, pct = ?, status = ?, data = ? WHERE id = ?;\n"
+
+	log.Printf("ioSample.RowUpdate(%+v)\n", d)
+
+	// Validate the input record.
+
+	// Update it in the table.
+	err = io.io.Exec(sqlStmt, d.Flag, d.Opt, d.Memo, d.Ratio, d.Big, d.Ident, d.Stamp, d.Doc, d.Part, d.Pct, d.Status, d.Data, d.Id)
+	if err != nil {
+		log.Printf("...end ioSample.RowUpdate(Error:500) - Internal Error\n")
+		err = fmt.Errorf("500. Internal Server Error. %s\n", err.Error())
+	}
+
+	log.Printf("...end ioSample.RowUpdate(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Table Count
+//----------------------------------------------------------------------------
+
+func (io *IO_App01sqSample) TableCount() (int, error) {
+	var err error
+	var count int
+	var sqlStmt = "SELECT COUNT(*) FROM sample;\n"
+
+	log.Printf("ioSample.TableCount()\n")
+
+	row := io.io.QueryRow(sqlStmt)
+
+	err = row.Scan(&count)
+	if err != nil {
+
+		log.Printf("...end ioSample.TableCount(%s) %d\n", util.ErrorString(err), count)
+		return 0, err
+	}
+
+	log.Printf("...end ioSample.TableCount(%s) %d\n", util.ErrorString(err), count)
+	return count, err
+}
+
+//----------------------------------------------------------------------------
+//                             Table Create
+//----------------------------------------------------------------------------
+
+// TableCreate creates the table in the given database deleting the current
+// table if present.
+func (io *IO_App01sqSample) TableCreate() error {
+	var sqlStmt = "CREATE TABLE IF NOT EXISTS sample (\n\tid\tINTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,\n\tflag\tBOOLEAN NOT NULL,\n\topt\tBOOLEAN,\n\tmemo\tVARCHAR(20),\n\tratio\tREAL,\n\tbig\tINTEGER NOT NULL,\n\tident\tTEXT,\n\tstamp\tTIMESTAMP,\n\tdoc\tTEXT NOT NULL,\n\tpart\tVARCHAR(8) NOT NULL,\n\tpct\tTEXT(5,2) NOT NULL,\n\tstatus\tVARCHAR(10) NOT NULL,\n\tdata\tBLOB,\n\tCONSTRAINT CK_sample_status CHECK (status IN ('', 'open', 'closed', 'on hold'))\n);\n"
+	var err error
+
+	log.Printf("ioSample.TableCreate()\n")
+	log.Printf("\tSQL:\n%s\n", sqlStmt)
+
+	err = io.TableDelete()
+	if err != nil {
+		log.Printf("...end ioSample.TableCreate(Error:%s)\n", err.Error())
+		return err
+	}
+	err = io.io.Exec(sqlStmt)
+
+	log.Printf("...end ioSample.TableCreate(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Table Delete
+//----------------------------------------------------------------------------
+
+// TableDelete deletes the table in the given database if present.
+func (io *IO_App01sqSample) TableDelete() error {
+	var sqlStmt = "DROP TABLE IF EXISTS sample;\n"
+	var err error
+
+	log.Printf("ioSample.TableDelete()\n")
+	log.Printf("\tSQL:\n%s\n", sqlStmt)
+
+	err = io.io.Exec(sqlStmt)
+
+	log.Printf("...end ioSample.TableDelete(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                             Table Scan
+//----------------------------------------------------------------------------
+
+// TableScan reads all the rows in the table applying a function to each of
+// them.
+func (io *IO_App01sqSample) TableScan(apply func(rcd App01sqSample.App01sqSample) error) error {
+	var err error
+	var rcd App01sqSample.App01sqSample
+	var sqlFirstStmt = "SELECT * FROM sample ORDER BY id ASC LIMIT 1;\n"
+	var sqlNextStmt = "SELECT * FROM sample WHERE id > ? ORDER BY id ASC LIMIT 1;\n"
+	var row *sql.Row
+
+	log.Printf("ioSample.TableScanner()\n")
+	log.Printf("\tSQL:\n%s\n", sqlFirstStmt)
+
+	log.Printf("ioSample.RowFirst()\n")
+
+	row = io.io.QueryRow(sqlFirstStmt)
+	for {
+		err = row.Scan(&rcd.Id, &rcd.Flag, &rcd.Opt, &rcd.Memo, &rcd.Ratio, &rcd.Big, &rcd.Ident, &rcd.Stamp, &rcd.Doc, &rcd.Part, &rcd.Pct, &rcd.Status, &rcd.Data)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				log.Printf("\tNo Rows found!\n")
+				err = nil
+			}
+			break
+		}
+		// Warning: Next relies on the current record giving the key(s)
+		// to find its position in the table. So, we pass a copy to apply().
+		err = apply(rcd)
+		if err != nil {
+			break
+		}
+		row = io.io.QueryRow(sqlNextStmt, rcd.Id)
+	}
+
+	log.Printf("...end ioSample.TableDelete(%s)\n", util.ErrorString(err))
+	return err
+}
+
+//----------------------------------------------------------------------------
+//                                  New
+//----------------------------------------------------------------------------
+
+// New creates a new io struct.
+func NewIoApp01sqSample(io *ioApp01sq.IO_App01sq) *IO_App01sqSample {
+	db := &IO_App01sqSample{}
+	if io == nil {
+		db.io = ioApp01sq.NewIoApp01sq()
+	} else {
+		db.io = io
+	}
+	return db
+}
@@ -0,0 +1,88 @@
+#!/usr/bin/env python3
+# vi:nu:et:sts=4 ts=4 sw=4
+
+""" Test util.py
+
+This module tests the util.py classes and functions.
+
+The module must be executed from the repository that contains the util.py.
+
+"""
+
+
+#   This is free and unencumbered software released into the public domain.
+#
+#   Anyone is free to copy, modify, publish, use, compile, sell, or
+#   distribute this software, either in source code form or as a compiled
+#   binary, for any purpose, commercial or non-commercial, and by any
+#   means.
+#
+#   In jurisdictions that recognize copyright laws, the author or authors
+#   of this software dedicate any and all copyright interest in the
+#   software to the public domain. We make this dedication for the benefit
+#   of the public at large and to the detriment of our heirs and
+#   successors. We intend this dedication to be an overt act of
+#   relinquishment in perpetuity of all present and future rights to this
+#   software under copyright law.
+#
+#   THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+#   EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+#   MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+#   IN NO EVENT SHALL THE AUTHORS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+#   OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+#   ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE
+#   OR OTHER DEALINGS IN THE SOFTWARE.
+#
+#   For more information, please refer to <http://unlicense.org/>
+
+
+from io import StringIO
+from unittest import TestCase
+import      util
+import      os
+import      sys
+
+
+
+
+################################################################################
+#                               Test Classes
+################################################################################
+
+class testAbsolutePath(TestCase):
+
+    def test_1(self):
+        txt = "${HOME}/a.txt"
+        a = util.absolutePath(txt)
+        b = os.path.expandvars(txt)
+        self.assertEqual(a,b)
+        a = util.absolutePath('~/a.txt')
+        self.assertEqual(a,b)
+
+
+    def test_2(self):
+        txt = "."
+        a = util.absolutePath(txt)
+        b = os.getcwd()
+        self.assertEqual(a,b)
+
+
+class testBuild(TestCase):
+
+    def test_one(self):
+        util.fDebug = True
+        util.fTrace = True
+        err = util.buildGoApp('cmd', 'app01sq')
+        if err:
+            print(err.Error())
+        self.assertEqual(err, None)
+
+
+################################################################################
+#                           Command-line interface
+################################################################################
+
+if '__main__' == __name__:
+    import unittest
+    unittest.main()
+
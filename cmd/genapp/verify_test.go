@@ -0,0 +1,270 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Verify the Generated Applications
+
+// Notes:
+//	1.	Each misc/test01* definition is generated into a temporary
+//		directory which is then vetted and built. The generated tests
+//		are also run for SQLite since it needs no server.
+//	2.	The generated go.mod has no requirements so the modules that
+//		the application imports are required at the versions below.
+//		They must be in the local module cache since GOPROXY=off is
+//		used. If they are not, the test is skipped.
+//	3.	This takes a while so it is skipped by go test -short.
+
+package main
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// appModules are the modules which the generated applications may import
+// with the version to use and any others needed at a version in the module
+// cache. genapp's own go.mod is used for the versions that it has.
+var appModules = []struct {
+	Path    string
+	Version string
+	Needs   []string
+}{
+	{"github.com/2kranki/go_util", "v1.0.3", nil},
+	{"github.com/denisenkom/go-mssqldb", "v0.9.0", []string{"golang.org/x/crypto@v0.9.0"}},
+	{"github.com/go-sql-driver/mysql", "v1.5.0", nil},
+	{"github.com/lib/pq", "v1.10.0", nil},
+	{"github.com/mattn/go-sqlite3", "v1.14.6", nil},
+	{"github.com/shopspring/decimal", "v1.2.0", nil},
+	{"golang.org/x/crypto", "v0.9.0", nil},
+}
+
+//----------------------------------------------------------------------------
+//								Helpers
+//----------------------------------------------------------------------------
+
+// goCmd runs the go command in the directory with the module cache only
+// returning its combined output.
+func goCmd(dir string, args ...string) (string, error) {
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOSUMDB=off")
+	out, err := cmd.CombinedOutput()
+
+	return string(out), err
+}
+
+// genappVersions returns the versions of the modules required by genapp.
+func genappVersions(t *testing.T) map[string]string {
+
+	data, err := ioutil.ReadFile("../../go.mod")
+	if err != nil {
+		t.Fatalf("Error: Reading go.mod: %s\n", err)
+	}
+	vers := map[string]string{}
+	re := regexp.MustCompile(`(?m)^\s*(?:require\s+)?(\S+\.\S+)\s+(v\S+)`)
+	for _, m := range re.FindAllStringSubmatch(string(data), -1) {
+		vers[m[1]] = m[2]
+	}
+
+	return vers
+}
+
+// appImports returns the paths of the non-standard packages imported by
+// the Go files in the directory.
+func appImports(t *testing.T, dir string) map[string]bool {
+
+	imports := map[string]bool{}
+	err := filepath.Walk(dir,
+		func(fn string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() || filepath.Ext(fn) != ".go" {
+				return err
+			}
+			f, err := parser.ParseFile(token.NewFileSet(), fn, nil, parser.ImportsOnly)
+			if err != nil {
+				return err
+			}
+			for _, spec := range f.Imports {
+				p := strings.Trim(spec.Path.Value, `"`)
+				if strings.Contains(strings.Split(p, "/")[0], ".") {
+					imports[p] = true
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Error: Reading the imports of %s: %s\n", dir, err)
+	}
+
+	return imports
+}
+
+// requireModules adds the modules imported by the generated application in
+// the directory to its go.mod.
+func requireModules(t *testing.T, dir string) {
+
+	vers := genappVersions(t)
+	args := []string{"mod", "edit"}
+	for imp := range appImports(t, dir) {
+		found := false
+		for _, m := range appModules {
+			if imp != m.Path && !strings.HasPrefix(imp, m.Path+"/") {
+				continue
+			}
+			v := m.Version
+			if len(vers[m.Path]) > 0 {
+				v = vers[m.Path]
+			}
+			args = append(args, "-require="+m.Path+"@"+v)
+			for _, n := range m.Needs {
+				args = append(args, "-require="+n)
+			}
+			found = true
+		}
+		if !found {
+			t.Fatalf("Error: There is no version for %s in appModules!\n", imp)
+		}
+	}
+	if out, err := goCmd(dir, args...); err != nil {
+		t.Fatalf("Error: go mod edit: %s\n%s\n", err, out)
+	}
+}
+
+// buildGenapp builds genapp into the directory returning its path.
+func buildGenapp(t *testing.T, dir string) string {
+
+	genapp := filepath.Join(dir, "genapp")
+	if out, err := goCmd(".", "build", "-o", genapp, "."); err != nil {
+		t.Fatalf("Error: Building genapp: %s\n%s\n", err, out)
+	}
+
+	return genapp
+}
+
+// generateApp generates the application of the exec json file into the
+// output directory using genapp with any additional arguments. Only the
+// built-in models are used.
+func generateApp(t *testing.T, genapp, execPath, outDir string, args ...string) {
+	var exe map[string]interface{}
+
+	data, err := ioutil.ReadFile(execPath)
+	if err != nil {
+		t.Fatalf("Error: Reading %s: %s\n", execPath, err)
+	}
+	if err = json.Unmarshal(data, &exe); err != nil {
+		t.Fatalf("Error: Decoding %s: %s\n", execPath, err)
+	}
+	exe["outdir"] = outDir
+	exe["debug"] = false
+	exe["quiet"] = true
+	if data, err = json.Marshal(exe); err != nil {
+		t.Fatalf("Error: Encoding %s: %s\n", execPath, err)
+	}
+	fn := filepath.Join(filepath.Dir(outDir), "exec.json")
+	if err = ioutil.WriteFile(fn, data, 0644); err != nil {
+		t.Fatalf("Error: Writing %s: %s\n", fn, err)
+	}
+
+	// The paths in the exec json file are relative to the repository. The
+	// project and user model layers are empty.
+	mdls := filepath.Join(filepath.Dir(outDir), "models")
+	os.Mkdir(mdls, 0755)
+	args = append([]string{"-debug=false", "-mdldir=" + mdls}, args...)
+	cmd := exec.Command(genapp, append(args, "-x", fn)...)
+	cmd.Dir = "../.."
+	cmd.Env = append(os.Environ(), "GENAPP_MODELS="+mdls)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Error: Generating %s: %s\n%s\n", execPath, err, out)
+	}
+}
+
+// verifyApp generates the application of the exec json file into the
+// directory with any additional genapp arguments and then vets and builds
+// it. The generated tests are also run for SQLite if cgo is enabled.
+func verifyApp(t *testing.T, genapp, execPath, dir, cgo string, args ...string) {
+
+	os.Mkdir(dir, 0755)
+	dir = filepath.Join(dir, "app")
+	generateApp(t, genapp, execPath, dir, args...)
+
+	// The vendor directory only has notes in it.
+	os.RemoveAll(filepath.Join(dir, "vendor"))
+	requireModules(t, dir)
+
+	if out, err := goCmd(dir, "list", "-deps", "-test", "./..."); err != nil {
+		if strings.Contains(out, "GOPROXY=off") {
+			t.Skipf("Skipping since modules are not in the module cache:\n%s\n", out)
+		}
+		t.Fatalf("Error: go list: %s\n%s\n", err, out)
+	}
+	if out, err := goCmd(dir, "vet", "./..."); err != nil {
+		t.Fatalf("Error: go vet: %s\n%s\n", err, out)
+	}
+	if out, err := goCmd(dir, "build", "./..."); err != nil {
+		t.Fatalf("Error: go build: %s\n%s\n", err, out)
+	}
+
+	// Only SQLite can be tested without a server.
+	if _, ok := appImports(t, dir)["github.com/mattn/go-sqlite3"]; !ok {
+		return
+	}
+	if strings.TrimSpace(cgo) != "1" {
+		t.Skip("Skipping the SQLite tests since cgo is not enabled")
+	}
+	if out, err := goCmd(dir, "test", "-count=1", "./..."); err != nil {
+		t.Fatalf("Error: go test: %s\n%s\n", err, out)
+	}
+}
+
+//----------------------------------------------------------------------------
+//							TestGeneratedApps
+//----------------------------------------------------------------------------
+
+func TestGeneratedApps(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("Skipping the generated applications in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("Skipping the generated applications since go is not available")
+	}
+	t.Log("main::TestGeneratedApps()")
+
+	tmp, err := ioutil.TempDir("", "genapp")
+	if err != nil {
+		t.Fatalf("Error: TempDir: %s\n", err)
+	}
+	defer os.RemoveAll(tmp)
+	genapp := buildGenapp(t, tmp)
+	cgo, _ := goCmd(".", "env", "CGO_ENABLED")
+
+	execs, _ := filepath.Glob("../../misc/test01*.exec.json.txt")
+	if len(execs) == 0 {
+		t.Fatalf("Error: There are no misc/test01* exec json files!\n")
+	}
+	for _, execPath := range execs {
+		name := strings.TrimSuffix(filepath.Base(execPath), ".exec.json.txt")
+		t.Run(name, func(t *testing.T) {
+			verifyApp(t, genapp, execPath, filepath.Join(tmp, name), cgo)
+		})
+	}
+
+	// Each authentication mode is generated for SQLite so that the tests
+	// of its login, sessions or API keys are run.
+	for _, mode := range []string{"basic", "session", "apikey"} {
+		mode := mode
+		t.Run("test01sq_"+mode, func(t *testing.T) {
+			verifyApp(t, genapp, "../../misc/test01sq.exec.json.txt",
+				filepath.Join(tmp, "test01sq_"+mode), cgo, "-genAuth="+mode)
+		})
+	}
+
+	t.Log("...End of main::TestGeneratedApps")
+}
@@ -1,5 +1,8 @@
 module genapp
 
-go 1.12
+go 1.16
 
-require github.com/2kranki/go_util v1.0.3
+require (
+	github.com/2kranki/go_util v1.0.3
+	github.com/mattn/go-sqlite3 v1.14.6
+)
@@ -0,0 +1,19 @@
+// See License.txt in main repository directory
+
+// models contains the model files used by the generators. They are
+// embedded into genapp so that it can be run without a checkout of
+// this repository.
+
+// Notes:
+//	*	Each generator has its own subdirectory named by GenData.Name.
+//	*	A directory given with -mdldir overlays these files. See
+//		genCmn.ModelFS().
+
+package models
+
+import "embed"
+
+// FS holds the model subdirectories of all of the generators.
+//
+//go:embed cobj sqlapp
+var FS embed.FS
@@ -25,18 +25,19 @@ import (
 
 // DbProp defines an Objects Property.
 type DbProp struct {
-	Name     string `json:"name,omitempty"`     // External Name
-	Internal string `json:"internal,omitempty"` // Optional Internal Name
-	External string `json:"external,omitempty"` // Optional External Name
-	Desc     string `json:"desc,omitempty"`     // Optional Description
-	TypeDefn string `json:"type,omitempty"`     // Type Definition
-	Init     string `json: init,omitempty`      // Initialization
-	Object   bool   `json:"object,omitempty"`
-	Vis      string `json:"vis,omitempty"`    // Visibility: public,private,read-only,none
-	Base     string `json:"base,omitempty"`   // Base Struct or Pointer for Offset
-	Offset   int    `json:"offset,omitempty"` // Offset into Base in bytes
-	Shift    int    `json:"len,omitempty"`    // Shift Amount to put field in lowest bit
-	Size     int    `json:"Dec,omitempty"`    // Size in bits
+	Name     string    `json:"name,omitempty"`     // External Name
+	Internal string    `json:"internal,omitempty"` // Optional Internal Name
+	External string    `json:"external,omitempty"` // Optional External Name
+	Desc     string    `json:"desc,omitempty"`     // Optional Description
+	TypeDefn string    `json:"type,omitempty"`     // Type Definition
+	Init     string    `json: init,omitempty`      // Initialization
+	Object   bool      `json:"object,omitempty"`
+	Vis      string    `json:"vis,omitempty"`    // Visibility: public,private,read-only,none
+	Base     string    `json:"base,omitempty"`   // Base Struct or Pointer for Offset
+	Offset   int       `json:"offset,omitempty"` // Offset into Base in bytes
+	Shift    int       `json:"len,omitempty"`    // Shift Amount to put field in lowest bit
+	Size     int       `json:"Dec,omitempty"`    // Size in bits
+	Obj      *DbObject `json:"-"`                // Object of the Property
 }
 
 func (f *DbProp) GenBody() string {
@@ -58,16 +59,16 @@ func (f *DbProp) GenBody() string {
 
 	// Generate Get()
 	if f.Object && f.TypeDefn != "OBJ_ID" {
-		str.WriteString(fmt.Sprintf("\t%s *\t\t\t\t%s_get%s(\n", f.TypeDefn, dbStruct.Name, f.TitledName()))
+		str.WriteString(fmt.Sprintf("\t%s *\t\t\t\t%s_get%s(\n", f.TypeDefn, f.Obj.Name, f.TitledName()))
 	} else {
-		str.WriteString(fmt.Sprintf("\t%s\t\t\t\t%s_get%s(\n", f.TypeDefn, dbStruct.Name, f.TitledName()))
+		str.WriteString(fmt.Sprintf("\t%s\t\t\t\t%s_get%s(\n", f.TypeDefn, f.Obj.Name, f.TitledName()))
 	}
-	str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this\n", dbStruct.UpperName()))
+	str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this\n", f.Obj.UpperName()))
 	str.WriteString("\t)\n")
 	str.WriteString("\t{\n\n")
 	str.WriteString("#ifdef NDEBUG\n")
 	str.WriteString("#else\n")
-	str.WriteString(fmt.Sprintf("\t\tif (%s_Validate(this)) {\n", dbStruct.Name))
+	str.WriteString(fmt.Sprintf("\t\tif (%s_Validate(this)) {\n", f.Obj.Name))
 	str.WriteString("\t\t\tDEBUG_BREAK();\n")
 	if f.Object {
 		str.WriteString("\t\t\treturn OBJ_NIL;\n")
@@ -80,8 +81,8 @@ func (f *DbProp) GenBody() string {
 	str.WriteString("\t}\n\n\n")
 
 	// Generate Set()
-	str.WriteString(fmt.Sprintf("\tbool\t\t\t\t%s_set%s(\n", dbStruct.Name, f.TitledName()))
-	str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this,\n", dbStruct.UpperName()))
+	str.WriteString(fmt.Sprintf("\tbool\t\t\t\t%s_set%s(\n", f.Obj.Name, f.TitledName()))
+	str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this,\n", f.Obj.UpperName()))
 	if f.Object && f.TypeDefn != "OBJ_ID" {
 		str.WriteString(fmt.Sprintf("\t\t%s\t\t*pValue,\n", f.TypeDefn))
 	} else {
@@ -95,7 +96,7 @@ func (f *DbProp) GenBody() string {
 	str.WriteString("\t{\n")
 	str.WriteString("#ifdef NDEBUG\n")
 	str.WriteString("#else\n")
-	str.WriteString(fmt.Sprintf("\t\tif (%s_Validate(this)) {\n", dbStruct.Name))
+	str.WriteString(fmt.Sprintf("\t\tif (%s_Validate(this)) {\n", f.Obj.Name))
 	str.WriteString("\t\t\tDEBUG_BREAK();\n")
 	str.WriteString("\t\t\treturn false;\n")
 	str.WriteString("\t\t}\n")
@@ -123,15 +124,15 @@ func (f *DbProp) GenDefn() string {
 			str.WriteString(fmt.Sprintf("//%s - %s\n", f.Name, f.Desc))
 		}
 		if f.Object && f.TypeDefn != "OBJ_ID" {
-			str.WriteString(fmt.Sprintf("\t%s *\t\t\t\t%s_get%s(\n", f.TypeDefn, dbStruct.Name, f.TitledName()))
+			str.WriteString(fmt.Sprintf("\t%s *\t\t\t\t%s_get%s(\n", f.TypeDefn, f.Obj.Name, f.TitledName()))
 		} else {
-			str.WriteString(fmt.Sprintf("\t%s\t\t\t\t%s_get%s(\n", f.TypeDefn, dbStruct.Name, f.TitledName()))
+			str.WriteString(fmt.Sprintf("\t%s\t\t\t\t%s_get%s(\n", f.TypeDefn, f.Obj.Name, f.TitledName()))
 		}
-		str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this\n", dbStruct.UpperName()))
+		str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this\n", f.Obj.UpperName()))
 		str.WriteString("\t);\n\n")
 		if f.Vis == "public" {
-			str.WriteString(fmt.Sprintf("\tbool\t\t\t\t%s_set%s(\n", dbStruct.Name, f.TitledName()))
-			str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this,\n", dbStruct.UpperName()))
+			str.WriteString(fmt.Sprintf("\tbool\t\t\t\t%s_set%s(\n", f.Obj.Name, f.TitledName()))
+			str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this,\n", f.Obj.UpperName()))
 			if f.Object && f.TypeDefn != "OBJ_ID" {
 				str.WriteString(fmt.Sprintf("\t\t%s\t\t*pValue\n", f.TypeDefn))
 			} else {
@@ -160,16 +161,16 @@ func (f *DbProp) GenDefnPrivate() string {
 	}
 	if f.Vis == "private" {
 		if f.Object && f.TypeDefn != "OBJ_ID" {
-			str.WriteString(fmt.Sprintf("\t%s *\t\t\t\t%s_get%s(\n", f.TypeDefn, dbStruct.Name, f.TitledName()))
+			str.WriteString(fmt.Sprintf("\t%s *\t\t\t\t%s_get%s(\n", f.TypeDefn, f.Obj.Name, f.TitledName()))
 		} else {
-			str.WriteString(fmt.Sprintf("\t%s\t\t\t\t%s_get%s(\n", f.TypeDefn, dbStruct.Name, f.TitledName()))
+			str.WriteString(fmt.Sprintf("\t%s\t\t\t\t%s_get%s(\n", f.TypeDefn, f.Obj.Name, f.TitledName()))
 		}
-		str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this\n", dbStruct.UpperName()))
+		str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this\n", f.Obj.UpperName()))
 		str.WriteString("\t);\n\n")
 	}
 	if f.Vis == "private" || f.Vis == "read-only" || f.Vis == "ro" {
-		str.WriteString(fmt.Sprintf("\tbool\t\t\t\t%s_set%s(\n", dbStruct.Name, f.TitledName()))
-		str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this,\n", dbStruct.UpperName()))
+		str.WriteString(fmt.Sprintf("\tbool\t\t\t\t%s_set%s(\n", f.Obj.Name, f.TitledName()))
+		str.WriteString(fmt.Sprintf("\t\t%s_DATA\t*this,\n", f.Obj.UpperName()))
 		if f.Object && f.TypeDefn == "OBJ_ID" {
 			str.WriteString(fmt.Sprintf("\t\t%s\t\t*pValue\n", f.TypeDefn))
 		} else {
@@ -223,12 +224,12 @@ func (f *DbProp) GenProp() string {
 	if len(f.Base) > 0 {
 		str.WriteString(fmt.Sprintf("\"%s\",", f.Base))
 	} else {
-		str.WriteString(fmt.Sprintf("\"%s\",", dbStruct.DataName()))
+		str.WriteString(fmt.Sprintf("\"%s\",", f.Obj.DataName()))
 	}
 	if f.Offset < 0 {
-		str.WriteString(fmt.Sprintf("offsetof(%s,%s),", dbStruct.DataName(), name))
+		str.WriteString(fmt.Sprintf("offsetof(%s,%s),", f.Obj.DataName(), name))
 	} else {
-		str.WriteString(fmt.Sprintf("\"%s_DATA\",", dbStruct.UpperName()))
+		str.WriteString(fmt.Sprintf("\"%s_DATA\",", f.Obj.UpperName()))
 	}
 	if f.Size == 0 {
 		if f.Object && f.TypeDefn != "OBJ_ID" {
@@ -276,6 +277,17 @@ type DbObject struct {
 	Name  string   `json:"name,omitempty"`
 	Super string   `json:"super,omitempty"`
 	Props []DbProp `json:"properties,omitempty"`
+	// Ctx holds the definitions of the generation using this Object. If it
+	// is nil, the sharedData.Default Context is used.
+	Ctx *sharedData.Context `json:"-"`
+}
+
+// Context returns the definitions of the generation using this Object.
+func (t *DbObject) Context() *sharedData.Context {
+	if t.Ctx == nil {
+		return sharedData.Default
+	}
+	return t.Ctx
 }
 
 func (t *DbObject) DataName() string {
@@ -326,21 +338,32 @@ func DbStruct() *DbObject {
 // and stores the generic JSON Table as well as the
 // decoded structs.
 func ReadJsonFile(fn string) error {
+	return dbStruct.ReadJsonFile(fn)
+}
+
+// ReadJsonFile reads the input JSON file for app into
+// the Object.
+func (t *DbObject) ReadJsonFile(fn string) error {
 	var err error
 	var jsonPath string
 
 	jsonPath, _ = filepath.Abs(fn)
-	if sharedData.Debug() {
+	if t.Context().Debug() {
 		log.Println("json path:", jsonPath)
 	}
 
 	// Read in the json file structurally
-	if err = util.ReadJsonFileToData(jsonPath, &dbStruct); err != nil {
+	if err = util.ReadJsonFileToData(jsonPath, t); err != nil {
 		return errors.New(fmt.Sprintln("Error: unmarshalling", jsonPath, ", JSON input file:", err))
 	}
 
-	if sharedData.Debug() {
-		log.Println("\tJson Struct:", dbStruct)
+	// Link each property back to the object.
+	for i := range t.Props {
+		t.Props[i].Obj = t
+	}
+
+	if t.Context().Debug() {
+		log.Println("\tJson Struct:", t)
 	}
 
 	return nil
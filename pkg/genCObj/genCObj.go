@@ -18,13 +18,12 @@ import (
 	"flag"
 	"fmt"
 	"genapp/pkg/genCmn"
+	"genapp/pkg/sharedData"
 	"log"
 
 	"github.com/2kranki/go_util"
 )
 
-var sd *util.SharedData
-
 // FileDefns controls what files are generated.
 var FileDefs1 []genCmn.FileDefn = []genCmn.FileDefn{
 	{"obj_int_h.txt",
@@ -89,7 +88,7 @@ func (g *GenCObj) CreateOutputPath(dir []string, fn string) (*util.Path, error)
 	var outPath *util.Path
 
 	mapper := func(placeholderName string) string {
-		var name = DbStruct().Name
+		var name = object(g.g).Name
 		switch placeholderName {
 		case "Name":
 			if len(name) > 0 {
@@ -113,11 +112,10 @@ func (g *GenCObj) CreateOutputPath(dir []string, fn string) (*util.Path, error)
 func (g *GenCObj) ReadJsonFileData(gd *genCmn.GenData) error {
 	var err error
 
-	if err = ReadJsonFile(sd.DataPath()); err != nil {
+	if err = object(gd).ReadJsonFile(gd.Context().DataPath()); err != nil {
 		return fmt.Errorf("Error: Reading Data Json Input:%s %s\n",
-			sd.DataPath(), err.Error())
+			gd.Context().DataPath(), err.Error())
 	}
-	gd.TmplData.Data = DbStruct()
 
 	return nil
 }
@@ -133,24 +131,24 @@ func (g *GenCObj) SetupFile(gd *genCmn.GenData, fd genCmn.FileDefn, wrk *util.Wo
 
 	data := &genCmn.TaskData{}
 	data.FD = &fd
-	data.TD = DbStruct()
-	data.Data = DbStruct()
+	data.TD = object(gd)
+	data.Data = object(gd)
 
 	// Create the input model file path.
 	data.PathIn, err = gd.CreateModelPath(fd.ModelName)
 	if err != nil {
-		return fmt.Errorf("Error: %s: %s\n", data.PathIn.String(), err.Error())
+		return err
 	}
-	if sd.Debug() {
+	if gd.Context().Debug() {
 		log.Println("\t\tmodelPath=", data.PathIn.String())
 	}
 
 	// Create the output path
 	data.PathOut, err = g.CreateOutputPath(fd.FileDir, fd.FileName)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
-	if sd.Debug() {
+	if gd.Context().Debug() {
 		log.Println("\t\t outPath=", data.PathOut)
 	}
 
@@ -180,6 +178,15 @@ func init() {
 
 }
 
+// object returns the Object being generated which is set up by
+// GenerateWith().
+func object(g *genCmn.GenData) *DbObject {
+	if obj, ok := g.TmplData.Data.(*DbObject); ok {
+		return obj
+	}
+	return DbStruct()
+}
+
 //----------------------------------------------------------------------------
 //								createOutputDir
 //----------------------------------------------------------------------------
@@ -191,7 +198,7 @@ func CreateOutputDir(g *genCmn.GenData, dir []string) error {
 	var outPath *util.Path
 
 	mapper := func(placeholderName string) string {
-		var name = DbStruct().Name
+		var name = object(g).Name
 		switch placeholderName {
 		case "Name":
 			if len(name) > 0 {
@@ -201,7 +208,7 @@ func CreateOutputDir(g *genCmn.GenData, dir []string) error {
 		return ""
 	}
 
-	outPath = util.NewPath(sd.OutDir())
+	outPath = util.NewPath(g.Context().OutDir())
 	for _, d := range dir {
 		if len(dir) > 0 {
 			outPath = outPath.Append(d)
@@ -227,21 +234,15 @@ func CreateOutputDirs(g *genCmn.GenData) error {
 	var err error
 	var outDir *util.Path
 
-	if sd.Noop() {
+	if g.Context().Noop() {
 		log.Printf("NOOP -- Skipping Creating directories\n")
 		return nil
 	}
-	outDir = util.NewPath(sd.OutDir())
-
-	// We only delete main directory if forced to. Otherwise, we
-	// will simply replace our files within it.
-	if sd.Force() {
-		log.Printf("\tRemoving directory: %s...\n", outDir.String())
-		if err = outDir.RemoveDir(); err != nil {
-			return fmt.Errorf("Error: Could not remove output directory: %s: %s\n",
-				outDir.String(), err.Error())
-		}
-	}
+	outDir = util.NewPath(g.Context().OutDir())
+
+	// We do not delete the main directory since it may have hand-written
+	// code. Files which are no longer generated are removed using the
+	// manifest of the prior run (see genCmn.Manifest).
 
 	// Create the main directory if needed.
 	if !outDir.IsPathDir() {
@@ -270,17 +271,21 @@ func CreateOutputDirs(g *genCmn.GenData) error {
 //----------------------------------------------------------------------------
 
 func CreateOutputFilePath(name string, dir []string, fn string) (*util.Path, error) {
+	return createOutputFilePath(sharedData.Default, name, dir, fn)
+}
+
+func createOutputFilePath(ctx *sharedData.Context, name string, dir []string, fn string) (*util.Path, error) {
 	var outPath *util.Path
 
 	mapper := func(varSub string) string {
 		switch varSub {
 		case "Name":
-			return DbStruct().Name
+			return name
 		}
 		return ""
 	}
 
-	outPath = util.NewPath(sd.OutDir())
+	outPath = util.NewPath(ctx.OutDir())
 	for _, d := range dir {
 		outPath = outPath.Append(d)
 	}
@@ -288,7 +293,7 @@ func CreateOutputFilePath(name string, dir []string, fn string) (*util.Path, err
 	outPath = outPath.Expand(mapper)
 
 	if outPath.IsPathRegularFile() {
-		if !sd.Force() {
+		if !ctx.Force() {
 			return outPath, fmt.Errorf("Over-write error of: %s\n", outPath)
 		}
 	}
@@ -305,11 +310,10 @@ func CreateOutputFilePath(name string, dir []string, fn string) (*util.Path, err
 func ReadJsonFileData(g *genCmn.GenData) error {
 	var err error
 
-	if err = ReadJsonFile(sd.DataPath()); err != nil {
+	if err = object(g).ReadJsonFile(g.Context().DataPath()); err != nil {
 		return fmt.Errorf("Error: Reading Data Json Input:%s %s\n",
-			sd.DataPath(), err.Error())
+			g.Context().DataPath(), err.Error())
 	}
-	g.TmplData.Data = DbStruct()
 
 	return nil
 }
@@ -323,26 +327,28 @@ func ReadJsonFileData(g *genCmn.GenData) error {
 func SetupFile(g *genCmn.GenData, fd genCmn.FileDefn, wrk *util.WorkQueue) error {
 	var err error
 
+	ctx := g.Context()
+	obj := object(g)
 	data := &genCmn.TaskData{}
 	data.FD = &fd
-	data.TD = DbStruct()
-	data.Data = DbStruct()
+	data.TD = obj
+	data.Data = obj
 
 	// Create the input model file path.
 	data.PathIn, err = g.CreateModelPath(fd.ModelName)
 	if err != nil {
-		return fmt.Errorf("Error: %s: %s\n", data.PathIn.String(), err.Error())
+		return err
 	}
-	if sd.Debug() {
+	if ctx.Debug() {
 		log.Println("\t\tmodelPath=", data.PathIn.String())
 	}
 
 	// Create the output path
-	data.PathOut, err = CreateOutputFilePath(dbStruct.Name, fd.FileDir, fd.FileName)
+	data.PathOut, err = createOutputFilePath(ctx, obj.Name, fd.FileDir, fd.FileName)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
-	if sd.Debug() {
+	if ctx.Debug() {
 		log.Println("\t\t outPath=", data.PathOut)
 	}
 
@@ -356,14 +362,24 @@ func SetupFile(g *genCmn.GenData, fd genCmn.FileDefn, wrk *util.WorkQueue) error
 //								GenCObj
 //============================================================================
 
+// Generate generates the object using the default Context.
 func Generate(inDefns map[string]interface{}) error {
+	return GenerateWith(sharedData.Default)
+}
+
+// GenerateWith generates the object defined by the Context. Each call
+// has its own data so more than one object can be generated at the
+// same time given separate Contexts.
+func GenerateWith(ctx *sharedData.Context) error {
 	var genData genCmn.GenData
 
+	obj := &DbObject{Ctx: ctx}
+	genData.Ctx = ctx
 	genData.Name = "cobj"
 	genData.Mapper = func(varSub string) string {
 		switch varSub {
 		case "Name":
-			return dbStruct.Name
+			return obj.Name
 		}
 		return ""
 	}
@@ -371,14 +387,12 @@ func Generate(inDefns map[string]interface{}) error {
 	genData.CreateOutputDirs = CreateOutputDirs
 	genData.ReadJsonData = ReadJsonFileData
 	genData.SetupFile = SetupFile
-	genData.TmplData.Data = DbStruct()
+	genData.TmplData.Data = obj
 
-	if sd.Debug() {
+	if ctx.Debug() {
 		log.Println("GenCObj: In Debug Mode...")
 		log.Printf("\t  args: %q\n", flag.Args())
 	}
 
-	genData.GenOutput()
-
-	return nil
+	return genData.GenOutput()
 }
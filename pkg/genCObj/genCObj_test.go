@@ -7,120 +7,105 @@ package genCObj
 
 import (
 	"genapp/pkg/sharedData"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
-	"time"
-	//"github.com/2kranki/go_util"
 )
 
-const jsonTestPath = "../../misc/"
+const jsonTestPath = "../../misc/testObj01/"
 
-// Setup Shared Data for the current test. We assume Debug and Noop.
+// Setup a Context for the current test. We assume Debug and Noop.
 // So, things don't change in the real environment.
-func setupShared(t *testing.T) {
-
-	t.Log("genCObj::SetupShared()")
-
-	sharedData.SetDebug(true)
-	sharedData.SetForce(false)
-	sharedData.SetNoop(true)
-	sharedData.SetQuiet(false)
-	sharedData.SetMdlDir("../../models/")
-	sharedData.SetOutDir("/tmp/testgen")
-	sharedData.SetTime(time.Now().Format("Mon Jan _2, 2006 15:04"))
-	sharedData.SetFunc("Time", sharedData.Time)
-	sharedData.SetDataPath("./test/db.json.txt")
-	sharedData.SetMainPath("./test/main.json.txt")
-
-	t.Log("...End of genCObj::SetupShared")
+func setupContext(t *testing.T) *sharedData.Context {
+
+	t.Log("genCObj::setupContext()")
+
+	ctx := sharedData.New()
+	ctx.SetDebug(true)
+	ctx.SetForce(false)
+	ctx.SetNoop(true)
+	ctx.SetQuiet(false)
+	ctx.SetMdlDir("")
+	ctx.SetOutDir("/tmp/testgen")
+	ctx.SetTime("Mon Jan  1, 2001 00:00")
+	ctx.SetDataPath(jsonTestPath + "test.json.txt")
+	ctx.SetMainPath(jsonTestPath + "main.json.txt")
+
+	t.Log("...End of genCObj::setupContext")
+	return ctx
 }
 
-// Setup GenData and sharedData structures for testing.
-func setupForCObj(t *testing.T, genData *GenData) {
+func TestReadJsonFile(t *testing.T) {
 
-	t.Log("genCObj::TestCreateModelPath()")
+	t.Log("genCObj::TestReadJsonFile()")
+	obj := &DbObject{Ctx: setupContext(t)}
 
-	genData.Name = "cobj"
-	genData.Mapper = func(varSub string) string {
-		switch varSub {
-		case "Name":
-			//return dbStruct.Name
-			return "???"
-		}
-		return ""
+	if err := obj.ReadJsonFile(obj.Context().DataPath()); err != nil {
+		t.Fatalf("ReadJsonFile() failed: %s\n", err)
 	}
-	//genData.FileDefs1 = &FileDefs1
-	//genData.CreateOutputDirs = CreateOutputDirs
-	//genData.ReadJsonData = ReadJsonFileData
-	//genData.SetupFile = SetupFile
-	//genData.TmplData.Data = DbStruct()
-
-	t.Log("...End of genCObj::TestCreateModelPath")
-}
-
-func TestReadJsonFiles(t *testing.T) {
-	var data *appData.Database
-	var err error
-
-	t.Log("genCObj::TestReadJsonFiles()")
-	setupShared(t)
-
-	if err = readJsonFiles(); err != nil {
-		t.Errorf("ReadJsonFile() failed: %s\n", err)
+	if obj.Name != "anObj" {
+		t.Errorf("ReadJsonFile() failed: Name should be 'anObj' but is '%s'\n", obj.Name)
 	}
-	data = appData.AppStruct()
-
-	if data.Name != "Finances" {
-		t.Errorf("ReadJsonFile() failed: Name should be 'Finances' but is '%s'\n", data.Name)
+	if obj.Super != "xyz" {
+		t.Errorf("ReadJsonFile() failed: Super should be 'xyz' but is '%s'\n", obj.Super)
 	}
-	if len(data.Tables) != 2 {
-		t.Errorf("ReadJsonFile() failed: should be 2 tables but is %d\n", len(data.Tables))
+	if len(obj.Props) != 3 {
+		t.Fatalf("ReadJsonFile() failed: should be 3 properties but is %d\n", len(obj.Props))
 	}
-	if len(data.Tables[0].Fields) != 8 {
-		t.Errorf("ReadJsonFile() failed: should be 8 fields in table[0] but is %d\n", len(data.Tables[0].Fields))
+	for i := range obj.Props {
+		if obj.Props[i].Obj != obj {
+			t.Errorf("ReadJsonFile() failed: property %s is not linked to its object\n",
+				obj.Props[i].Name)
+		}
 	}
 
-	t.Log("Successfully, completed: genCObj::TestReadJsonFiles")
+	t.Log("Successfully, completed: genCObj::TestReadJsonFile")
 }
 
-func TestCreateModelPath(t *testing.T) {
-	var name string
-	var name2 string
-	var err error
+func TestCreateOutputFilePath(t *testing.T) {
 
-	t.Log("genCObj::TestCreateModelPath()")
-	setupShared(t)
+	t.Log("genCObj::TestCreateOutputFilePath()")
+	ctx := setupContext(t)
 
-	if name, err = createModelPath("../models/sqlapp/tableio.go.tmpl.txt"); err != nil {
-		t.Errorf("createModelPath() failed: %s\n", err)
+	name, err := createOutputFilePath(ctx, "anObj", []string{"src"}, "${Name}.c")
+	if err != nil {
+		t.Errorf("createOutputFilePath() failed: %s\n", err)
 	}
-	t.Logf("\t../Models/sqlapp/tableio.go.tmpl.txt -> '%s'\n", name)
-
-	if name2, err = createModelPath("tableio.go.tmpl.txt"); err != nil {
-		t.Errorf("createModelPath() failed: %s\n", err)
-	}
-	t.Logf("\ttableio.go.tmpl.txt -> '%s'\n", name2)
-
-	if name != name2 {
-		t.Errorf("createModelPath() file names don't match!\n")
+	t.Logf("\tsrc/${Name}.c -> '%s'\n", name)
+	if name.String() != "/tmp/testgen/src/anObj.c" {
+		t.Errorf("createOutputFilePath() file path isn't correct!\n")
 	}
 
-	t.Log("Successfully, completed: genCObj::TestCreateModelPath")
+	t.Log("Successfully, completed: genCObj::TestCreateOutputFilePath")
 }
 
-func TestCreateOutputPath(t *testing.T) {
-	var name string
-	var err error
-
-	t.Log("genCObj::TestCreateOutputPath()")
-	setupShared(t)
+func TestGenerateWith(t *testing.T) {
 
-	if name, err = createOutputPath("tableio.go"); err != nil {
-		t.Errorf("createModelPath() failed: %s\n", err)
+	t.Log("genCObj::TestGenerateWith()")
+	tmp, err := ioutil.TempDir("", "genCObj")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
 	}
-	t.Logf("\ttableio.go -> '%s'\n", name)
-	if name != "/tmp/gen/tableio.go" {
-		t.Errorf("createOutputPath() file path isn't correct!\n")
+	defer os.RemoveAll(tmp)
+
+	ctx := setupContext(t)
+	ctx.SetDebug(false)
+	ctx.SetNoop(false)
+	ctx.SetQuiet(true)
+	ctx.SetOutDir(tmp)
+	if err = GenerateWith(ctx); err != nil {
+		t.Fatalf("GenerateWith() failed: %s\n", err)
+	}
+
+	for _, fd := range FileDefs1 {
+		fn := filepath.Join(append(append([]string{tmp}, fd.FileDir...),
+			strings.Replace(fd.FileName, "${Name}", "anObj", -1))...)
+		if _, err := os.Stat(fn); err != nil {
+			t.Errorf("GenerateWith() did not generate %s: %s\n", fn, err)
+		}
 	}
 
-	t.Log("Successfully, completed: genCObj::TestCreateOutputPath")
+	t.Log("Successfully, completed: genCObj::TestGenerateWith")
 }
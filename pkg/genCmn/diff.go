@@ -0,0 +1,414 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Dry-Run Differences
+
+// Notes:
+//	1.	With -noop (or the diff command), nothing is written. Instead,
+//		each file is compared with what is on disk and the changes are
+//		recorded as created, modified, unchanged, kept (edited by hand
+//		so not replaced) or deleted.
+//	2.	The differences are given as unified diffs with 3 lines of
+//		context. The lines are compared using the Myers algorithm.
+//	3.	The changes are also given as JSON (-diffjson) so that review
+//		tooling can show the impact of a change to the data json file.
+
+package genCmn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The statuses of a FileChange.
+const (
+	ChangeCreated   = "created"
+	ChangeModified  = "modified"
+	ChangeUnchanged = "unchanged"
+	ChangeKept      = "kept"
+	ChangeDeleted   = "deleted"
+)
+
+// FileChange is what regeneration would do to one file.
+type FileChange struct {
+	Path   string `json:"path"` // Relative to the output directory
+	Status string `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// fileChangeList is the changes recorded by a generation.
+type fileChangeList struct {
+	sync.Mutex
+	list []FileChange
+}
+
+//----------------------------------------------------------------------------
+//								Record Changes
+//----------------------------------------------------------------------------
+
+// relOutPath returns the path relative to the output directory.
+func (g *GenData) relOutPath(out string) string {
+
+	if rel, err := filepath.Rel(g.Context().OutDir(), out); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(out)
+}
+
+// addFileChange records the status of an output file.
+func (g *GenData) addFileChange(out, status, diff string) {
+
+	g.changes.Lock()
+	g.changes.list = append(g.changes.list, FileChange{Path: g.relOutPath(out), Status: status, Diff: diff})
+	g.changes.Unlock()
+}
+
+// diffOutput records how the output file would change if the data were
+// written to it.
+func (g *GenData) diffOutput(out string, data []byte) error {
+
+	old, err := ioutil.ReadFile(out)
+	if err != nil {
+		if os.IsNotExist(err) {
+			name := g.relOutPath(out)
+			g.addFileChange(out, ChangeCreated, UnifiedDiff("/dev/null", "b/"+name, "", string(data)))
+			return nil
+		}
+		return fmt.Errorf("Error: Reading %s - %s\n", out, err.Error())
+	}
+	if bytes.Equal(old, data) {
+		g.addFileChange(out, ChangeUnchanged, "")
+		return nil
+	}
+	name := g.relOutPath(out)
+	g.addFileChange(out, ChangeModified, UnifiedDiff("a/"+name, "b/"+name, string(old), string(data)))
+
+	return nil
+}
+
+// diffCopy records how copying the model file would change the output.
+func (t *TaskData) diffCopy() error {
+
+	data, err := fs.ReadFile(t.gen.ModelFS(), t.PathIn.String())
+	if err != nil {
+		return fmt.Errorf("Error: Reading model %s - %s\n", t.PathIn.String(), err.Error())
+	}
+
+	return t.gen.diffOutput(t.PathOut.String(), data)
+}
+
+// diffCopyDir records how copying the model directory would change the
+// output directory. Files only in the output directory would be deleted
+// since it is replaced.
+func (t *TaskData) diffCopyDir() error {
+
+	mdls := t.gen.ModelFS()
+	mdl := t.PathIn.String()
+	out := t.outputOf()
+	copied := map[string]bool{}
+	err := fs.WalkDir(mdls, mdl,
+		func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(mdls, name)
+			if err != nil {
+				return err
+			}
+			fn := filepath.Join(out, filepath.FromSlash(strings.TrimPrefix(name, mdl+"/")))
+			copied[fn] = true
+			return t.gen.diffOutput(fn, data)
+		})
+	if err != nil {
+		return fmt.Errorf("Error: Reading model %s - %s\n", mdl, err.Error())
+	}
+
+	return filepath.Walk(out,
+		func(fn string, fi os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !fi.IsDir() && !copied[fn] {
+				t.gen.addFileChange(fn, ChangeDeleted, "")
+			}
+			return nil
+		})
+}
+
+// FileChanges returns the changes recorded so far in path order. A file
+// recorded more than once is only given once.
+func (g *GenData) FileChanges() []FileChange {
+	var list []FileChange
+
+	g.changes.Lock()
+	changes := append([]FileChange{}, g.changes.list...)
+	g.changes.Unlock()
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	for i, c := range changes {
+		if i == 0 || c.Path != changes[i-1].Path {
+			list = append(list, c)
+		}
+	}
+
+	return list
+}
+
+// WriteFileChanges writes the unified diffs of the changes followed by a
+// summary of them.
+func (g *GenData) WriteFileChanges(w io.Writer) {
+	counts := map[string]int{}
+
+	changes := g.FileChanges()
+	for _, c := range changes {
+		counts[c.Status]++
+		if len(c.Diff) > 0 {
+			io.WriteString(w, c.Diff)
+		}
+	}
+	fmt.Fprintf(w, "\nSummary:\n")
+	for _, c := range changes {
+		if c.Status != ChangeUnchanged {
+			fmt.Fprintf(w, "\t%-9s %s\n", c.Status, c.Path)
+		}
+	}
+	fmt.Fprintf(w, "%d created, %d modified, %d unchanged, %d kept, %d deleted\n",
+		counts[ChangeCreated], counts[ChangeModified], counts[ChangeUnchanged],
+		counts[ChangeKept], counts[ChangeDeleted])
+}
+
+// WriteFileChangesJson writes the changes as JSON to the path or to
+// standard output if it is "-".
+func (g *GenData) WriteFileChangesJson(fn string) error {
+	var err error
+
+	data, err := json.MarshalIndent(struct {
+		Files []FileChange `json:"files"`
+	}{g.FileChanges()}, "", "\t")
+	if err != nil {
+		return fmt.Errorf("Error: Encoding changes - %s\n", err.Error())
+	}
+	data = append(data, '\n')
+	if fn == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err = ioutil.WriteFile(fn, data, 0644); err != nil {
+		return fmt.Errorf("Error: Writing %s - %s\n", fn, err.Error())
+	}
+
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//								Unified Diff
+//----------------------------------------------------------------------------
+
+// diffOp is one line of an edit script which is kept (' '), deleted ('-')
+// or inserted ('+').
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffLines returns the shortest edit script from a to b.
+func diffLines(a, b []string) []diffOp {
+	var ops []diffOp
+
+	// Trim the common prefix and suffix.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	for _, l := range a[:pre] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	ops = append(ops, myers(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	for _, l := range a[len(a)-suf:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	return ops
+}
+
+// myers returns the shortest edit script from a to b using the Myers
+// algorithm.
+func myers(a, b []string) []diffOp {
+	var trace [][]int
+	var ops []diffOp
+
+	n, m := len(a), len(b)
+	max := n + m
+	off := max + 1
+	v := make([]int, 2*max+3)
+
+	// Find the length of the shortest edit script keeping the furthest
+	// x reached on each diagonal k (x - y) for each number of edits d.
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		trace = append(trace, append([]int(nil), v[off-d:off+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Backtrack through the trace to build the edit script in reverse.
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d] }
+		k := x - y
+		var pk int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := 0
+		if d > 0 {
+			px = at(pk)
+		}
+		py := px - pk
+		for x > px && y > py {
+			x--
+			y--
+			ops = append(ops, diffOp{' ', a[x]})
+		}
+		if d > 0 {
+			if x == px {
+				y--
+				ops = append(ops, diffOp{'+', b[y]})
+			} else {
+				x--
+				ops = append(ops, diffOp{'-', a[x]})
+			}
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+
+	return ops
+}
+
+// UnifiedDiff returns the unified diff with 3 lines of context from the
+// old text to the new one or "" if they are the same.
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	var str strings.Builder
+	const context = 3
+
+	if oldText == newText {
+		return ""
+	}
+	ops := diffLines(splitLines(oldText), splitLines(newText))
+	fmt.Fprintf(&str, "--- %s\n+++ %s\n", oldName, newName)
+
+	for i := 0; i < len(ops); {
+		// Find the next change and the end of its hunk which includes
+		// any changes within twice the context of each other.
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*context {
+				break
+			}
+		}
+		end += context
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		// Count the lines before the hunk and within it.
+		aLine, bLine := 0, 0
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				aLine++
+			}
+			if op.kind != '-' {
+				bLine++
+			}
+		}
+		aCnt, bCnt := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				aCnt++
+			}
+			if op.kind != '-' {
+				bCnt++
+			}
+		}
+		if aCnt > 0 {
+			aLine++
+		}
+		if bCnt > 0 {
+			bLine++
+		}
+
+		fmt.Fprintf(&str, "@@ -%d,%d +%d,%d @@\n", aLine, aCnt, bLine, bCnt)
+		for _, op := range ops[start:end] {
+			str.WriteByte(op.kind)
+			str.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				str.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+
+	return str.String()
+}
+
+// splitLines splits the text into lines keeping their line endings.
+func splitLines(text string) []string {
+
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
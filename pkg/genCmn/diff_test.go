@@ -0,0 +1,131 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test Dry-Run Differences
+
+package genCmn
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"genapp/pkg/sharedData"
+
+	"github.com/2kranki/go_util"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+
+	t.Log("genCmn::TestUnifiedDiff()")
+
+	oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	newText := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+	want := "--- a/x\n+++ b/x\n" +
+		"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n" +
+		"@@ -13,3 +13,4 @@\n 13\n 14\n 15\n+16\n"
+	if str := UnifiedDiff("a/x", "b/x", oldText, newText); str != want {
+		t.Errorf("UnifiedDiff() should be:\n%s\nbut is:\n%s\n", want, str)
+	}
+	if str := UnifiedDiff("a/x", "b/x", oldText, oldText); str != "" {
+		t.Errorf("UnifiedDiff() of the same text should be empty: %s\n", str)
+	}
+	want = "--- /dev/null\n+++ b/x\n@@ -0,0 +1,2 @@\n+a\n+b\n\\ No newline at end of file\n"
+	if str := UnifiedDiff("/dev/null", "b/x", "", "a\nb"); str != want {
+		t.Errorf("UnifiedDiff() should be:\n%s\nbut is:\n%s\n", want, str)
+	}
+
+	// The edit script must turn the old lines into the new ones.
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		var a, b []string
+		for j := rnd.Intn(20); j > 0; j-- {
+			a = append(a, string(rune('a'+rnd.Intn(4))))
+		}
+		for j := rnd.Intn(20); j > 0; j-- {
+			b = append(b, string(rune('a'+rnd.Intn(4))))
+		}
+		var ga, gb []string
+		for _, op := range diffLines(a, b) {
+			if op.kind != '+' {
+				ga = append(ga, op.line)
+			}
+			if op.kind != '-' {
+				gb = append(gb, op.line)
+			}
+		}
+		if strings.Join(ga, "") != strings.Join(a, "") || strings.Join(gb, "") != strings.Join(b, "") {
+			t.Fatalf("diffLines(%q, %q) is not valid\n", a, b)
+		}
+	}
+
+	t.Log("...End of genCmn::TestUnifiedDiff")
+}
+
+func TestFileChanges(t *testing.T) {
+	var err error
+
+	t.Log("genCmn::TestFileChanges()")
+	setupShared(t)
+
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	sharedData.SetOutDir(dir)
+	defer sharedData.SetOutDir("/tmp/testgen")
+	os.MkdirAll(filepath.Join(dir, "models", "sqlapp"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "models", "sqlapp", "x.tmpl.txt"), []byte("[[.]]\n"), 0644)
+	sharedData.SetMdlDir(filepath.Join(dir, "models"))
+	defer sharedData.SetMdlDir("../../models/")
+	g := NewGenData()
+
+	// With -noop, nothing is written, but the changes are recorded.
+	ioutil.WriteFile(filepath.Join(dir, "same.txt"), []byte("same\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "mod.txt"), []byte("old\n"), 0644)
+	for _, fn := range []string{"same", "mod", "new"} {
+		out := util.NewPath(filepath.Join(dir, fn+".txt"))
+		if err = g.GenTextFile(util.NewPath("sqlapp/x.tmpl.txt"), out, fn); err != nil {
+			t.Fatalf("GenTextFile() failed: %s\n", err)
+		}
+	}
+	if _, err = os.Stat(filepath.Join(dir, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("GenTextFile() should not have written new.txt: %v\n", err)
+	}
+	changes := g.FileChanges()
+	want := []FileChange{
+		{"mod.txt", ChangeModified, "--- a/mod.txt\n+++ b/mod.txt\n@@ -1,1 +1,1 @@\n-old\n+mod\n"},
+		{"new.txt", ChangeCreated, "--- /dev/null\n+++ b/new.txt\n@@ -0,0 +1,1 @@\n+new\n"},
+		{"same.txt", ChangeUnchanged, ""},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("FileChanges() should be %+v but is %+v\n", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("FileChanges() should be %+v but is %+v\n", want[i], changes[i])
+		}
+	}
+
+	var str strings.Builder
+	g.WriteFileChanges(&str)
+	if !strings.Contains(str.String(), "1 created, 1 modified, 1 unchanged, 0 kept, 0 deleted") {
+		t.Errorf("WriteFileChanges() invalid summary:\n%s\n", str.String())
+	}
+	fn := filepath.Join(dir, "changes.json")
+	if err = g.WriteFileChangesJson(fn); err != nil {
+		t.Fatalf("WriteFileChangesJson() failed: %s\n", err)
+	}
+	var js struct{ Files []FileChange }
+	data, _ := ioutil.ReadFile(fn)
+	if err = json.Unmarshal(data, &js); err != nil || len(js.Files) != 3 || js.Files[1].Status != ChangeCreated {
+		t.Errorf("WriteFileChangesJson() invalid json: %s %v\n", data, err)
+	}
+
+	t.Log("...End of genCmn::TestFileChanges")
+}
@@ -0,0 +1,202 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Format Generated Output
+
+// Notes:
+//	1.	Generated text is passed through the formatter registered for
+//		the extension of its output file before it is written. Other
+//		formatters such as for SQL or HTML may be added with
+//		RegisterFormatter().
+//	2.	Go output is formatted the same as gofmt after any unused
+//		imports are removed. An import is only removed if the names of
+//		all of the packages used can be matched to the imports since
+//		the name of a package is guessed from its import path.
+
+package genCmn
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Formatter formats the text generated for the named output file.
+type Formatter func(name string, src []byte) ([]byte, error)
+
+var formatters = struct {
+	sync.RWMutex
+	exts map[string]Formatter
+}{exts: map[string]Formatter{".go": FormatGo}}
+
+// RegisterFormatter sets the formatter for output files with the given
+// extension such as ".sql". A nil formatter removes it.
+func RegisterFormatter(ext string, f Formatter) {
+
+	formatters.Lock()
+	if f == nil {
+		delete(formatters.exts, ext)
+	} else {
+		formatters.exts[ext] = f
+	}
+	formatters.Unlock()
+}
+
+// formatOutput formats the text generated from the model for the output
+// file if there is a formatter for its extension.
+func formatOutput(mdl, out, text string) (string, error) {
+
+	formatters.RLock()
+	f := formatters.exts[filepath.Ext(out)]
+	formatters.RUnlock()
+	if f == nil {
+		return text, nil
+	}
+	data, err := f(out, []byte(text))
+	if err != nil {
+		return "", fmt.Errorf("Error: Formatting the output of template %s: %s\n", mdl, err.Error())
+	}
+
+	return string(data), nil
+}
+
+//----------------------------------------------------------------------------
+//								FormatGo
+//----------------------------------------------------------------------------
+
+var errLine = regexp.MustCompile(`^[^:]*:?(\d+):(\d+): `)
+
+// FormatGo formats Go source the same as gofmt after removing any unused
+// imports. Syntax errors are given with the line in error.
+func FormatGo(name string, src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return nil, goSourceError(err, src)
+	}
+	if removeUnusedImports(file) {
+		if err = format.Node(&buf, fset, file); err != nil {
+			return nil, err
+		}
+		src = buf.Bytes()
+	}
+	data, err := format.Source(src)
+	if err != nil {
+		return nil, goSourceError(err, src)
+	}
+
+	return data, nil
+}
+
+// goSourceError adds the source line to the first error.
+func goSourceError(err error, src []byte) error {
+
+	msg := strings.Split(err.Error(), "\n")[0]
+	if m := errLine.FindStringSubmatch(msg); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		lines := strings.Split(string(src), "\n")
+		if n > 0 && n <= len(lines) {
+			return fmt.Errorf("%s\n\t%d: %s", msg, n, strings.TrimSpace(lines[n-1]))
+		}
+	}
+	return err
+}
+
+// importName returns the package name of an import which is the given
+// name or the one guessed from its path.
+func importName(spec *ast.ImportSpec) string {
+
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	p, _ := strconv.Unquote(spec.Path.Value)
+	name := path.Base(p)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(p))
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "go-"), "go_")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "-go"), "_go")
+
+	return strings.Replace(name, "-", "_", -1)
+}
+
+// removeUnusedImports removes the imports which are not used by the file
+// returning true if any were.
+func removeUnusedImports(file *ast.File) bool {
+	var removed bool
+
+	// Find the package names used which are the unresolved identifiers
+	// selected from.
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	// Only remove imports if every package used has an import.
+	names := map[string]bool{}
+	for _, spec := range file.Imports {
+		names[importName(spec)] = true
+	}
+	for n := range used {
+		if !names[n] {
+			return false
+		}
+	}
+
+	unused := func(spec ast.Spec) bool {
+		name := importName(spec.(*ast.ImportSpec))
+		return name != "_" && name != "." && !used[name]
+	}
+	var decls []ast.Decl
+	for _, d := range file.Decls {
+		gen, ok := d.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, d)
+			continue
+		}
+		var specs []ast.Spec
+		for _, spec := range gen.Specs {
+			if unused(spec) {
+				removed = true
+			} else {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+		if len(specs) > 0 || gen.Lparen.IsValid() {
+			decls = append(decls, d)
+		}
+	}
+	if !removed {
+		return false
+	}
+	file.Decls = decls
+	var imports []*ast.ImportSpec
+	for _, spec := range file.Imports {
+		if !unused(spec) {
+			imports = append(imports, spec)
+		}
+	}
+	file.Imports = imports
+
+	return true
+}
@@ -0,0 +1,61 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test Formatting of Generated Output
+
+package genCmn
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatGo(t *testing.T) {
+
+	t.Log("genCmn::TestFormatGo()")
+
+	src := "package x\n\nimport (\n\"fmt\"\n  \"os\"\n\t\"strings\"\n\t_ \"embed\"\n\t\"github.com/2kranki/go_util\"\n)\n\n" +
+		"func F( s string ) string {\nos := strings.TrimSpace(s)\n  return util.String(os) }\n"
+	want := "package x\n\nimport (\n\t_ \"embed\"\n\t\"github.com/2kranki/go_util\"\n\t\"strings\"\n)\n\n" +
+		"func F(s string) string {\n\tos := strings.TrimSpace(s)\n\treturn util.String(os)\n}\n"
+	data, err := FormatGo("x.go", []byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo() failed: %s\n", err)
+	}
+	if string(data) != want {
+		t.Errorf("FormatGo() should be:\n%s\nbut is:\n%s\n", want, data)
+	}
+
+	// An import is kept if a package used has no matching import.
+	src = "package x\n\nimport (\n\"fmt\"\n\tyaml \"gopkg.in/yaml.v2\"\n)\n\nvar _ = other.X\n"
+	if data, err = FormatGo("x.go", []byte(src)); err != nil || !strings.Contains(string(data), "\"fmt\"") {
+		t.Errorf("FormatGo() should have kept the imports: %s %v\n", data, err)
+	}
+
+	// Syntax errors give the line and the template.
+	src = "package x\n\nfunc F() {\n\tvar 1x int\n}\n"
+	_, err = formatOutput("sqlapp/x.go.tmpl.txt", "/tmp/x.go", src)
+	if err == nil || !strings.Contains(err.Error(), "sqlapp/x.go.tmpl.txt") || !strings.Contains(err.Error(), "4: var 1x int") {
+		t.Errorf("formatOutput() should have failed with the template and line: %v\n", err)
+	}
+
+	t.Log("...End of genCmn::TestFormatGo")
+}
+
+func TestRegisterFormatter(t *testing.T) {
+
+	t.Log("genCmn::TestRegisterFormatter()")
+
+	if str, err := formatOutput("x.sql.txt", "x.sql", "select  *"); err != nil || str != "select  *" {
+		t.Errorf("formatOutput() should not have changed %q: %v\n", str, err)
+	}
+	RegisterFormatter(".sql", func(name string, src []byte) ([]byte, error) {
+		return []byte(strings.Join(strings.Fields(string(src)), " ")), nil
+	})
+	defer RegisterFormatter(".sql", nil)
+	if str, err := formatOutput("x.sql.txt", "x.sql", "select  *"); err != nil || str != "select *" {
+		t.Errorf("formatOutput() should have formatted %q: %v\n", str, err)
+	}
+
+	t.Log("...End of genCmn::TestRegisterFormatter")
+}
@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"genapp/pkg/mainData"
 	"genapp/pkg/sharedData"
+	"io/fs"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
 
 	"github.com/2kranki/go_util"
 )
@@ -69,6 +72,9 @@ type TmplData struct {
 // not support structs.  (Not certain why yet.) We also maintain the data
 // in structs for easier access by the generation functions.
 type GenData struct {
+	// Ctx holds the definitions of this generation. If it is nil, the
+	// sharedData.Default Context is used.
+	Ctx *sharedData.Context
 	// Name indicates the type of generation being performed. Valid values
 	// are:
 	//				"cobj"
@@ -101,6 +107,19 @@ type GenData struct {
 	SetupFile func(g *GenData, fd FileDefn, work *util.WorkQueue) error
 	// SetupTmplData
 	SetupTmplData func(g *GenData) error
+
+	// The state of the generation in progress.
+	manifest *Manifest
+	changes  fileChangeList
+	uses     modelUseList
+}
+
+// Context returns the definitions of this generation.
+func (g *GenData) Context() *sharedData.Context {
+	if g.Ctx == nil {
+		return sharedData.Default
+	}
+	return g.Ctx
 }
 
 //----------------------------------------------------------------------------
@@ -108,20 +127,27 @@ type GenData struct {
 //----------------------------------------------------------------------------
 
 // CreateModelPath creates an input path from our models and verifies that it
-// exists.  Regular files and directories that are found in the "models"
-// directory are acceptable. genDir is the model subdirectory for this type
-// of generation.
+// exists.  Regular files and directories that are found in the models (see
+// ModelFS()) are acceptable. The path is relative to the models and starts
+// with the model subdirectory for this type of generation, g.Name. It is
+// resolved by searching the project, user and built-in layers in that order
+// (see ModelLayers()).
 func (g *GenData) CreateModelPath(fn string) (*util.Path, error) {
 
 	// Calculate the model path.
-	path := util.NewPath(sharedData.MdlDir())
-	path = path.Append(g.Name)
-	path = path.Append(fn)
-	if !path.IsPathRegularFile() && !path.IsPathDir() {
-		return nil, fmt.Errorf("Error: %s is not a directory or a file!\n", path.String())
+	name := path.Join(g.Name, filepath.ToSlash(fn))
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("Error: %s is not within the models!\n", name)
+	}
+	l, err := g.ModelLayerOf(name)
+	if err != nil {
+		return nil, fmt.Errorf("Error: %s is not a directory or a file!\n", name)
+	}
+	if g.Context().Debug() {
+		log.Printf("\t\tmodel %s is from the %s layer %s\n", name, l.Name, l.Dir)
 	}
 
-	return path, nil
+	return util.NewPath(name), nil
 }
 
 //----------------------------------------------------------------------------
@@ -138,7 +164,7 @@ func (g *GenData) CreateOutputPath(mapper func(string) string, dir []string, fn
 	var path *util.Path
 
 	// Create output path with possible embedded substitutions.
-	outPath = sharedData.OutDir()
+	outPath = g.Context().OutDir()
 	outPath += string(os.PathSeparator)
 	for _, d := range dir {
 		outPath += d
@@ -158,7 +184,7 @@ func (g *GenData) CreateOutputPath(mapper func(string) string, dir []string, fn
 	// Create the directory portion of the path if it doesn't exist.
 	oDirPath := util.NewPath(path.Dir())
 	if !oDirPath.IsPathDir() {
-		if sharedData.Noop() {
+		if g.Context().Noop() {
 			log.Printf("genCmn::CreateOutputPath would have created %s\n", oDirPath.String())
 		} else {
 			oDirPath.CreateDir()
@@ -167,7 +193,7 @@ func (g *GenData) CreateOutputPath(mapper func(string) string, dir []string, fn
 
 	// Check if we would over-write the file.
 	if path.IsPathRegularFile() {
-		if !sharedData.Replace() {
+		if !g.Context().Replace() {
 			err = fmt.Errorf("Over-write error of %s!\n", outPath)
 		}
 	}
@@ -183,11 +209,13 @@ func (g *GenData) CreateOutputPath(mapper func(string) string, dir []string, fn
 // application arguments.
 func (g *GenData) ReadJsonFileMain() error {
 	var err error
+	var m *mainData.MainData
 
-	if err = mainData.ReadJsonFileMain(sharedData.MainPath()); err != nil {
-		return errors.New(fmt.Sprintln("Error: Reading Main Json Input:", sharedData.MainPath(), err))
+	ctx := g.Context()
+	if g.TmplData.Main, m, err = mainData.ReadMain(ctx.MainPath(), ctx); err != nil {
+		return errors.New(fmt.Sprintln("Error: Reading Main Json Input:", ctx.MainPath(), err))
 	}
-	g.TmplData.Main = mainData.MainJson()
+	m.SetFuncs(ctx)
 
 	return nil
 }
@@ -197,53 +225,71 @@ func (g *GenData) ReadJsonFileMain() error {
 //----------------------------------------------------------------------------
 
 // GenFiles generates all the files in a specific phase (ie File Definition Table).
+// All of the files are attempted and any errors are returned together.
 func (g *GenData) GenFiles(fd *[]FileDefn) error {
+	var errs taskErrors
+
+	g.genFiles(fd, &errs)
+
+	return errs.Err()
+}
+
+// genFiles generates all the files in a specific phase adding any errors
+// to errs.
+func (g *GenData) genFiles(fd *[]FileDefn, errs *taskErrors) {
 	var err error
 	var pathIn *util.Path
 	var work *util.WorkQueue
 
+	if g.SetupFile == nil {
+		errs.add(fmt.Errorf("Error: Missing GenData::SetupFile!\n"))
+		return
+	}
+
 	// Setup the worker queue.
 	work = util.NewWorkQueue(
 		func(a interface{}, cmn interface{}) {
 			var data *TaskData
 			var ok bool
 
+			errs := cmn.(*taskErrors)
 			data, ok = a.(*TaskData)
-			if ok {
-				data.genFile()
-			} else {
-				panic(fmt.Sprintf("FATAL: Invalid TaskData Type of %T!", a))
+			if !ok {
+				errs.add(fmt.Errorf("Error: Invalid TaskData Type of %T!\n", a))
+				return
+			}
+			data.gen = g
+			if err := data.genFile(); err != nil {
+				errs.add(err)
 			}
 		},
-		nil,
+		errs,
 		0)
 
 	// Generate all the files for this phase.
+	ctx := g.Context()
 	for _, def := range *fd {
 
-		if !sharedData.Quiet() {
+		if !ctx.Quiet() {
 			log.Println("Setting up file:", def.ModelName, "generating:", def.FileName, "...")
 		}
 
 		// Create the input model file path.
 		if pathIn, err = g.CreateModelPath(def.ModelName); err != nil {
-			return fmt.Errorf("Error: %s: %s\n", pathIn.String(), err.Error())
+			errs.add(err)
+			continue
 		}
-		if sharedData.Debug() {
+		if ctx.Debug() {
 			log.Println("\t\tmodelPath=", pathIn)
 		}
 
 		// Now setup to generate the file pushing the setup info
 		// onto the work queue.
-		if g.SetupFile != nil {
-			g.SetupFile(g, def, work)
-		} else {
-			panic("FATAL: Missing GenData::SetupFile!\n")
+		if err = g.SetupFile(g, def, work); err != nil {
+			errs.add(err)
 		}
 	}
 	work.CloseAndWaitForCompletion()
-
-	return err
 }
 
 //----------------------------------------------------------------------------
@@ -253,31 +299,32 @@ func (g *GenData) GenFiles(fd *[]FileDefn) error {
 func (g *GenData) GenOutput() error {
 	var err error
 
-	if sharedData.Debug() {
+	ctx := g.Context()
+	if ctx.Debug() {
 		log.Println("\t genOutput: In Debug Mode")
 		log.Printf("\t    args: %q\n", flag.Args())
-		log.Printf("\t  mdldir: %s\n", sharedData.MdlDir())
+		log.Printf("\t  mdldir: %s\n", ctx.MdlDir())
+		log.Printf("\t  usrdir: %s\n", ctx.UserMdlDir())
 	}
 
 	// Read the JSON files.
 	if err = g.ReadJsonFileMain(); err != nil {
-		log.Fatalln(err)
+		return err
 	}
 	if g.ReadJsonData != nil {
 		if err = g.ReadJsonData(g); err != nil {
-			log.Fatalln(err)
+			return err
 		}
 	}
 
 	// Set up template data
 	if g.SetupTmplData != nil {
-		err = g.SetupTmplData(g)
-		if err != nil {
-			log.Fatalln(err)
+		if err = g.SetupTmplData(g); err != nil {
+			return err
 		}
 	}
 
-	if sharedData.OutDir() == "" {
+	if ctx.OutDir() == "" {
 		return fmt.Errorf("Error - 'libPath' cli argument is required!\n\n\n")
 	}
 
@@ -288,9 +335,43 @@ func (g *GenData) GenOutput() error {
 		}
 	}
 
-	g.GenFiles(g.FileDefs1)
+	// Generate the files skipping the ones that the manifest of the
+	// prior run shows are unchanged.
+	if g.manifest, err = g.ReadManifest(ctx.OutDir()); err != nil {
+		return err
+	}
+	defer func() { g.manifest = nil }()
+	var errs taskErrors
+	g.genFiles(g.FileDefs1, &errs)
 	if g.FileDefs2 != nil {
-		g.GenFiles(g.FileDefs2)
+		g.genFiles(g.FileDefs2, &errs)
+	}
+	if err = errs.Err(); err != nil {
+		// Files which failed keep their prior output so keep their
+		// prior manifest entries and do not prune anything.
+		g.manifest.KeepPrior()
+		if !ctx.Noop() {
+			g.manifest.Write()
+		}
+		return err
+	}
+	if err = g.manifest.Prune(); err != nil {
+		return err
+	}
+	if !ctx.Noop() {
+		if err = g.manifest.Write(); err != nil {
+			return err
+		}
+	} else {
+		g.WriteFileChanges(os.Stdout)
+		if fn, ok := ctx.Defn("DiffJson").(string); ok && len(fn) > 0 {
+			if err = g.WriteFileChangesJson(fn); err != nil {
+				return err
+			}
+		}
+	}
+	if !ctx.Quiet() {
+		g.LogModelReport()
 	}
 
 	return nil
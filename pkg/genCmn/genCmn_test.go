@@ -8,6 +8,10 @@ package genCmn
 import (
 	// "genapp/pkg/genSqlAppGo/dbJson"
 	"genapp/pkg/sharedData"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"testing"
 	"time"
@@ -52,7 +56,7 @@ func TestCreateModelPath(t *testing.T) {
 		t.Errorf("CreateModelPath() failed: %s\n", err)
 	}
 	t.Logf("\t../sqlapp/io.table.go.tmpl.txt -> '%s'\n", name)
-	if name.String() != "../../models/sqlapp/io.table.go.tmpl.txt" {
+	if name.String() != "sqlapp/io.table.go.tmpl.txt" {
 		t.Errorf("CreateModelPath() file names don't match - %s!\n", name)
 	}
 
@@ -60,7 +64,7 @@ func TestCreateModelPath(t *testing.T) {
 		t.Errorf("CreateModelPath() failed: %s\n", err)
 	}
 	t.Logf("\tio.table.go.tmpl.txt -> '%s'\n", name2)
-	if name2.String() != "../../models/sqlapp/io.table.go.tmpl.txt" {
+	if name2.String() != "sqlapp/io.table.go.tmpl.txt" {
 		t.Errorf("CreateModelPath() file names don't match - %s!\n", name)
 	}
 
@@ -68,7 +72,7 @@ func TestCreateModelPath(t *testing.T) {
 		t.Errorf("CreateModelPath() failed: %s\n", err)
 	}
 	t.Logf("\t../../Models/dbs -> '%s'\n", name)
-	if name.String() != "../../models/sqlapp/dbs" {
+	if name.String() != "sqlapp/dbs" {
 		t.Errorf("CreateModelPath() file names don't match - %s!\n", name)
 	}
 
@@ -80,9 +84,210 @@ func TestCreateModelPath(t *testing.T) {
 		t.Errorf("CreateModelPath() file names don't match - %s!\n", name)
 	}
 
+	if name, err = gd.CreateModelPath("../../go.mod"); err == nil {
+		t.Errorf("CreateModelPath() should not allow paths outside of the models: %s\n", name)
+	}
+
 	t.Log("...End of genCmn::TestCreateModelPath")
 }
 
+func TestModelFS(t *testing.T) {
+	var err error
+	var data []byte
+
+	t.Log("genCmn::TestModelFS()")
+	setupShared(t)
+
+	// Without a model directory, the embedded models are used.
+	sharedData.SetMdlDir("")
+	if data, err = fs.ReadFile(ModelFS(), "sqlapp/css.txt"); err != nil || len(data) == 0 {
+		t.Errorf("ModelFS() embedded models could not be read: %v\n", err)
+	}
+
+	// A model directory overrides only the files that it has.
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "sqlapp", "dbs"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "sqlapp", "css.txt"), []byte("override"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "sqlapp", "dbs", "extra.txt"), []byte("extra"), 0644)
+	sharedData.SetMdlDir(dir)
+
+	mdls := ModelFS()
+	if data, err = fs.ReadFile(mdls, "sqlapp/css.txt"); err != nil || string(data) != "override" {
+		t.Errorf("ModelFS() model was not overridden: %q %v\n", data, err)
+	}
+	if _, err = fs.Stat(mdls, "sqlapp/form.html.tmpl.txt"); err != nil {
+		t.Errorf("ModelFS() embedded model is missing: %s\n", err)
+	}
+	ents, err := fs.ReadDir(mdls, "sqlapp/dbs")
+	if err != nil {
+		t.Fatalf("ModelFS() ReadDir failed: %s\n", err)
+	}
+	names := map[string]bool{}
+	for _, e := range ents {
+		names[e.Name()] = true
+	}
+	if !names["extra.txt"] || !names["mariadb"] || !names["notes.txt"] {
+		t.Errorf("ModelFS() directories were not merged: %v\n", names)
+	}
+
+	sharedData.SetMdlDir("../../models/")
+	t.Log("...End of genCmn::TestModelFS")
+}
+
+func TestModelLayers(t *testing.T) {
+	var err error
+	var data []byte
+	var l ModelLayer
+
+	t.Log("genCmn::TestModelLayers()")
+	setupShared(t)
+
+	// The project layer overrides the user layer which overrides the
+	// built-in one.
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	prj := filepath.Join(dir, "project")
+	usr := filepath.Join(dir, "user")
+	os.MkdirAll(filepath.Join(prj, "sqlapp"), 0755)
+	os.MkdirAll(filepath.Join(usr, "sqlapp"), 0755)
+	ioutil.WriteFile(filepath.Join(prj, "sqlapp", "css.txt"), []byte("project"), 0644)
+	ioutil.WriteFile(filepath.Join(usr, "sqlapp", "css.txt"), []byte("user"), 0644)
+	ioutil.WriteFile(filepath.Join(usr, "sqlapp", "form.html.tmpl.txt"), []byte("user"), 0644)
+	sharedData.SetMdlDir(prj)
+	sharedData.SetUserMdlDir(usr)
+	defer sharedData.SetUserMdlDir("")
+
+	layers := ModelLayers()
+	if len(layers) != 3 || layers[0].Name != LayerProject || layers[1].Name != LayerUser ||
+		layers[2].Name != LayerBuiltIn {
+		t.Fatalf("ModelLayers() invalid layers: %+v\n", layers)
+	}
+	tests := []struct {
+		name  string
+		layer string
+	}{
+		{"sqlapp/css.txt", LayerProject},
+		{"sqlapp/form.html.tmpl.txt", LayerUser},
+		{"sqlapp/main.go.tmpl.txt", LayerBuiltIn},
+	}
+	for _, tst := range tests {
+		if l, err = ModelLayerOf(tst.name); err != nil || l.Name != tst.layer {
+			t.Errorf("ModelLayerOf(%s) should be %s but is %s %v\n", tst.name, tst.layer, l.Name, err)
+		}
+	}
+	if _, err = ModelLayerOf("sqlapp/xyzzy.txt"); err == nil {
+		t.Errorf("ModelLayerOf() should not find sqlapp/xyzzy.txt\n")
+	}
+	if data, err = fs.ReadFile(ModelFS(), "sqlapp/css.txt"); err != nil || string(data) != "project" {
+		t.Errorf("ModelFS() should read the project model: %q %v\n", data, err)
+	}
+	if data, err = fs.ReadFile(ModelFS(), "sqlapp/form.html.tmpl.txt"); err != nil || string(data) != "user" {
+		t.Errorf("ModelFS() should read the user model: %q %v\n", data, err)
+	}
+
+	// The report gives the layer of each generated file.
+	g := NewGenData()
+	g.addModelUse("sqlapp/form.html.tmpl.txt", "/tmp/b/form.html")
+	g.addModelUse("sqlapp/css.txt", "/tmp/a/css.txt")
+	found := 0
+	for _, u := range g.ModelReport() {
+		switch u.Output {
+		case "/tmp/a/css.txt":
+			found++
+			if u.Layer != LayerProject {
+				t.Errorf("ModelReport() %s should be from the project layer: %+v\n", u.Output, u)
+			}
+		case "/tmp/b/form.html":
+			found++
+			if u.Layer != LayerUser || found != 2 {
+				t.Errorf("ModelReport() %s should be from the user layer: %+v\n", u.Output, u)
+			}
+		}
+	}
+	if found != 2 {
+		t.Errorf("ModelReport() is missing files: %+v\n", g.ModelReport())
+	}
+
+	sharedData.SetMdlDir("../../models/")
+	t.Log("...End of genCmn::TestModelLayers")
+}
+
+func TestExportModel(t *testing.T) {
+	var err error
+	var out string
+	var data []byte
+
+	t.Log("genCmn::TestExportModel()")
+	setupShared(t)
+
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	sharedData.SetMdlDir(dir)
+
+	tests := []struct {
+		name string
+		mdl  string
+		ok   bool
+	}{
+		{"sqlapp/form.html.tmpl.txt", "sqlapp/form.html.tmpl.txt", true},
+		{"handlers.table.go.tmpl", "sqlapp/handlers.table.go.tmpl.txt", true},
+		{"xyzzy.txt", "", false},
+		{"../go.mod", "", false},
+	}
+	for _, tst := range tests {
+		mdl, err := FindBuiltInModel(tst.name)
+		if tst.ok != (err == nil) || mdl != tst.mdl {
+			t.Errorf("FindBuiltInModel(%s) should be %q but is %q %v\n", tst.name, tst.mdl, mdl, err)
+		}
+	}
+
+	if out, err = ExportModel("form.html.tmpl.txt", false); err != nil {
+		t.Fatalf("ExportModel() failed: %s\n", err)
+	}
+	if out != filepath.Join(dir, "sqlapp", "form.html.tmpl.txt") {
+		t.Errorf("ExportModel() wrote the wrong file: %s\n", out)
+	}
+	want, _ := fs.ReadFile(ModelFS(), "sqlapp/form.html.tmpl.txt")
+	if data, err = ioutil.ReadFile(out); err != nil || string(data) != string(want) {
+		t.Errorf("ExportModel() did not copy the built-in model: %v\n", err)
+	}
+	if l, _ := ModelLayerOf("sqlapp/form.html.tmpl.txt"); l.Name != LayerProject {
+		t.Errorf("ExportModel() model should now be from the project layer: %+v\n", l)
+	}
+	ioutil.WriteFile(out, []byte("custom"), 0644)
+	if _, err = ExportModel("form.html.tmpl.txt", false); err == nil {
+		t.Errorf("ExportModel() should not replace a customized model without force\n")
+	}
+	if _, err = ExportModel("form.html.tmpl.txt", true); err != nil {
+		t.Errorf("ExportModel() with force failed: %s\n", err)
+	}
+
+	list, err := ListModels()
+	if err != nil {
+		t.Fatalf("ListModels() failed: %s\n", err)
+	}
+	layers := map[string]string{}
+	for _, m := range list {
+		layers[m.Model] = m.Layer
+	}
+	if layers["sqlapp/form.html.tmpl.txt"] != LayerProject || layers["sqlapp/css.txt"] != LayerBuiltIn {
+		t.Errorf("ListModels() invalid layers: %v\n", layers)
+	}
+
+	sharedData.SetMdlDir("../../models/")
+	t.Log("...End of genCmn::TestExportModel")
+}
+
 func TestCreateOutputPath(t *testing.T) {
 	var name *util.Path
 	var err error
@@ -7,9 +7,7 @@ package genCmn
 
 import (
 	"fmt"
-	"genapp/pkg/sharedData"
 	"html/template"
-	"io/ioutil"
 	"log"
 	"strings"
 
@@ -18,20 +16,30 @@ import (
 
 var htmlTmpls template.Template
 
+// GenHtmlFile generates the output file from the model using the default
+// Context. Any changes recorded by -noop are not kept.
 func GenHtmlFile(mdl *util.Path, outPath *util.Path, data interface{}) error {
+	return NewGenData().GenHtmlFile(mdl, outPath, data)
+}
+
+// GenHtmlFile generates the output file from the model using the
+// definitions and template functions of this generation.
+func (g *GenData) GenHtmlFile(mdl *util.Path, outPath *util.Path, data interface{}) error {
 	var err error
 	var tmpl *template.Template
 
+	ctx := g.Context()
+
 	log.Printf("\tGenHtmlFile mdl:%s fn:%s ...", mdl.String(), outPath.String())
 
 	outData := strings.Builder{}
-	if sharedData.Debug() {
+	if ctx.Debug() {
 		log.Println("\t\texecuting template...")
 		log.Println("\t\tdata:", nil)
 	}
 
 	name := mdl.Base()
-	tmpl, err = template.New(name).Delims("[[", "]]").Funcs(sharedData.Funcs()).ParseFiles(mdl.String())
+	tmpl, err = template.New(name).Delims("[[", "]]").Funcs(ctx.Funcs()).ParseFS(g.ModelFS(), mdl.String())
 	if err != nil {
 		return err
 	}
@@ -40,22 +48,35 @@ func GenHtmlFile(mdl *util.Path, outPath *util.Path, data interface{}) error {
 		return err
 	}
 
-	if !sharedData.Noop() {
+	// Format the output such as gofmt for Go source.
+	text, err := formatOutput(mdl.String(), outPath.String(), outData.String())
+	if err != nil {
+		return err
+	}
+
+	// Keep the user blocks of the existing file.
+	text, err = mergeOutputUserBlocks(outPath, text, ctx.Force())
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Noop() {
 		// Delete existing file.
 		if outPath.IsPathRegularFile() {
-			if !sharedData.Replace() {
+			if !ctx.Replace() {
 				return fmt.Errorf("Error - overwrite error of %s\n", outPath)
 			}
 		}
 		// Write the file to disk replacing an existing file.
-		err := ioutil.WriteFile(outPath.String(), []byte(outData.String()), 0664)
+		err := writeFile(outPath.String(), []byte(text), 0664)
 		if err != nil {
 			return fmt.Errorf("Error: I/O error for %s: %s\n", outPath.String(), err.Error())
 		}
 	} else {
-		log.Println("<<<<<<<<<<<<<<<<<<<<<<<<", outPath.String(), ">>>>>>>>>>>>>>>>>>>>>>>>>")
-		log.Println(outData.String())
-		log.Println("<<<<<<<<<<<<<<<<<<<<<<<<", outPath.String(), ">>>>>>>>>>>>>>>>>>>>>>>>>>")
+		// Record how the file would change.
+		if err = g.diffOutput(outPath.String(), []byte(text)); err != nil {
+			return err
+		}
 	}
 
 	return err
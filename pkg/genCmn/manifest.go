@@ -0,0 +1,396 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Generation Manifest
+
+// Notes:
+//	1.	The manifest, .genapp/manifest.json in the output directory,
+//		records each file generated with the model that it came from, a
+//		hash of the inputs and a hash of the output.
+//	2.	The inputs of a file are its model, its output path, the data
+//		and main json files, the definitions and genapp itself. If they
+//		are unchanged since the last run, the file is not regenerated.
+//	3.	If a file's output hash no longer matches, it was edited by hand
+//		and is not replaced unless -force is given. It is reported as an
+//		error so that the run fails rather than silently leaving the
+//		file out of date. The contents of
+//		user blocks (see userBlocks.go) are not part of the output hash
+//		since they are kept on regeneration.
+//	4.	Files in the prior manifest which were not generated by this run
+//		no longer have a definition and are deleted unless they were
+//		edited by hand.
+
+package genCmn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"genapp/pkg/sharedData"
+)
+
+// ManifestPath is the path of the manifest within the output directory.
+const ManifestPath = ".genapp/manifest.json"
+
+// ManifestFile is the manifest entry of one generated file or directory.
+type ManifestFile struct {
+	Output     string `json:"output"` // Relative to the output directory
+	Model      string `json:"model"`
+	InputHash  string `json:"inputHash"`
+	OutputHash string `json:"outputHash"`
+}
+
+// Manifest holds the files generated by the prior run and by this one.
+type Manifest struct {
+	Files  []ManifestFile `json:"files"`
+	dir    string
+	inputs string
+	prior  map[string]ManifestFile
+	files  map[string]ManifestFile
+	gen    *GenData
+	mu     sync.Mutex
+}
+
+//----------------------------------------------------------------------------
+//								ReadManifest
+//----------------------------------------------------------------------------
+
+// ReadManifest reads the manifest of the prior run from the output
+// directory (dir) if there is one and sets up the hash of the inputs
+// common to all of the files of this generation.
+func (g *GenData) ReadManifest(dir string) (*Manifest, error) {
+	var err error
+	var data []byte
+
+	m := &Manifest{dir: dir, prior: map[string]ManifestFile{}, files: map[string]ManifestFile{}, gen: g}
+	data, err = ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(ManifestPath)))
+	if err == nil {
+		var prior Manifest
+		if err = json.Unmarshal(data, &prior); err != nil {
+			return nil, fmt.Errorf("Error: Reading manifest of %s - %s\n", dir, err.Error())
+		}
+		for _, f := range prior.Files {
+			m.prior[f.Output] = f
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Error: Reading manifest of %s - %s\n", dir, err.Error())
+	}
+
+	if m.inputs, err = commonInputsHash(g.Context()); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// commonInputsHash returns the hash of the inputs that all files are
+// generated from.
+func commonInputsHash(ctx *sharedData.Context) (string, error) {
+	var keys []string
+
+	h := sha256.New()
+	for _, fn := range []string{ctx.DataPath(), ctx.MainPath()} {
+		if err := hashFile(h, fn); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("Error: Reading %s - %s\n", fn, err.Error())
+		}
+	}
+	if exe, err := os.Executable(); err == nil {
+		hashFile(h, exe)
+	}
+
+	// Time changes every run and the others do not change the output.
+	defns := map[string]interface{}{}
+	ctx.MergeTo(defns, true)
+	for _, k := range []string{"Debug", "DiffJson", "Force", "Noop", "Quiet", "Time"} {
+		delete(defns, k)
+	}
+	for k := range defns {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%v\n", k, defns[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile adds the contents of the named file to the hash.
+func hashFile(h io.Writer, fn string) error {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(h, f)
+
+	return err
+}
+
+//----------------------------------------------------------------------------
+//								Hashes
+//----------------------------------------------------------------------------
+
+// InputHash returns the hash of the inputs of the output (out) generated
+// from the model.
+func (m *Manifest) InputHash(model, out string) (string, error) {
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", m.inputs, model, m.rel(out))
+	mdls := m.gen.ModelFS()
+	err := fs.WalkDir(mdls, model,
+		func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			data, err := fs.ReadFile(mdls, name)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "%s %d\n", name, len(data))
+			h.Write(data)
+			return nil
+		})
+	if err != nil {
+		return "", fmt.Errorf("Error: Reading model %s - %s\n", model, err.Error())
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// OutputHash returns the hash of the output file or directory (out)
+// without the contents of its user blocks.
+func OutputHash(out string) (string, error) {
+
+	h := sha256.New()
+	err := filepath.Walk(out,
+		func(name string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fi.IsDir() {
+				return nil
+			}
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			text := stripUserBlocks(string(data))
+			rel, _ := filepath.Rel(out, name)
+			fmt.Fprintf(h, "%s %d\n", filepath.ToSlash(rel), len(text))
+			io.WriteString(h, text)
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stripUserBlocks returns the text without the contents of its user
+// blocks. If the blocks are not valid, the text is returned as is.
+func stripUserBlocks(text string) string {
+
+	lines := strings.SplitAfter(text, "\n")
+	blocks, err := findUserBlocks(lines)
+	if err != nil || len(blocks) == 0 {
+		return text
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		lines = append(lines[:blocks[i].begin+1], lines[blocks[i].end:]...)
+	}
+
+	return strings.Join(lines, "")
+}
+
+//----------------------------------------------------------------------------
+//								Check and Add
+//----------------------------------------------------------------------------
+
+// rel returns the output path relative to the output directory.
+func (m *Manifest) rel(out string) string {
+
+	if rel, err := filepath.Rel(m.dir, out); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(out)
+}
+
+// Check returns the hash of the inputs of the output (out) and whether it
+// should be skipped since it is unchanged or was edited by hand. A file
+// edited by hand is also given as an error. -force regenerates every file.
+func (m *Manifest) Check(model, out string) (string, bool, error) {
+
+	inHash, err := m.InputHash(model, out)
+	if err != nil {
+		return "", false, err
+	}
+	m.mu.Lock()
+	prior, ok := m.prior[m.rel(out)]
+	m.mu.Unlock()
+	if !ok || m.gen.Context().Force() {
+		return inHash, false, nil
+	}
+	outHash, err := OutputHash(out)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return inHash, false, nil
+		}
+		return "", false, err
+	}
+
+	if outHash != prior.OutputHash {
+		m.keep(prior)
+		m.gen.addFileChange(out, ChangeKept, "")
+		return inHash, true, fmt.Errorf("Error: It was edited since it was generated, use -force to replace it!\n")
+	}
+	if inHash == prior.InputHash {
+		if !m.gen.Context().Quiet() {
+			log.Printf("\t%s is unchanged\n", out)
+		}
+		m.keep(prior)
+		m.gen.addFileChange(out, ChangeUnchanged, "")
+		return inHash, true, nil
+	}
+
+	return inHash, false, nil
+}
+
+// keep records the prior entry of a file which was not regenerated.
+func (m *Manifest) keep(f ManifestFile) {
+
+	m.mu.Lock()
+	m.files[f.Output] = f
+	m.mu.Unlock()
+}
+
+// Add records the output (out) which was generated from the model with
+// the given hash of its inputs. With -noop, the output was not written so
+// it is only noted as still being generated.
+func (m *Manifest) Add(model, out, inHash string) error {
+
+	if m.gen.Context().Noop() {
+		m.keep(ManifestFile{Output: m.rel(out), Model: model, InputHash: inHash})
+		return nil
+	}
+	outHash, err := OutputHash(out)
+	if err != nil {
+		return fmt.Errorf("Error: Reading %s - %s\n", out, err.Error())
+	}
+	m.keep(ManifestFile{Output: m.rel(out), Model: model, InputHash: inHash, OutputHash: outHash})
+
+	return nil
+}
+
+// KeepPrior keeps the prior entries of the files which were not generated
+// by this run such as ones that failed.
+func (m *Manifest) KeepPrior() {
+
+	m.mu.Lock()
+	for n, f := range m.prior {
+		if _, ok := m.files[n]; !ok {
+			m.files[n] = f
+		}
+	}
+	m.mu.Unlock()
+}
+
+//----------------------------------------------------------------------------
+//								Prune and Write
+//----------------------------------------------------------------------------
+
+// Prune deletes the files of the prior run which were not generated by this
+// one unless they were edited by hand.
+func (m *Manifest) Prune() error {
+	var names []string
+
+	for n := range m.prior {
+		if _, ok := m.files[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	ctx := m.gen.Context()
+	for _, n := range names {
+		prior := m.prior[n]
+		out := filepath.Join(m.dir, filepath.FromSlash(n))
+		outHash, err := OutputHash(out)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("Error: Reading %s - %s\n", out, err.Error())
+		}
+		if outHash != prior.OutputHash && !ctx.Force() {
+			log.Printf("Warning: %s is no longer generated, but was edited so it was kept!\n", out)
+			m.gen.addFileChange(out, ChangeKept, "")
+			continue
+		}
+		if ctx.Noop() {
+			log.Printf("\tShould have deleted %s\n", out)
+			m.gen.addFileChange(out, ChangeDeleted, "")
+			continue
+		}
+		if !ctx.Quiet() {
+			log.Printf("\tDeleting %s since it is no longer generated\n", out)
+		}
+		if err = os.RemoveAll(out); err != nil {
+			return fmt.Errorf("Error: Deleting %s - %s\n", out, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// Write writes the manifest of the files generated into the output
+// directory.
+func (m *Manifest) Write() error {
+
+	m.Files = nil
+	for _, f := range m.files {
+		m.Files = append(m.Files, f)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Output < m.Files[j].Output })
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return fmt.Errorf("Error: Encoding manifest - %s\n", err.Error())
+	}
+
+	fn := filepath.Join(m.dir, filepath.FromSlash(ManifestPath))
+	if err = os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+		return fmt.Errorf("Error: Creating %s - %s\n", filepath.Dir(fn), err.Error())
+	}
+	if err = ioutil.WriteFile(fn, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("Error: Writing %s - %s\n", fn, err.Error())
+	}
+
+	return nil
+}
+
+// outputOf returns the path generated by the task which for a copied
+// directory is the directory within the output path.
+func (t *TaskData) outputOf() string {
+
+	if t.FD.FileType == "copyDir" {
+		return filepath.Join(t.PathOut.String(), path.Base(t.PathIn.String()))
+	}
+	return t.PathOut.String()
+}
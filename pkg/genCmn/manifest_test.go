@@ -0,0 +1,105 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test Generation Manifest
+
+package genCmn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"genapp/pkg/sharedData"
+)
+
+func TestManifest(t *testing.T) {
+	var err error
+	var m *Manifest
+	var skip bool
+	var inHash string
+
+	t.Log("genCmn::TestManifest()")
+	setupShared(t)
+	sharedData.SetNoop(false)
+	defer sharedData.SetNoop(true)
+
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	g := NewGenData()
+	mdl := "sqlapp/css.txt"
+	outs := map[string]string{
+		"a.txt": "a\n// <<user:one>>\n// <</user>>\n",
+		"b.txt": "b\n",
+		"c.txt": "c\n",
+	}
+
+	// Without a prior manifest, everything is generated.
+	if m, err = g.ReadManifest(dir); err != nil {
+		t.Fatalf("ReadManifest() failed: %s\n", err)
+	}
+	for fn, text := range outs {
+		out := filepath.Join(dir, fn)
+		if inHash, skip, err = m.Check(mdl, out); err != nil || skip {
+			t.Errorf("Check(%s) should not skip: %v %v\n", fn, skip, err)
+		}
+		ioutil.WriteFile(out, []byte(text), 0644)
+		if err = m.Add(mdl, out, inHash); err != nil {
+			t.Errorf("Add(%s) failed: %s\n", fn, err)
+		}
+	}
+	if err = m.Write(); err != nil {
+		t.Fatalf("Write() failed: %s\n", err)
+	}
+
+	// Unchanged files and ones only changed within user blocks are skipped.
+	// Ones edited by hand are kept, but are errors so that the run fails.
+	ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n// <<user:one>>\nmine\n// <</user>>\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "b.txt"), []byte("b edited\n"), 0644)
+	if m, err = g.ReadManifest(dir); err != nil {
+		t.Fatalf("ReadManifest() failed: %s\n", err)
+	}
+	if len(m.prior) != 3 || m.prior["b.txt"].Model != mdl {
+		t.Errorf("ReadManifest() invalid prior files: %+v\n", m.prior)
+	}
+	if _, skip, err = m.Check(mdl, filepath.Join(dir, "a.txt")); err != nil || !skip {
+		t.Errorf("Check(a.txt) should skip: %v %v\n", skip, err)
+	}
+	if _, skip, err = m.Check(mdl, filepath.Join(dir, "b.txt")); err == nil || !skip {
+		t.Errorf("Check(b.txt) should skip with an error: %v %v\n", skip, err)
+	}
+	if _, ok := m.files["b.txt"]; !ok {
+		t.Errorf("Check(b.txt) should have kept its prior entry\n")
+	}
+	sharedData.SetForce(true)
+	if _, skip, err = m.Check(mdl, filepath.Join(dir, "b.txt")); err != nil || skip {
+		t.Errorf("Check(b.txt) should not skip with force: %v %v\n", skip, err)
+	}
+	sharedData.SetForce(false)
+	m.inputs = "changed"
+	if _, skip, err = m.Check(mdl, filepath.Join(dir, "a.txt")); err != nil || skip {
+		t.Errorf("Check(a.txt) should not skip if the inputs changed: %v %v\n", skip, err)
+	}
+
+	// c.txt is no longer generated so it is deleted, but an edited file
+	// is kept.
+	delete(m.files, "b.txt")
+	ioutil.WriteFile(filepath.Join(dir, "b.txt"), []byte("b edited\n"), 0644)
+	if err = m.Prune(); err != nil {
+		t.Fatalf("Prune() failed: %s\n", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "c.txt")); !os.IsNotExist(err) {
+		t.Errorf("Prune() should have deleted c.txt: %v\n", err)
+	}
+	for _, fn := range []string{"a.txt", "b.txt"} {
+		if _, err = os.Stat(filepath.Join(dir, fn)); err != nil {
+			t.Errorf("Prune() should have kept %s: %s\n", fn, err)
+		}
+	}
+
+	t.Log("...End of genCmn::TestManifest")
+}
@@ -0,0 +1,352 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Model File System
+
+// Notes:
+//	1.	The models are searched for in layers. The project layer is the
+//		model directory given by -mdldir (or ./models). The user layer
+//		is $GENAPP_MODELS (or ~/.genapp/models). The built-in layer is
+//		the models embedded in genapp. A model is taken from the first
+//		layer which has it.
+//	2.	The project and user layers only need to hold the files that
+//		are to be overridden using the same relative paths as the
+//		"models" directory such as "sqlapp/form.html.tmpl.txt".
+//	3.	Model paths are slash separated and relative to the top of
+//		the models as required by io/fs.
+
+package genCmn
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"genapp/models"
+	"genapp/pkg/sharedData"
+)
+
+//============================================================================
+//								Model Layers
+//============================================================================
+
+const (
+	LayerProject = "project"
+	LayerUser    = "user"
+	LayerBuiltIn = "built-in"
+)
+
+// ModelLayer is one directory of models in the search path.
+type ModelLayer struct {
+	Name string // LayerProject, LayerUser or LayerBuiltIn
+	Dir  string // Directory of the models or "" if built-in
+	FS   fs.FS
+}
+
+// ModelLayers returns the layers of models which exist in the order that
+// they are searched using the directories of the default Context.
+func ModelLayers() []ModelLayer {
+	return modelLayers(sharedData.Default)
+}
+
+// ModelLayers returns the layers of models of this generation which exist
+// in the order that they are searched.
+func (g *GenData) ModelLayers() []ModelLayer {
+	return modelLayers(g.Context())
+}
+
+func modelLayers(ctx *sharedData.Context) []ModelLayer {
+	var layers []ModelLayer
+
+	dirs := []ModelLayer{
+		{Name: LayerProject, Dir: ctx.MdlDir()},
+		{Name: LayerUser, Dir: ctx.UserMdlDir()},
+	}
+	for _, l := range dirs {
+		if len(l.Dir) == 0 {
+			continue
+		}
+		if fi, err := os.Stat(l.Dir); err == nil && fi.IsDir() {
+			l.FS = os.DirFS(l.Dir)
+			layers = append(layers, l)
+		}
+	}
+	layers = append(layers, ModelLayer{Name: LayerBuiltIn, FS: models.FS})
+
+	return layers
+}
+
+// ModelLayerOf returns the layer which provides the named model.
+func ModelLayerOf(name string) (ModelLayer, error) {
+	return modelLayerOf(ModelLayers(), name)
+}
+
+// ModelLayerOf returns the layer of this generation which provides the
+// named model.
+func (g *GenData) ModelLayerOf(name string) (ModelLayer, error) {
+	return modelLayerOf(g.ModelLayers(), name)
+}
+
+func modelLayerOf(layers []ModelLayer, name string) (ModelLayer, error) {
+
+	for _, l := range layers {
+		if _, err := fs.Stat(l.FS, name); err == nil {
+			return l, nil
+		}
+	}
+
+	return ModelLayer{}, fmt.Errorf("Error: %s is not in the models!\n", name)
+}
+
+//============================================================================
+//							Model File System
+//============================================================================
+
+// ModelFS returns the file system holding the models. It is the layers of
+// ModelLayers() overlaid on each other.
+func ModelFS() fs.FS {
+	return modelFS(ModelLayers())
+}
+
+// ModelFS returns the file system holding the models of this generation.
+func (g *GenData) ModelFS() fs.FS {
+	return modelFS(g.ModelLayers())
+}
+
+func modelFS(layers []ModelLayer) fs.FS {
+	var o overlayFS
+
+	if len(layers) == 1 {
+		return layers[0].FS
+	}
+	for _, l := range layers {
+		o = append(o, l.FS)
+	}
+
+	return o
+}
+
+// overlayFS looks for files in each of its file systems in turn.
+type overlayFS []fs.FS
+
+// Open opens the named file from the first file system which has it.
+func (o overlayFS) Open(name string) (fs.File, error) {
+
+	for _, fsys := range o[:len(o)-1] {
+		f, err := fsys.Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return o[len(o)-1].Open(name)
+}
+
+// ReadDir merges the entries of the named directory from all the file
+// systems in name order with those of the earlier file systems replacing
+// the ones of the later.
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	var found bool
+	var names []string
+	var list []fs.DirEntry
+
+	entries := map[string]fs.DirEntry{}
+	for i := len(o) - 1; i >= 0; i-- {
+		ents, err := fs.ReadDir(o[i], name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+		for _, e := range ents {
+			entries[e.Name()] = e
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	for n := range entries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		list = append(list, entries[n])
+	}
+
+	return list, nil
+}
+
+//============================================================================
+//								Model Report
+//============================================================================
+
+// ModelUse records the model and its layer that a file was generated from.
+type ModelUse struct {
+	Output string
+	Model  string
+	Layer  string
+}
+
+// modelUseList is the models used by a generation.
+type modelUseList struct {
+	sync.Mutex
+	list []ModelUse
+}
+
+// addModelUse records the layer of the model that the output was generated
+// from. It is called by the concurrent file generation.
+func (g *GenData) addModelUse(model, output string) {
+
+	use := ModelUse{Output: output, Model: model, Layer: "?"}
+	if l, err := g.ModelLayerOf(model); err == nil {
+		use.Layer = l.Name
+	}
+	g.uses.Lock()
+	g.uses.list = append(g.uses.list, use)
+	g.uses.Unlock()
+}
+
+// ModelReport returns the files generated so far with the model and layer
+// that each came from in output path order. A file generated more than
+// once is only given once.
+func (g *GenData) ModelReport() []ModelUse {
+	var list []ModelUse
+
+	g.uses.Lock()
+	uses := append([]ModelUse{}, g.uses.list...)
+	g.uses.Unlock()
+	sort.Slice(uses, func(i, j int) bool { return uses[i].Output < uses[j].Output })
+	for i, u := range uses {
+		if i == 0 || u != uses[i-1] {
+			list = append(list, u)
+		}
+	}
+
+	return list
+}
+
+// LogModelReport logs which layer each generated file came from.
+func (g *GenData) LogModelReport() {
+
+	log.Printf("Model Layers:\n")
+	for _, l := range g.ModelLayers() {
+		log.Printf("\t%-8s %s\n", l.Name, l.Dir)
+	}
+	log.Printf("Generated Files:\n")
+	for _, u := range g.ModelReport() {
+		log.Printf("\t%-8s %s from %s\n", u.Layer, u.Output, u.Model)
+	}
+}
+
+//============================================================================
+//							Export Built-in Models
+//============================================================================
+
+// FindBuiltInModel returns the path of the named built-in model. The name
+// may be the full path within the models such as "sqlapp/form.html.tmpl.txt"
+// or just the file name if only one generator has it.
+func FindBuiltInModel(name string) (string, error) {
+	var found []string
+
+	name = path.Clean(filepath.ToSlash(name))
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("Error: %s is not within the models!\n", name)
+	}
+	if fi, err := fs.Stat(models.FS, name); err == nil && !fi.IsDir() {
+		return name, nil
+	}
+	err := fs.WalkDir(models.FS, ".",
+		func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && (d.Name() == name || d.Name() == name+".txt") {
+				found = append(found, p)
+			}
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("Error: %s is not a built-in model!\n", name)
+	case 1:
+		return found[0], nil
+	}
+
+	return "", fmt.Errorf("Error: %s is ambiguous, use one of %v!\n", name, found)
+}
+
+// ExportModel copies the named built-in model into the project layer so
+// that it can be customized and returns the path written. An existing file
+// is only replaced if force is given.
+func ExportModel(name string, force bool) (string, error) {
+	var err error
+	var data []byte
+
+	mdl, err := FindBuiltInModel(name)
+	if err != nil {
+		return "", err
+	}
+	dir := sharedData.MdlDir()
+	if len(dir) == 0 {
+		dir = "./models"
+	}
+	out := filepath.Join(dir, filepath.FromSlash(mdl))
+	if _, err = os.Stat(out); err == nil && !force {
+		return "", fmt.Errorf("Error: %s already exists, use -force to replace it!\n", out)
+	}
+
+	if data, err = fs.ReadFile(models.FS, mdl); err != nil {
+		return "", fmt.Errorf("Error: Reading %s - %s\n", mdl, err)
+	}
+	if err = os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return "", fmt.Errorf("Error: Creating %s - %s\n", filepath.Dir(out), err)
+	}
+	if err = ioutil.WriteFile(out, data, 0644); err != nil {
+		return "", fmt.Errorf("Error: Writing %s - %s\n", out, err)
+	}
+
+	return out, nil
+}
+
+// ListModels returns the paths of all the models of the generators with
+// the layer each is taken from.
+func ListModels() ([]ModelUse, error) {
+	var list []ModelUse
+
+	layers := ModelLayers()
+	err := fs.WalkDir(ModelFS(), ".",
+		func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.Contains(p, "/") {
+				return nil
+			}
+			for _, l := range layers {
+				if _, err := fs.Stat(l.FS, p); err == nil {
+					list = append(list, ModelUse{Model: p, Layer: l.Name})
+					break
+				}
+			}
+			return nil
+		})
+
+	return list, err
+}
@@ -15,11 +15,17 @@
 package genCmn
 
 import (
+	"errors"
 	"fmt"
-	"genapp/pkg/sharedData"
-	"io"
+	"io/fs"
+	"io/ioutil"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/2kranki/go_util"
 )
@@ -35,123 +41,250 @@ type TaskData struct {
 	PathOut *util.Path // Output File Path
 	Data    interface{}
 	Table   interface{}
+	gen     *GenData // Generation that the task is part of
 }
 
-func (t *TaskData) genFile() {
+// genFile generates one file returning an error giving the model and the
+// output if it fails. The existing output is left as it was on failure.
+func (t *TaskData) genFile() error {
 	var err error
 
 	if t.PathOut == nil {
-		log.Fatalf("Error - Missing output path for %s!\n", t.PathIn.String())
+		return fmt.Errorf("Error: Missing output path for %s!\n", t.PathIn.String())
 	}
+	if t.gen == nil {
+		t.gen = NewGenData()
+	}
+	g := t.gen
+	ctx := g.Context()
 
-	if !sharedData.Quiet() {
+	// Skip the file if the manifest shows that it is unchanged or
+	// was edited by hand.
+	var inHash string
+	if g.manifest != nil {
+		var skip bool
+		if inHash, skip, err = g.manifest.Check(t.PathIn.String(), t.outputOf()); err != nil {
+			return fmt.Errorf("Error: %s: %s\n", t.PathOut.String(), strings.TrimSpace(err.Error()))
+		}
+		if skip {
+			return nil
+		}
+	}
+
+	if !ctx.Quiet() {
 		log.Println("Processing file:", t.PathIn.String(), "generating:", t.PathOut.String(), "...")
 	}
 
 	// Now generate the file.
 	switch t.FD.FileType {
 	case "copy":
-		if sharedData.Noop() {
-			if !sharedData.Quiet() {
+		if ctx.Noop() {
+			if !ctx.Quiet() {
 				log.Printf("\tShould have copied from %s to %s\n",
 					t.PathIn.String(), t.PathOut.String())
 			}
+			err = t.diffCopy()
 		} else {
-			if amt, err := t.copyFile(t.PathIn, t.PathOut); err == nil {
+			var amt int64
+			if amt, err = t.copyFile(t.PathIn, t.PathOut); err == nil {
 				t.PathOut.Chmod(t.FD.FilePerms)
-				if !sharedData.Quiet() {
+				if !ctx.Quiet() {
 					log.Printf("\tCopied %d bytes from %s to %s\n",
 						amt, t.PathIn.String(), t.PathOut.String())
 				}
-			} else {
-				log.Fatalf("Error - Copied %d bytes from %s to %s with error %s\n",
-					amt, t.PathIn.String(), t.PathOut.String(), err.Error())
 			}
 		}
 	case "copyDir":
-		if !sharedData.Quiet() {
+		if !ctx.Quiet() {
 			log.Println("\t CopyDir...")
 		}
-		if sharedData.Noop() {
-			if !sharedData.Quiet() {
+		if ctx.Noop() {
+			if !ctx.Quiet() {
 				log.Printf("\tShould have copied directory from %s to %s\n",
 					t.PathIn.String(), t.PathOut.String())
 			}
+			err = t.diffCopyDir()
 		} else {
-			if err := t.copyDir(t.PathIn, t.PathOut); err == nil {
-				if !sharedData.Quiet() {
+			if err = t.copyDir(t.PathIn, t.PathOut); err == nil {
+				if !ctx.Quiet() {
 					log.Printf("\tCopied from %s to %s\n",
 						t.PathIn.String(), t.PathOut.String())
 				}
-			} else {
-				log.Fatalf("Error - Copied from %s to %s with error %s\n",
-					t.PathIn.String(), t.PathOut.String(), err.Error())
 			}
 		}
 	case "html":
-		if err = GenHtmlFile(t.PathIn, t.PathOut, t); err == nil {
+		if err = g.GenHtmlFile(t.PathIn, t.PathOut, t); err == nil {
 			t.PathOut.Chmod(t.FD.FilePerms)
-			if !sharedData.Quiet() {
+			if !ctx.Quiet() {
 				log.Printf("\tGenerated HTML from %s to %s\n",
 					t.PathIn.String(), t.PathOut.String())
 			}
-		} else {
-			log.Fatalf("Error - Generated HTML from %s to %s with error %s\n",
-				t.PathIn.String(), t.PathOut.String(), err.Error())
 		}
 	case "text":
-		if err = GenTextFile(t.PathIn, t.PathOut, t); err == nil {
+		if err = g.GenTextFile(t.PathIn, t.PathOut, t); err == nil {
 			t.PathOut.Chmod(t.FD.FilePerms)
-			if !sharedData.Quiet() {
+			if !ctx.Quiet() {
 				log.Printf("\tGenerated text from %s to %s\n",
 					t.PathIn.String(), t.PathOut.String())
 			}
-		} else {
-			log.Fatalf("Error - Generated text from %s to %s with error %s\n",
-				t.PathIn.String(), t.PathOut.String(), err.Error())
 		}
 	default:
-		log.Fatalln("Error: Invalid file type:", t.FD.FileType, "for",
-			t.FD.ModelName, err.Error())
+		err = fmt.Errorf("Error: Invalid file type: %s!\n", t.FD.FileType)
+	}
+	if err != nil {
+		return fmt.Errorf("Error: %s from %s: %s\n", t.PathOut.String(), t.PathIn.String(),
+			strings.TrimSpace(err.Error()))
+	}
+
+	g.addModelUse(t.PathIn.String(), t.outputOf())
+	if g.manifest != nil {
+		if err = g.manifest.Add(t.PathIn.String(), t.outputOf(), inHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//============================================================================
+//								Task Errors
+//============================================================================
+
+// taskErrors collects the errors of the tasks run by the work queue so
+// that they can be reported together.
+type taskErrors struct {
+	sync.Mutex
+	list []string
+}
+
+// add adds an error to the list.
+func (e *taskErrors) add(err error) {
+
+	e.Lock()
+	e.list = append(e.list, strings.TrimSpace(err.Error()))
+	e.Unlock()
+}
+
+// Err returns all of the errors collected as one error or nil if there
+// are none.
+func (e *taskErrors) Err() error {
+	var str strings.Builder
+
+	e.Lock()
+	defer e.Unlock()
+	switch len(e.list) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(e.list[0] + "\n")
+	}
+	sort.Strings(e.list)
+	fmt.Fprintf(&str, "Error: %d files failed:\n", len(e.list))
+	for _, msg := range e.list {
+		fmt.Fprintf(&str, "\t%s\n", strings.Replace(msg, "\n", "\n\t\t", -1))
+	}
+
+	return errors.New(str.String())
+}
+
+//----------------------------------------------------------------------------
+//								writeFile
+//----------------------------------------------------------------------------
+
+// writeFile writes the data to a temporary file in the same directory
+// and then renames it to the file name. So, the file is either replaced
+// entirely or left as it was.
+func writeFile(fn string, data []byte, perm os.FileMode) error {
+
+	tmp, err := ioutil.TempFile(filepath.Dir(fn), "."+filepath.Base(fn)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if err2 := tmp.Close(); err == nil {
+		err = err2
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fn)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
 	}
 
+	return err
 }
 
 //----------------------------------------------------------------------------
 //								copyDir
 //----------------------------------------------------------------------------
 
+// copyDir copies the model directory and all of its files into a directory
+// of the same base name within outPath. Since the embedded models do not
+// keep file permissions, the files are given the permissions of the file
+// definition with shell scripts also being made executable. The directory
+// is copied to a temporary directory which then replaces the old one.
 func (t *TaskData) copyDir(modelPath, outPath *util.Path) error {
 	var err error
 	var base string
 	var pathOut *util.Path
 
-	if !modelPath.IsPathDir() {
+	mdls := t.gen.ModelFS()
+	if fi, err := fs.Stat(mdls, modelPath.String()); err != nil || !fi.IsDir() {
 		return fmt.Errorf("Error - model directory, %s, does not exist!\n", modelPath.String())
 	}
-	base = modelPath.Base()
-	if len(base) == 0 {
+	base = path.Base(modelPath.String())
+	if len(base) == 0 || base == "." {
 		return fmt.Errorf("Error - model directory, %s, does not have base directory!\n", modelPath.String())
 	}
 
 	pathOut = outPath.Append(base)
 	log.Printf("\tcopyDir:  inPath: %s\n", modelPath.String())
 	log.Printf("\tcopyDir: outPath: %s base: %s\n", pathOut.String(), base)
-	if outPath.IsPathDir() {
-		if sharedData.Replace() {
-			log.Printf("\tcopyDir: Removing %s\n", pathOut.String())
-			if err = pathOut.RemoveDir(); err != nil {
-				return fmt.Errorf("Error - could not delete %s: %s\n", pathOut.String(), err.Error())
+	if pathOut.IsPathDir() && !t.gen.Context().Replace() {
+		return fmt.Errorf("Error - overwrite error of %s\n", pathOut.String())
+	}
+
+	tmpDir, err := ioutil.TempDir(outPath.String(), "."+base+".tmp")
+	if err != nil {
+		return fmt.Errorf("Error - could not create a temporary directory in %s: %s\n",
+			outPath.String(), err.Error())
+	}
+	defer os.RemoveAll(tmpDir)
+	err = fs.WalkDir(mdls, modelPath.String(),
+		func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
 			}
-		} else {
-			return fmt.Errorf("Error - overwrite error of %s\n", pathOut.String())
-		}
+			rel := strings.TrimPrefix(strings.TrimPrefix(name, modelPath.String()), "/")
+			out := filepath.Join(tmpDir, filepath.FromSlash(rel))
+			if d.IsDir() {
+				return os.MkdirAll(out, 0755)
+			}
+			data, err := fs.ReadFile(mdls, name)
+			if err != nil {
+				return err
+			}
+			perms := t.FD.FilePerms
+			if strings.HasSuffix(name, ".sh") {
+				perms |= 0111
+			}
+			return ioutil.WriteFile(out, data, perms)
+		})
+	if err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpDir, 0755); err != nil {
+		return err
 	}
-	pathOut = pathOut.Append("") // Add trailing path separator.
 
-	err = util.CopyDir(modelPath, pathOut)
+	log.Printf("\tcopyDir: Replacing %s\n", pathOut.String())
+	if err = pathOut.RemoveDir(); err != nil {
+		return fmt.Errorf("Error - could not delete %s: %s\n", pathOut.String(), err.Error())
+	}
 
-	return err
+	return os.Rename(tmpDir, pathOut.String())
 }
 
 //----------------------------------------------------------------------------
@@ -159,30 +292,21 @@ func (t *TaskData) copyDir(modelPath, outPath *util.Path) error {
 //----------------------------------------------------------------------------
 
 func (t *TaskData) copyFile(modelPath, outPath *util.Path) (int64, error) {
-	var dst *os.File
 	var err error
-	var src *os.File
-
-	if !modelPath.IsPathRegularFile() {
-		return 0, fmt.Errorf("Error - model file does not exist for %s: %s\n", modelPath.String(), err.Error())
-	}
+	var data []byte
 
 	if outPath.IsPathRegularFile() {
-		if !sharedData.Replace() {
+		if !t.gen.Context().Replace() {
 			return 0, fmt.Errorf("Error - overwrite error of %s\n", outPath.String())
 		}
 	}
-	if dst, err = os.Create(outPath.Absolute()); err != nil {
-		return 0, fmt.Errorf("Error - could not create %s: %s\n", outPath.String(), err.Error())
-	}
-	defer dst.Close()
 
-	if src, err = os.Open(modelPath.Absolute()); err != nil {
-		return 0, fmt.Errorf("Error - could not open model file, %s: %s\n", modelPath.String(), err.Error())
+	if data, err = fs.ReadFile(t.gen.ModelFS(), modelPath.String()); err != nil {
+		return 0, fmt.Errorf("Error - could not read model file, %s: %s\n", modelPath.String(), err.Error())
+	}
+	if err = writeFile(outPath.Absolute(), data, 0644); err != nil {
+		return 0, fmt.Errorf("Error - could not write %s: %s\n", outPath.String(), err.Error())
 	}
-	defer src.Close()
-
-	amt, err := io.Copy(dst, src)
 
-	return amt, err
+	return int64(len(data)), nil
 }
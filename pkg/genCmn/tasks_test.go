@@ -0,0 +1,93 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test Generate Tasks
+
+package genCmn
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"genapp/pkg/sharedData"
+
+	"github.com/2kranki/go_util"
+)
+
+func TestGenFilesErrors(t *testing.T) {
+	var err error
+	var data []byte
+
+	t.Log("genCmn::TestGenFilesErrors()")
+	setupShared(t)
+	sharedData.SetNoop(false)
+	defer sharedData.SetNoop(true)
+
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	mdls := filepath.Join(dir, "models", "sqlapp")
+	os.MkdirAll(mdls, 0755)
+	ioutil.WriteFile(filepath.Join(mdls, "good.txt"), []byte("good [[.Data]]\n"), 0644)
+	ioutil.WriteFile(filepath.Join(mdls, "parse.txt"), []byte("line 1\n[[if]]\n"), 0644)
+	ioutil.WriteFile(filepath.Join(mdls, "exec.txt"), []byte("line 1\nline 2\n[[.Nope]]\n"), 0644)
+	ioutil.WriteFile(filepath.Join(mdls, "setup.txt"), []byte("setup\n"), 0644)
+	sharedData.SetMdlDir(filepath.Join(dir, "models"))
+	defer sharedData.SetMdlDir("../../models/")
+
+	// A failed file keeps its prior contents.
+	ioutil.WriteFile(filepath.Join(dir, "exec.out"), []byte("prior\n"), 0644)
+
+	gd := NewGenData()
+	gd.Name = "sqlapp"
+	gd.SetupFile = func(g *GenData, fd FileDefn, work *util.WorkQueue) error {
+		if fd.ModelName == "setup.txt" {
+			return fmt.Errorf("Error: setup failed!\n")
+		}
+		pathIn, err := g.CreateModelPath(fd.ModelName)
+		if err != nil {
+			return err
+		}
+		fn := filepath.Join(dir, strings.TrimSuffix(fd.ModelName, ".txt")+".out")
+		work.PushWork(&TaskData{FD: &fd, PathIn: pathIn, PathOut: util.NewPath(fn), Data: "data"})
+		return nil
+	}
+	defs := []FileDefn{
+		{ModelName: "good.txt", FileType: "text", FilePerms: 0644},
+		{ModelName: "parse.txt", FileType: "text", FilePerms: 0644},
+		{ModelName: "exec.txt", FileType: "text", FilePerms: 0644},
+		{ModelName: "setup.txt", FileType: "text", FilePerms: 0644},
+		{ModelName: "missing.txt", FileType: "text", FilePerms: 0644},
+	}
+	err = gd.GenFiles(&defs)
+	if err == nil {
+		t.Fatalf("GenFiles() should have failed\n")
+	}
+	for _, msg := range []string{"4 files failed", "parse.txt:2", "exec.txt:3", "setup failed",
+		"sqlapp/missing.txt is not"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("GenFiles() error is missing %q:\n%s\n", msg, err)
+		}
+	}
+	if data, err = ioutil.ReadFile(filepath.Join(dir, "good.out")); err != nil || string(data) != "good data\n" {
+		t.Errorf("GenFiles() should have generated good.out: %q %v\n", data, err)
+	}
+	if data, err = ioutil.ReadFile(filepath.Join(dir, "exec.out")); err != nil || string(data) != "prior\n" {
+		t.Errorf("GenFiles() should have kept exec.out: %q %v\n", data, err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "parse.out")); !os.IsNotExist(err) {
+		t.Errorf("GenFiles() should not have written parse.out: %v\n", err)
+	}
+	names, _ := filepath.Glob(filepath.Join(dir, ".*"))
+	if len(names) > 0 {
+		t.Errorf("GenFiles() left temporary files: %v\n", names)
+	}
+
+	t.Log("...End of genCmn::TestGenFilesErrors")
+}
@@ -0,0 +1,159 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// User Blocks
+
+// Notes:
+//	1.	A user block is the lines between a line containing
+//		"<<user:name>>" and the next line containing "<</user>>". The
+//		markers may be in any comment style such as:
+//			// <<user:handlers>>
+//			// <</user>>
+//		or:
+//			<!-- <<user:body>> -->
+//			<!-- <</user>> -->
+//	2.	The models define where the user blocks are. When a file is
+//		regenerated, the lines within each block of the existing file
+//		are put into the same block of the newly generated text. So,
+//		hand-written code within them survives regeneration.
+//	3.	If the existing file has a block that the model no longer has,
+//		generation fails unless -force is given since its contents
+//		would be lost.
+
+package genCmn
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/2kranki/go_util"
+)
+
+var userBlockBegin = regexp.MustCompile(`<<user:([A-Za-z0-9_.-]+)>>`)
+
+const userBlockEnd = "<</user>>"
+
+// userBlock is one user block within a text.
+type userBlock struct {
+	name  string
+	begin int // Line index of the beginning marker
+	end   int // Line index of the ending marker
+}
+
+// findUserBlocks returns the user blocks in the lines of a text.
+func findUserBlocks(lines []string) ([]userBlock, error) {
+	var blocks []userBlock
+	var cur *userBlock
+
+	names := map[string]bool{}
+	for i, line := range lines {
+		if m := userBlockBegin.FindStringSubmatch(line); m != nil {
+			if cur != nil {
+				return nil, fmt.Errorf("Error: line %d: user block %s begins within user block %s!\n",
+					i+1, m[1], cur.name)
+			}
+			if names[m[1]] {
+				return nil, fmt.Errorf("Error: line %d: user block %s is duplicated!\n", i+1, m[1])
+			}
+			names[m[1]] = true
+			cur = &userBlock{name: m[1], begin: i}
+		} else if strings.Contains(line, userBlockEnd) {
+			if cur == nil {
+				return nil, fmt.Errorf("Error: line %d: %s is not within a user block!\n", i+1, userBlockEnd)
+			}
+			cur.end = i
+			blocks = append(blocks, *cur)
+			cur = nil
+		}
+	}
+	if cur != nil {
+		return nil, fmt.Errorf("Error: user block %s is missing its %s!\n", cur.name, userBlockEnd)
+	}
+
+	return blocks, nil
+}
+
+// UserBlocks returns the contents of the user blocks of a text by name.
+func UserBlocks(text string) (map[string]string, error) {
+
+	lines := strings.SplitAfter(text, "\n")
+	blocks, err := findUserBlocks(lines)
+	if err != nil {
+		return nil, err
+	}
+	contents := map[string]string{}
+	for _, b := range blocks {
+		contents[b.name] = strings.Join(lines[b.begin+1:b.end], "")
+	}
+
+	return contents, nil
+}
+
+// MergeUserBlocks returns the generated text with the contents of its user
+// blocks replaced by the ones of the same name in the existing text. It is
+// an error if the existing text has a block not in the generated text
+// unless force is given.
+func MergeUserBlocks(generated, existing string, force bool) (string, error) {
+	var str strings.Builder
+
+	old, err := UserBlocks(existing)
+	if err != nil {
+		return "", err
+	}
+	if len(old) == 0 {
+		return generated, nil
+	}
+	lines := strings.SplitAfter(generated, "\n")
+	blocks, err := findUserBlocks(lines)
+	if err != nil {
+		return "", err
+	}
+
+	next := 0
+	for _, b := range blocks {
+		contents, ok := old[b.name]
+		if !ok {
+			continue
+		}
+		delete(old, b.name)
+		str.WriteString(strings.Join(lines[next:b.begin+1], ""))
+		str.WriteString(contents)
+		next = b.end
+	}
+	str.WriteString(strings.Join(lines[next:], ""))
+
+	if len(old) > 0 && !force {
+		var names []string
+		for n := range old {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("Error: user blocks %v are no longer in the model, use -force to drop them!\n",
+			names)
+	}
+
+	return str.String(), nil
+}
+
+// mergeOutputUserBlocks puts the user blocks of the existing output file,
+// if there is one, into its newly generated text. If force is true, user
+// blocks which are no longer generated are dropped.
+func mergeOutputUserBlocks(outPath *util.Path, text string, force bool) (string, error) {
+
+	if !outPath.IsPathRegularFile() {
+		return text, nil
+	}
+	data, err := ioutil.ReadFile(outPath.String())
+	if err != nil {
+		return "", fmt.Errorf("Error: Reading %s - %s\n", outPath.String(), err.Error())
+	}
+	text, err = MergeUserBlocks(text, string(data), force)
+	if err != nil {
+		return "", fmt.Errorf("Error: %s: %s", outPath.String(), err.Error())
+	}
+
+	return text, nil
+}
@@ -0,0 +1,110 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test User Blocks
+
+package genCmn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"genapp/pkg/sharedData"
+
+	"github.com/2kranki/go_util"
+)
+
+func TestUserBlocks(t *testing.T) {
+
+	t.Log("genCmn::TestUserBlocks()")
+
+	text := "a\n// <<user:one>>\nkeep 1\nkeep 2\n// <</user>>\nb\n<!-- <<user:two>> -->\n<!-- <</user>> -->\n"
+	blocks, err := UserBlocks(text)
+	if err != nil {
+		t.Fatalf("UserBlocks() failed: %s\n", err)
+	}
+	if len(blocks) != 2 || blocks["one"] != "keep 1\nkeep 2\n" || blocks["two"] != "" {
+		t.Errorf("UserBlocks() invalid blocks: %q\n", blocks)
+	}
+
+	bad := []string{
+		"// <<user:one>>\n// <<user:two>>\n// <</user>>\n",
+		"// <<user:one>>\n// <</user>>\n// <<user:one>>\n// <</user>>\n",
+		"// <</user>>\n",
+		"// <<user:one>>\n",
+	}
+	for _, b := range bad {
+		if _, err = UserBlocks(b); err == nil {
+			t.Errorf("UserBlocks() should have failed for %q\n", b)
+		}
+	}
+
+	t.Log("...End of genCmn::TestUserBlocks")
+}
+
+func TestMergeUserBlocks(t *testing.T) {
+	var err error
+	var str string
+
+	t.Log("genCmn::TestMergeUserBlocks()")
+
+	generated := "new 1\n// <<user:one>>\n// <</user>>\nnew 2\n\t// <<user:two>>\n\tdefault\n\t// <</user>>\nnew 3\n"
+	existing := "old 1\n// <<user:one>>\nmine\n// <</user>>\nold 2\n// <<user:two>>\n// <</user>>\n"
+	want := "new 1\n// <<user:one>>\nmine\n// <</user>>\nnew 2\n\t// <<user:two>>\n\t// <</user>>\nnew 3\n"
+	if str, err = MergeUserBlocks(generated, existing, false); err != nil || str != want {
+		t.Errorf("MergeUserBlocks() should be %q but is %q %v\n", want, str, err)
+	}
+
+	// Blocks not in the existing file keep the generated contents.
+	if str, err = MergeUserBlocks(generated, "no blocks\n", false); err != nil || str != generated {
+		t.Errorf("MergeUserBlocks() should not change %q but is %q %v\n", generated, str, err)
+	}
+
+	// A block that is no longer in the model is only dropped if forced.
+	existing += "// <<user:three>>\nlost\n// <</user>>\n"
+	if _, err = MergeUserBlocks(generated, existing, false); err == nil ||
+		!strings.Contains(err.Error(), "three") {
+		t.Errorf("MergeUserBlocks() should have failed for a missing block: %v\n", err)
+	}
+	if str, err = MergeUserBlocks(generated, existing, true); err != nil || str != want {
+		t.Errorf("MergeUserBlocks() forced should be %q but is %q %v\n", want, str, err)
+	}
+
+	t.Log("...End of genCmn::TestMergeUserBlocks")
+}
+
+func TestGenTextFileUserBlocks(t *testing.T) {
+	var err error
+	var data []byte
+
+	t.Log("genCmn::TestGenTextFileUserBlocks()")
+	setupShared(t)
+	sharedData.SetNoop(false)
+	defer sharedData.SetNoop(true)
+
+	dir, err := ioutil.TempDir("", "genCmn")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "models", "sqlapp"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "models", "sqlapp", "x.tmpl.txt"),
+		[]byte("package [[.]]\n\n// <<user:code>>\n// <</user>>\n"), 0644)
+	sharedData.SetMdlDir(filepath.Join(dir, "models"))
+	defer sharedData.SetMdlDir("../../models/")
+
+	out := filepath.Join(dir, "x.go")
+	ioutil.WriteFile(out, []byte("package old\n\n// <<user:code>>\nfunc mine() {}\n// <</user>>\n"), 0644)
+	if err = GenTextFile(util.NewPath("sqlapp/x.tmpl.txt"), util.NewPath(out), "new"); err != nil {
+		t.Fatalf("GenTextFile() failed: %s\n", err)
+	}
+	want := "package new\n\n// <<user:code>>\nfunc mine() {}\n// <</user>>\n"
+	if data, err = ioutil.ReadFile(out); err != nil || string(data) != want {
+		t.Errorf("GenTextFile() should be %q but is %q %v\n", want, data, err)
+	}
+
+	t.Log("...End of genCmn::TestGenTextFileUserBlocks")
+}
@@ -18,7 +18,6 @@ import (
 	"genapp/pkg/genSqlAppGo/dbJson"
 	"genapp/pkg/genSqlAppGo/dbPlugin"
 	"genapp/pkg/sharedData"
-	"log"
 	"strings"
 
 	"github.com/2kranki/go_util"
@@ -81,6 +80,18 @@ type GenTableDeleteStmter interface {
 	GenTableDeleteStmt(tb *dbJson.DbTable) string
 }
 
+// GenTableIndexStmter defines the interface for generating the CREATE
+// INDEX statements of a table.
+type GenTableIndexStmter interface {
+	GenTableIndexStmts(tb *dbJson.DbTable) []string
+}
+
+// GenTableLookupStmter defines the interface for generating the SELECT
+// which returns the key and display field of every row for lookup lists.
+type GenTableLookupStmter interface {
+	GenTableLookupStmt(tb *dbJson.DbTable) string
+}
+
 //----------------------------------------------------------------------------
 //                        	Row SQL Interface Support
 //----------------------------------------------------------------------------
@@ -274,7 +285,7 @@ func GenTableCreateStmt(t *dbJson.DbTable) string {
 	var str strings.Builder
 	var intr GenTableCreateStmter
 	var ok bool
-	var hasKeys bool
+	var cons []string
 
 	db := t.DB
 	pluginData := db.Plugin.(dbPlugin.PluginData)
@@ -284,6 +295,32 @@ func GenTableCreateStmt(t *dbJson.DbTable) string {
 		return intr.GenTableCreateStmt(t)
 	}
 
+	// The table constraints follow the fields. SQLite requires a single
+	// key to be defined on the field if it is to be the rowid.
+	inlinePk := db.SqlType == "sqlite" && t.KeyCount() == 1 && t.Fields[0].KeyNum > 0
+	if t.KeyCount() > 0 && !inlinePk {
+		cons = append(cons, fmt.Sprintf("CONSTRAINT PK_%s PRIMARY KEY(%s)", t.Name, t.KeysList("", "")))
+	}
+	for _, x := range t.IndexDefns() {
+		if x.IsConstraint() {
+			cons = append(cons, x.ConstraintSql())
+		}
+	}
+	for _, r := range t.ForeignKeys() {
+		cons = append(cons, r.CreateSql(db.Schema+r.Table))
+	}
+	for i := range t.Fields {
+		if c := t.Fields[i].EnumCheckSql(); len(c) > 0 {
+			cons = append(cons, strings.Replace(c, "\"", "\\\"", -1))
+		}
+	}
+
+	// The types of the enumerated fields must exist before the table.
+	for i := range t.Fields {
+		if s := t.Fields[i].EnumCreateSql(); len(s) > 0 {
+			fmt.Fprintf(&str, "%s;\\n", strings.Replace(s, "\"", "\\\"", -1))
+		}
+	}
 	fmt.Fprintf(&str, "CREATE TABLE IF NOT EXISTS %s%s (\\n", db.Schema, t.Name)
 	for i, _ := range t.Fields {
 		var cm string
@@ -293,33 +330,20 @@ func GenTableCreateStmt(t *dbJson.DbTable) string {
 		var nl string
 		var pk string
 		var sp string
+		var uq string
 
 		f = &t.Fields[i]
 		cm = ""
-		if i != (len(t.Fields) - 1) {
+		if i != (len(t.Fields)-1) || len(cons) > 0 {
 			cm = ","
-		} else {
-			if hasKeys {
-				cm = ","
-			}
 		}
 
 		td := f.Typ
 		if td == nil {
-			log.Fatalln("Error - Could not find Type definition for field,",
-				f.Name, "type:", f.TypeDefn)
-		}
-		tdd := f.Typ.SqlType()
-
-		if f.Len > 0 {
-			if f.Dec > 0 {
-				ft = fmt.Sprintf("%s(%d,%d)", tdd, f.Len, f.Dec)
-			} else {
-				ft = fmt.Sprintf("%s(%d)", tdd, f.Len)
-			}
-		} else {
-			ft = tdd
+			panic(fmt.Sprintf("Error - Could not find Type definition for field, %s type: %s",
+				f.Name, f.TypeDefn))
 		}
+		ft = strings.Replace(f.ColumnType(), "\"", "\\\"", -1)
 		nl = " NOT NULL"
 		if f.Nullable {
 			nl = ""
@@ -335,23 +359,26 @@ func GenTableCreateStmt(t *dbJson.DbTable) string {
 			}
 		}
 		pk = ""
-		if f.KeyNum > 0 {
-			if t.KeyCount() == 1 && i == 0 && db.SqlType == "sqlite" {
-				pk = " PRIMARY KEY"
-			} else {
-				hasKeys = true
-			}
+		if f.KeyNum > 0 && inlinePk {
+			pk = " PRIMARY KEY"
+		}
+		uq = ""
+		if f.Unique && f.KeyNum == 0 {
+			uq = " UNIQUE"
 		}
 		sp = ""
 		if len(f.SQLParms) > 0 {
 			sp = " " + f.SQLParms
 		}
 
-		fmt.Fprintf(&str, "\\t%s\\t%s%s%s%s%s%s\\n", f.Name, ft, nl, pk, incr, cm, sp)
+		fmt.Fprintf(&str, "\\t%s\\t%s%s%s%s%s%s%s\\n", f.Name, ft, nl, pk, incr, uq, cm, sp)
 	}
-	if hasKeys {
-		wrk := fmt.Sprintf("\\tCONSTRAINT PK_%s PRIMARY KEY(%s)\\n", t.Name, t.KeysList("", ""))
-		str.WriteString(wrk)
+	for i, c := range cons {
+		cm := ","
+		if i == len(cons)-1 {
+			cm = ""
+		}
+		fmt.Fprintf(&str, "\\t%s%s\\n", c, cm)
 	}
 	str.WriteString(")")
 	if len(t.SQLParms) > 0 {
@@ -379,6 +406,58 @@ func GenTableDeleteStmt(t *dbJson.DbTable) string {
 	}
 
 	fmt.Fprintf(&str, "DROP TABLE IF EXISTS %s%s;\\n", db.Schema, t.Name)
+	for i := range t.Fields {
+		if s := t.Fields[i].EnumDropSql(); len(s) > 0 {
+			fmt.Fprintf(&str, "%s;\\n", s)
+		}
+	}
+
+	return str.String()
+}
+
+// GenTableIndexStmts generates the CREATE INDEX statements for the
+// indexes of the table which are not table constraints. They must be
+// issued after the table is created.
+func GenTableIndexStmts(t *dbJson.DbTable) []string {
+	var stmts []string
+	var intr GenTableIndexStmter
+	var ok bool
+
+	db := t.DB
+	pluginData := db.Plugin.(dbPlugin.PluginData)
+	plugin := pluginData.Plugin
+	intr, ok = plugin.(GenTableIndexStmter)
+	if ok {
+		return intr.GenTableIndexStmts(t)
+	}
+
+	for _, x := range t.IndexDefns() {
+		if !x.IsConstraint() {
+			stmt := strings.Replace(x.CreateSql(db.Schema+t.Name), "\"", "\\\"", -1)
+			stmts = append(stmts, stmt+";\\n")
+		}
+	}
+
+	return stmts
+}
+
+// GenTableLookupStmt generates the SELECT which returns the key and
+// display field of every row ordered by the display field.
+func GenTableLookupStmt(t *dbJson.DbTable) string {
+	var str strings.Builder
+	var intr GenTableLookupStmter
+	var ok bool
+
+	db := t.DB
+	pluginData := db.Plugin.(dbPlugin.PluginData)
+	plugin := pluginData.Plugin
+	intr, ok = plugin.(GenTableLookupStmter)
+	if ok {
+		return intr.GenTableLookupStmt(t)
+	}
+
+	fmt.Fprintf(&str, "SELECT %s, %s FROM %s%s ORDER BY %s;\\n", t.KeysList("", ""),
+		t.LookupField().Name, db.Schema, t.Name, t.LookupField().Name)
 
 	return str.String()
 }
@@ -457,12 +536,38 @@ func GenRowInsertStmt(t *dbJson.DbTable) string {
 		return intr.GenRowInsertStmt(t)
 	}
 
-	str.WriteStringf("INSERT INTO %s%s (%s) VALUES (%s);\\n",
-		db.Schema, t.Name, t.InsertNameList(""), GenDataPlaceHolder(t))
+	// The key assigned to the Incr field is returned as a row if the SQL
+	// Server can not give it with the result.
+	var out, ret string
+	if f := t.IncrField(); f != nil && GenRowInsertReturnsKey(t) {
+		if db.SqlType == "mssql" {
+			out = " OUTPUT INSERTED." + f.Name
+		} else {
+			ret = " RETURNING " + f.Name
+		}
+	}
+	str.WriteStringf("INSERT INTO %s%s (%s)%s VALUES (%s)%s;\\n",
+		db.Schema, t.Name, t.InsertNameList(""), out, GenDataPlaceHolder(t), ret)
 
 	return str.String()
 }
 
+// GenRowInsertReturnsKey returns true if the INSERT statement of a table
+// with an Incr field returns the key assigned to it as a row. Otherwise,
+// the key is the LastInsertId() of the result.
+func GenRowInsertReturnsKey(t *dbJson.DbTable) bool {
+
+	if !t.HasIncr() {
+		return false
+	}
+	switch t.DB.SqlType {
+	case "mssql", "postgres":
+		return true
+	}
+
+	return false
+}
+
 func GenRowLastStmt(t *dbJson.DbTable) string {
 	var str util.StringBuilder
 	var intr GenRowLastStmter
@@ -596,9 +701,17 @@ func GenRowUpdateStmt(t *dbJson.DbTable) string {
 		return intr.GenRowUpdateStmt(t)
 	}
 
-	//TODO: Finish Row Update SQL
-	str.WriteStringf("INSERT INTO %s%s ([[.Table.CreateInsertStr]]) VALUES ([[.Table.CreateValueStr]]);\\n",
-		db.Schema, t.Name)
+	// The place holders are the fields being set followed by the keys
+	// as given by TitledUpdateNameList().
+	str.WriteStringf("UPDATE %s%s SET ", db.Schema, t.Name)
+	for i, f := range t.UpdateFields() {
+		cm := ", "
+		if i == 0 {
+			cm = ""
+		}
+		str.WriteStringf("%s%s = ?", cm, f.Name)
+	}
+	str.WriteStringf(" WHERE %s;\\n", GenKeySearchPlaceHolder(t, "="))
 
 	return str.String()
 }
@@ -629,20 +742,31 @@ func GenFormDataDisplay(tb *dbJson.DbTable) string {
 	for _, f := range tb.Fields {
 
 		if !f.Hidden {
-			tdd := f.Typ.Html
 			if len(f.Label) > 0 {
 				lbl = strings.Title(f.Label)
 			} else {
 				lbl = strings.Title(f.Name)
 			}
-			switch f.Typ.GoType() {
-			case "float64":
-				m = "m=\"0\" step=\"0.01\" "
-			default:
-				m = ""
+			if tb.LookupRef(f.Name) != nil && f.IsNull() {
+				// NULL is selected by the empty option.
+				fmt.Fprintf(&str, "\t<tr><td><label>%s</label></td> <td><select name=\"%s\" id=\"%s\">"+
+					"{{$v := .Rcd.ToString \"%s\"}}<option value=\"\"></option>{{range index .Lookups \"%s\"}}"+
+					"<option value=\"{{.Value}}\"{{if eq .Value $v}} selected{{end}}>{{.Label}}</option>"+
+					"{{end}}</select></td>%s</tr>\n",
+					lbl, f.TitledName(), f.TitledName(), f.TitledName(), f.TitledName(), formError(&f))
+				continue
 			}
-			fmt.Fprintf(&str, "\t<tr><td><label>%s</label></td> <td><input type=\"%s\" name=\"%s\" id=\"%s\" %svalue=\"{{.Rcd.%s}}\"></td></tr>\n",
-				lbl, tdd, f.TitledName(), f.TitledName(), m, f.TitledName())
+			if tb.LookupRef(f.Name) != nil {
+				// Referencing fields select from the rows of the parent table.
+				fmt.Fprintf(&str, "\t<tr><td><label>%s</label></td> <td><select name=\"%s\" id=\"%s\">"+
+					"{{$v := printf \"%%v\" .Rcd.%s}}{{range index .Lookups \"%s\"}}"+
+					"<option value=\"{{.Value}}\"{{if eq .Value $v}} selected{{end}}>{{.Label}}</option>"+
+					"{{end}}</select></td>%s</tr>\n",
+					lbl, f.TitledName(), f.TitledName(), f.TitledName(), f.TitledName(), formError(&f))
+				continue
+			}
+			fmt.Fprintf(&str, "\t<tr><td><label>%s</label></td> <td>%s</td>%s</tr>\n",
+				lbl, f.FormInput(), formError(&f))
 		}
 	}
 	str.WriteString("</table>\n")
@@ -650,20 +774,7 @@ func GenFormDataDisplay(tb *dbJson.DbTable) string {
 	// Process Hidden fields outside of the table
 	for _, f := range tb.Fields {
 		if f.Hidden {
-			//tdd := f.Typ.Html
-			if len(f.Label) > 0 {
-				lbl = strings.Title(f.Label)
-			} else {
-				lbl = strings.Title(f.Name)
-			}
-			switch f.Typ.GoType() {
-			case "float64":
-				m = "m=\"0\" step=\"0.01\" "
-			default:
-				m = ""
-			}
-			fmt.Fprintf(&str, "\t<input type=\"hidden\" name=\"%s\" id=\"%s\" %svalue=\"{{.Rcd.%s}}\">\n",
-				f.TitledName(), f.TitledName(), m, f.TitledName())
+			fmt.Fprintf(&str, "\t%s\n", f.FormInput())
 		}
 	}
 
@@ -695,6 +806,12 @@ func GenFormDataDisplay(tb *dbJson.DbTable) string {
 	return str.String()
 }
 
+// formError returns the table cell displaying the message of the field
+// if it failed validation.
+func formError(f *dbJson.DbField) string {
+	return fmt.Sprintf("<td class=\"error\">{{index .Errors \"%s\"}}</td>", f.TitledName())
+}
+
 func GenFormDataKeyGet(tb *dbJson.DbTable) string {
 	var str strings.Builder
 	var intr GenFormDataKeyGetter
@@ -855,23 +972,26 @@ func GenKeysPlaceHolder(tb *dbJson.DbTable) string {
 // init() is called before main(). Here we define the functions that will be
 // used in the templates.
 func init() {
-	sharedData.SetFunc("GenExecErrorCheck", GenExecErrorCheck)
-	sharedData.SetFunc("GenQueryErrorCheck", GenQueryErrorCheck)
-	sharedData.SetFunc("GenDatabaseCreateStmt", GenDatabaseCreateStmt)
-	sharedData.SetFunc("GenDatabaseDeleteStmt", GenDatabaseDeleteStmt)
-	sharedData.SetFunc("GenTableCountStmt", GenTableCountStmt)
-	sharedData.SetFunc("GenTableCreateStmt", GenTableCreateStmt)
-	sharedData.SetFunc("GenTableDeleteStmt", GenTableDeleteStmt)
-	sharedData.SetFunc("GenRowDeleteStmt", GenRowDeleteStmt)
-	sharedData.SetFunc("GenRowFindStmt", GenRowFindStmt)
-	sharedData.SetFunc("GenRowFirstStmt", GenRowFirstStmt)
-	sharedData.SetFunc("GenRowInsertStmt", GenRowInsertStmt)
-	sharedData.SetFunc("GenRowLastStmt", GenRowLastStmt)
-	sharedData.SetFunc("GenRowNextStmt", GenRowNextStmt)
-	sharedData.SetFunc("GenRowPageStmt", GenRowPageStmt)
-	sharedData.SetFunc("GenRowPrevStmt", GenRowPrevStmt)
-	sharedData.SetFunc("GenRowUpdateStmt", GenRowUpdateStmt)
-	sharedData.SetFunc("GenFormDataDisplay", GenFormDataDisplay)
-	sharedData.SetFunc("GenFormDataKeyGet", GenFormDataKeyGet)
-	sharedData.SetFunc("GenFormDataKeys", GenFormDataKeys)
+	sharedData.RegisterFunc("GenExecErrorCheck", GenExecErrorCheck)
+	sharedData.RegisterFunc("GenQueryErrorCheck", GenQueryErrorCheck)
+	sharedData.RegisterFunc("GenDatabaseCreateStmt", GenDatabaseCreateStmt)
+	sharedData.RegisterFunc("GenDatabaseDeleteStmt", GenDatabaseDeleteStmt)
+	sharedData.RegisterFunc("GenTableCountStmt", GenTableCountStmt)
+	sharedData.RegisterFunc("GenTableCreateStmt", GenTableCreateStmt)
+	sharedData.RegisterFunc("GenTableDeleteStmt", GenTableDeleteStmt)
+	sharedData.RegisterFunc("GenTableIndexStmts", GenTableIndexStmts)
+	sharedData.RegisterFunc("GenTableLookupStmt", GenTableLookupStmt)
+	sharedData.RegisterFunc("GenRowDeleteStmt", GenRowDeleteStmt)
+	sharedData.RegisterFunc("GenRowFindStmt", GenRowFindStmt)
+	sharedData.RegisterFunc("GenRowFirstStmt", GenRowFirstStmt)
+	sharedData.RegisterFunc("GenRowInsertStmt", GenRowInsertStmt)
+	sharedData.RegisterFunc("GenRowInsertReturnsKey", GenRowInsertReturnsKey)
+	sharedData.RegisterFunc("GenRowLastStmt", GenRowLastStmt)
+	sharedData.RegisterFunc("GenRowNextStmt", GenRowNextStmt)
+	sharedData.RegisterFunc("GenRowPageStmt", GenRowPageStmt)
+	sharedData.RegisterFunc("GenRowPrevStmt", GenRowPrevStmt)
+	sharedData.RegisterFunc("GenRowUpdateStmt", GenRowUpdateStmt)
+	sharedData.RegisterFunc("GenFormDataDisplay", GenFormDataDisplay)
+	sharedData.RegisterFunc("GenFormDataKeyGet", GenFormDataKeyGet)
+	sharedData.RegisterFunc("GenFormDataKeys", GenFormDataKeys)
 }
@@ -9,8 +9,10 @@ package dbGener
 
 import (
 	"genapp/pkg/genSqlAppGo/dbJson"
+	"genapp/pkg/genSqlAppGo/dbPlugin"
 	"genapp/pkg/sharedData"
 	"log"
+	"strings"
 	"testing"
 	// Include the various Database Plugins so that they will register
 	// with dbPlugin.
@@ -58,7 +60,7 @@ func ReadJsonFile(t *testing.T) {
 
 func TestGenFormDataDisplay(t *testing.T) {
 	var str string
-	var dataTest = "<table>\n\t<tr><td><label>Num</label></td> <td><input type=\"number\" name=\"Num\" id=\"Num\" value=\"{{.Rcd.Num}}\"></td></tr>\n\t<tr><td><label>Name</label></td> <td><input type=\"text\" name=\"Name\" id=\"Name\" value=\"{{.Rcd.Name}}\"></td></tr>\n\t<tr><td><label>Addr1</label></td> <td><input type=\"text\" name=\"Addr1\" id=\"Addr1\" value=\"{{.Rcd.Addr1}}\"></td></tr>\n\t<tr><td><label>Addr2</label></td> <td><input type=\"text\" name=\"Addr2\" id=\"Addr2\" value=\"{{.Rcd.Addr2}}\"></td></tr>\n\t<tr><td><label>City</label></td> <td><input type=\"text\" name=\"City\" id=\"City\" value=\"{{.Rcd.City}}\"></td></tr>\n\t<tr><td><label>State</label></td> <td><input type=\"text\" name=\"State\" id=\"State\" value=\"{{.Rcd.State}}\"></td></tr>\n\t<tr><td><label>Zip</label></td> <td><input type=\"text\" name=\"Zip\" id=\"Zip\" value=\"{{.Rcd.Zip}}\"></td></tr>\n\t<tr><td><label>CurBal</label></td> <td><input type=\"number\" name=\"CurBal\" id=\"CurBal\" value=\"{{.Rcd.CurBal}}\"></td></tr>\n</table>\n<input type=\"hidden\" id=\"key0\" name=\"key0\"value=\"{{.Rcd.Num}}\">\n"
+	var dataTest = "<table>\n\t<tr><td><label>Num</label></td> <td><input type=\"number\" name=\"Num\" id=\"Num\" value=\"{{.Rcd.Num}}\"></td><td class=\"error\">{{index .Errors \"Num\"}}</td></tr>\n\t<tr><td><label>Name</label></td> <td><input type=\"text\" name=\"Name\" id=\"Name\" value=\"{{.Rcd.ToString \"Name\"}}\" required maxlength=\"30\"></td><td class=\"error\">{{index .Errors \"Name\"}}</td></tr>\n\t<tr><td><label>Addr1</label></td> <td><input type=\"text\" name=\"Addr1\" id=\"Addr1\" value=\"{{.Rcd.ToString \"Addr1\"}}\" maxlength=\"30\"></td><td class=\"error\">{{index .Errors \"Addr1\"}}</td></tr>\n\t<tr><td><label>Addr2</label></td> <td><input type=\"text\" name=\"Addr2\" id=\"Addr2\" value=\"{{.Rcd.ToString \"Addr2\"}}\" maxlength=\"30\"></td><td class=\"error\">{{index .Errors \"Addr2\"}}</td></tr>\n\t<tr><td><label>City</label></td> <td><input type=\"text\" name=\"City\" id=\"City\" value=\"{{.Rcd.ToString \"City\"}}\" maxlength=\"20\"></td><td class=\"error\">{{index .Errors \"City\"}}</td></tr>\n\t<tr><td><label>State</label></td> <td><input type=\"text\" name=\"State\" id=\"State\" value=\"{{.Rcd.ToString \"State\"}}\" maxlength=\"10\"></td><td class=\"error\">{{index .Errors \"State\"}}</td></tr>\n\t<tr><td><label>Zip</label></td> <td><input type=\"text\" name=\"Zip\" id=\"Zip\" value=\"{{.Rcd.ToString \"Zip\"}}\" maxlength=\"15\" pattern=\"[0-9A-Za-z -]*\"></td><td class=\"error\">{{index .Errors \"Zip\"}}</td></tr>\n\t<tr><td><label>CurBal</label></td> <td><input type=\"number\" name=\"CurBal\" id=\"CurBal\" value=\"{{.Rcd.ToString \"CurBal\"}}\" min=\"0\" step=\"0.01\"></td><td class=\"error\">{{index .Errors \"CurBal\"}}</td></tr>\n</table>\n<input type=\"hidden\" id=\"key0\" name=\"key0\"value=\"{{.Rcd.Num}}\">\n"
 
 	log.Printf("dbGener::TestGenFormDataDisplay()..\n")
 	sharedData.SetDebug(true)
@@ -165,3 +167,177 @@ func TestGenTableCreateStmt(t *testing.T) {
 	t.Log("...end of dbGener::TestGenTableCreateStmt\n")
 
 }
+
+func TestGenTableCreateStmtReferences(t *testing.T) {
+	var str string
+	var dataTest = "CREATE TABLE IF NOT EXISTS Invoice (\\n\\tNum\\tINTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,\\n\\tCustNum\\tINTEGER NOT NULL,\\n\\tPoNum\\tVARCHAR(20),\\n\\tAmount\\tTEXT(15,2) NOT NULL,\\n\\tCONSTRAINT UQ_Invoice_1 UNIQUE(CustNum, PoNum),\\n\\tCONSTRAINT FK_Invoice_1 FOREIGN KEY(CustNum) REFERENCES Customer(Num) ON DELETE CASCADE\\n);\\n"
+
+	log.Printf("dbGener::TestGenTableCreateStmtReferences()..\n")
+	sharedData.SetDebug(true)
+
+	// Read the test JSON Tables
+	ReadJsonFile(t)
+
+	str = GenTableCreateStmt(&jsonData.Tables[2])
+	t.Log("===")
+	t.Log(str)
+	t.Log(dataTest)
+	t.Log("===")
+	if str != dataTest {
+		t.Errorf(" str: %s", str)
+		t.Errorf("data: %s", dataTest)
+		t.Fatalf("TestGenTableCreateStmtReferences() generated data did not match saved data\n")
+	}
+
+	str = GenTableLookupStmt(&jsonData.Tables[0])
+	if str != "SELECT Num, Name FROM Customer ORDER BY Name;\\n" {
+		t.Fatalf("TestGenTableCreateStmtReferences() invalid lookup: %s\n", str)
+	}
+
+	str = GenFormDataDisplay(&jsonData.Tables[2])
+	t.Log(str)
+	if !strings.Contains(str, "<select name=\"CustNum\" id=\"CustNum\">{{$v := printf \"%v\" .Rcd.CustNum}}{{range index .Lookups \"CustNum\"}}") {
+		t.Fatalf("TestGenTableCreateStmtReferences() form is missing the lookup list\n")
+	}
+
+	t.Log("...end of dbGener::TestGenTableCreateStmtReferences\n")
+
+}
+
+func TestGenTableCreateStmtEnum(t *testing.T) {
+	var str string
+	var tb dbJson.DbTable
+
+	log.Printf("dbGener::TestGenTableCreateStmtEnum()..\n")
+	sharedData.SetDebug(true)
+
+	// Read the test JSON Tables
+	ReadJsonFile(t)
+
+	db := *jsonData
+	tb = db.Tables[0]
+	tb.DB = &db
+	tb.Fields = append([]dbJson.DbField(nil), tb.Fields...)
+	tb.Fields = append(tb.Fields, dbJson.DbField{Name: "Status", TypeDefn: "text", Len: 6,
+		Required: true, Enum: []string{"open", "closed"}})
+	for i := range tb.Fields {
+		tb.Fields[i].Tbl = &tb
+	}
+	tb.Fields[len(tb.Fields)-1].Typ = tb.Fields[1].Typ
+
+	str = GenTableCreateStmt(&tb)
+	t.Log(str)
+	if !strings.Contains(str, "\\tStatus\\tVARCHAR(6) NOT NULL,\\n\\tCONSTRAINT CK_Customer_Status CHECK (Status IN ('open', 'closed'))\\n);") {
+		t.Fatalf("TestGenTableCreateStmtEnum() sqlite is missing the CHECK constraint\n")
+	}
+
+	plg, err := dbPlugin.FindPlugin("postgres")
+	if err != nil {
+		t.Fatalf("TestGenTableCreateStmtEnum() FindPlugin() failed: %s\n", err.Error())
+	}
+	db.SqlType = "postgres"
+	db.Plugin = plg
+	str = GenTableCreateStmt(&tb)
+	t.Log(str)
+	if !strings.HasPrefix(str, "DO $$ BEGIN CREATE TYPE customer_status AS ENUM ('open', 'closed'); "+
+		"EXCEPTION WHEN duplicate_object THEN NULL; END $$;\\nCREATE TABLE IF NOT EXISTS Customer (") ||
+		!strings.Contains(str, "\\tStatus\\tcustomer_status NOT NULL,\\n") {
+		t.Fatalf("TestGenTableCreateStmtEnum() postgres is missing the enum type\n")
+	}
+	str = GenTableDeleteStmt(&tb)
+	if str != "DROP TABLE IF EXISTS Customer;\\nDROP TYPE IF EXISTS customer_status;\\n" {
+		t.Fatalf("TestGenTableCreateStmtEnum() invalid postgres delete: %s\n", str)
+	}
+
+	t.Log("...end of dbGener::TestGenTableCreateStmtEnum\n")
+
+}
+
+func TestGenTableIndexStmts(t *testing.T) {
+	var stmts []string
+	var dataTest = "CREATE INDEX IX_Invoice_Amount ON Invoice(Amount) WHERE Amount <> '0';\\n"
+
+	log.Printf("dbGener::TestGenTableIndexStmts()..\n")
+	sharedData.SetDebug(true)
+
+	// Read the test JSON Tables
+	ReadJsonFile(t)
+
+	stmts = GenTableIndexStmts(&jsonData.Tables[2])
+	t.Log(stmts)
+	if len(stmts) != 1 || stmts[0] != dataTest {
+		t.Fatalf("TestGenTableIndexStmts() generated data did not match saved data\n")
+	}
+
+	stmts = GenTableIndexStmts(&jsonData.Tables[0])
+	if len(stmts) != 0 {
+		t.Fatalf("TestGenTableIndexStmts() Customer should not have any indexes\n")
+	}
+
+	t.Log("...end of dbGener::TestGenTableIndexStmts\n")
+
+}
+
+func TestGenRowUpdateStmt(t *testing.T) {
+	var str string
+	var dataTest = "UPDATE Customer SET Name = ?, Addr1 = ?, Addr2 = ?, City = ?, State = ?, Zip = ?, CurBal = ? WHERE Num = ?;\\n"
+	var nameTest = "d.Name, d.Addr1, d.Addr2, d.City, d.State, d.Zip, d.CurBal, d.Num"
+
+	log.Printf("dbGener::TestGenRowUpdateStmt()..\n")
+	sharedData.SetDebug(true)
+
+	// Read the test JSON Tables
+	ReadJsonFile(t)
+
+	str = GenRowUpdateStmt(&jsonData.Tables[0])
+	if str != dataTest {
+		t.Errorf(" str: %s", str)
+		t.Errorf("data: %s", dataTest)
+		t.Fatalf("TestGenRowUpdateStmt() generated data did not match saved data\n")
+	}
+	if str = jsonData.Tables[0].TitledUpdateNameList("d."); str != nameTest {
+		t.Fatalf("TestGenRowUpdateStmt() invalid name list: %s\n", str)
+	}
+
+	t.Log("...end of dbGener::TestGenRowUpdateStmt\n")
+
+}
+
+func TestGenRowInsertStmt(t *testing.T) {
+	var str string
+
+	log.Printf("dbGener::TestGenRowInsertStmt()..\n")
+	sharedData.SetDebug(true)
+
+	// Read the test JSON Tables
+	ReadJsonFile(t)
+
+	// Invoice has an Incr key.
+	db := *jsonData
+	tb := db.Tables[2]
+	tb.DB = &db
+	tests := []struct {
+		sqlType string
+		keyed   bool
+		stmt    string
+	}{
+		{"sqlite", false, "INSERT INTO Invoice (CustNum, PoNum, Amount) VALUES (?, ?, ?);\\n"},
+		{"postgres", true, "INSERT INTO Invoice (CustNum, PoNum, Amount) VALUES (?, ?, ?) RETURNING Num;\\n"},
+		{"mssql", true, "INSERT INTO Invoice (CustNum, PoNum, Amount) OUTPUT INSERTED.Num VALUES (?, ?, ?);\\n"},
+	}
+	for _, tst := range tests {
+		db.SqlType = tst.sqlType
+		if str = GenRowInsertStmt(&tb); str != tst.stmt {
+			t.Errorf("TestGenRowInsertStmt() invalid %s statement: %s\n", tst.sqlType, str)
+		}
+		if GenRowInsertReturnsKey(&tb) != tst.keyed {
+			t.Errorf("TestGenRowInsertStmt() invalid %s GenRowInsertReturnsKey()\n", tst.sqlType)
+		}
+	}
+	if GenRowInsertReturnsKey(&jsonData.Tables[0]) {
+		t.Errorf("TestGenRowInsertStmt() Customer has no Incr field\n")
+	}
+
+	t.Log("...end of dbGener::TestGenRowInsertStmt\n")
+
+}
@@ -0,0 +1,337 @@
+// See License.txt in main repository directory
+
+// migrate generates the SQL statements which migrate a database from
+// one version of its definitions to another (see dbJson.Diff()).
+
+// Unlike the other generation functions which produce the contents of
+// Go string literals for the generated application, these produce
+// plain SQL statements without the trailing ';' since they are written
+// to migration files.
+
+// The default statements follow the SQL standard which PostgreSQL
+// supports. Plugins supply their own through the interfaces below.
+
+// The type (PostgreSQL) or CHECK constraint of an enumerated field is
+// named after its table and field. So, it is renamed along with them,
+// replaced when the field is altered and dropped with the field.
+
+package dbGener
+
+import (
+	"fmt"
+	"genapp/pkg/genSqlAppGo/dbJson"
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+	"strconv"
+	"strings"
+)
+
+//----------------------------------------------------------------------------
+//                        	Migration Interface Support
+//----------------------------------------------------------------------------
+
+// TableRebuilder is implemented by plugins whose ALTER TABLE can not
+// make all of the changes. RebuildTable returns true if the change must
+// be made by rebuilding the table (see GenTableRebuildStmts()).
+type TableRebuilder interface {
+	RebuildTable(c *dbJson.DbChange) bool
+}
+
+type GenTableRenameStmter interface {
+	GenTableRenameStmts(c *dbJson.DbChange) []string
+}
+
+type GenFieldAddStmter interface {
+	GenFieldAddStmts(c *dbJson.DbChange) []string
+}
+
+type GenFieldAlterStmter interface {
+	GenFieldAlterStmts(c *dbJson.DbChange) []string
+}
+
+type GenFieldDropStmter interface {
+	GenFieldDropStmts(c *dbJson.DbChange) []string
+}
+
+type GenFieldRenameStmter interface {
+	GenFieldRenameStmts(c *dbJson.DbChange) []string
+}
+
+type GenIndexAddStmter interface {
+	GenIndexAddStmts(c *dbJson.DbChange) []string
+}
+
+type GenIndexDropStmter interface {
+	GenIndexDropStmts(c *dbJson.DbChange) []string
+}
+
+//----------------------------------------------------------------------------
+//							Migration Support Functions
+//----------------------------------------------------------------------------
+
+// SqlText converts a statement generated for a Go string literal such
+// as GenTableCreateStmt() into plain SQL without the trailing ';'.
+func SqlText(stmt string) string {
+	s, err := strconv.Unquote("\"" + stmt + "\"")
+	if err != nil {
+		s = stmt
+	}
+	return strings.TrimRight(strings.TrimSpace(s), ";")
+}
+
+// changePlugin returns the plugin for the database of the change.
+func changePlugin(c *dbJson.DbChange) interface{} {
+	t := c.New
+	if t == nil {
+		t = c.Old
+	}
+	return t.DB.Plugin.(dbPlugin.PluginData).Plugin
+}
+
+// GenTableRebuildStmts generates the statements which rebuild a table
+// as the given definition by creating a new table, copying the rows to
+// it and replacing the old table with it. All of the columns of the
+// definition must exist in the current table. Only the given indexes
+// are created.
+func GenTableRebuildStmts(tb *dbJson.DbTable, idxs []dbJson.DbIndex) []string {
+	var stmts []string
+	var tmp dbJson.DbTable
+
+	db := tb.DB
+	tn := db.Schema + tb.Name
+
+	// The copy keeps the references of the table, but under the names
+	// that they have now.
+	tmp = *tb
+	tmp.Name = tb.Name + "_new"
+	tmp.References = tb.ForeignKeys()
+	tmp.Fields = append([]dbJson.DbField(nil), tb.Fields...)
+	for i := range tmp.Fields {
+		tmp.Fields[i].Ref = ""
+	}
+	tmp.Indexes = nil
+	for _, x := range idxs {
+		if x.IsConstraint() {
+			tmp.Indexes = append(tmp.Indexes, x)
+		}
+	}
+
+	if db.SqlType == "sqlite" && db.HasReferences() {
+		stmts = append(stmts, "PRAGMA foreign_keys=OFF")
+	}
+	stmts = append(stmts, SqlText(GenTableCreateStmt(&tmp)))
+	stmts = append(stmts, fmt.Sprintf("INSERT INTO %s%s (%s) SELECT %s FROM %s", db.Schema,
+		tmp.Name, tb.FieldNameList(""), tb.FieldNameList(""), tn))
+	stmts = append(stmts, fmt.Sprintf("DROP TABLE %s", tn))
+	stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s RENAME TO %s", db.Schema, tmp.Name, tb.Name))
+	for _, x := range idxs {
+		if !x.IsConstraint() {
+			stmts = append(stmts, x.CreateSql(tn))
+		}
+	}
+	if db.SqlType == "sqlite" && db.HasReferences() {
+		stmts = append(stmts, "PRAGMA foreign_keys=ON")
+	}
+
+	return stmts
+}
+
+// genEnumRenameStmts generates the statement which renames the type of an
+// enumerated field (of) to its name as the other field (nf) if the SQL
+// Server uses one and its name changed.
+func genEnumRenameStmts(of, nf *dbJson.DbField) []string {
+	if len(of.EnumCreateSql()) == 0 || of.EnumTypeName() == nf.EnumTypeName() {
+		return nil
+	}
+	return []string{fmt.Sprintf("ALTER TYPE %s%s RENAME TO %s", of.Tbl.DB.Schema,
+		of.EnumTypeName(), nf.EnumTypeName())}
+}
+
+// fieldDropTable returns the table of a FieldDrop change as it is once
+// the field is dropped. The fields have been renamed, but not altered or
+// added. So, the other fields are as defined in the Old table under their
+// New names. The fields dropped before this one are already gone.
+func fieldDropTable(c *dbJson.DbChange) *dbJson.DbTable {
+	var tb dbJson.DbTable
+
+	tb = *c.Old
+	tb.Name = c.New.Name
+	tb.Fields = nil
+	dropped := true
+	for _, f := range c.Old.Fields {
+		if f.Name == c.OldField.Name {
+			dropped = false
+			continue
+		}
+		kept := false
+		for _, nf := range c.New.Fields {
+			if nf.OldName == f.Name {
+				f.Name = nf.Name
+				kept = true
+				break
+			}
+		}
+		if !kept && c.New.FindField(f.Name) == nil && dropped {
+			continue
+		}
+		tb.Fields = append(tb.Fields, f)
+	}
+	for i := range tb.Fields {
+		tb.Fields[i].Tbl = &tb
+	}
+
+	return &tb
+}
+
+// GenMigrationStmts generates the statements for one change.
+func GenMigrationStmts(c *dbJson.DbChange) []string {
+	var stmts []string
+
+	plugin := changePlugin(c)
+	if intr, ok := plugin.(TableRebuilder); ok && intr.RebuildTable(c) {
+		if c.Kind == dbJson.IndexDrop {
+			// The fields have not been changed yet.
+			old := *c.Old
+			old.Name = c.New.Name
+			return GenTableRebuildStmts(&old, c.KeptIndexes(true))
+		}
+		if c.Kind == dbJson.FieldDrop {
+			return GenTableRebuildStmts(fieldDropTable(c), c.KeptIndexes(false))
+		}
+		return GenTableRebuildStmts(c.New, c.KeptIndexes(false))
+	}
+
+	switch c.Kind {
+	case dbJson.TableAdd:
+		stmts = append(stmts, SqlText(GenTableCreateStmt(c.New)))
+		for _, s := range GenTableIndexStmts(c.New) {
+			stmts = append(stmts, SqlText(s))
+		}
+	case dbJson.TableDrop:
+		stmts = append(stmts, SqlText(GenTableDeleteStmt(c.Old)))
+	case dbJson.TableRename:
+		if intr, ok := plugin.(GenTableRenameStmter); ok {
+			return intr.GenTableRenameStmts(c)
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s RENAME TO %s", c.Old.DB.Schema,
+			c.Old.Name, c.New.Name))
+		for i := range c.Old.Fields {
+			stmts = append(stmts, genEnumRenameStmts(&c.Old.Fields[i],
+				c.Old.Fields[i].InTable(c.New))...)
+		}
+	case dbJson.FieldAdd:
+		if intr, ok := plugin.(GenFieldAddStmter); ok {
+			return intr.GenFieldAddStmts(c)
+		}
+		if s := c.NewField.EnumCreateSql(); len(s) > 0 {
+			stmts = append(stmts, s)
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s ADD COLUMN %s", c.New.DB.Schema,
+			c.New.Name, c.NewField.ColumnDefn()))
+	case dbJson.FieldAlter:
+		if intr, ok := plugin.(GenFieldAlterStmter); ok {
+			return intr.GenFieldAlterStmts(c)
+		}
+		tn := c.New.DB.Schema + c.New.Name
+		of := c.OldField.InTable(c.New)
+		of.Name = c.NewField.Name
+		nf := c.NewField
+		if len(of.EnumCheckSql()) > 0 {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tn,
+				of.EnumCheckName()))
+		}
+		// An enumerated type is replaced by one with the new values. The
+		// column is converted through text since there is no cast between
+		// the types.
+		using := ""
+		oldType := ""
+		if len(of.EnumCreateSql()) > 0 {
+			oldType = c.New.DB.Schema + of.EnumTypeName()
+			if nf.IsEnum() {
+				stmts = append(stmts, fmt.Sprintf("ALTER TYPE %s RENAME TO %s_old", oldType,
+					of.EnumTypeName()))
+				oldType += "_old"
+			}
+		}
+		if s := nf.EnumCreateSql(); len(s) > 0 {
+			stmts = append(stmts, s)
+		}
+		if len(oldType) > 0 || len(nf.EnumCreateSql()) > 0 {
+			using = fmt.Sprintf(" USING %s::text::%s", nf.Name, nf.ColumnType())
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s", tn,
+			nf.Name, nf.ColumnType(), using))
+		if c.OldField.Nullable != nf.Nullable {
+			nl := "SET NOT NULL"
+			if nf.Nullable {
+				nl = "DROP NOT NULL"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", tn,
+				nf.Name, nl))
+		}
+		if len(oldType) > 0 {
+			stmts = append(stmts, fmt.Sprintf("DROP TYPE IF EXISTS %s", oldType))
+		}
+		if ck := nf.EnumCheckSql(); len(ck) > 0 {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD %s", tn, ck))
+		}
+	case dbJson.FieldDrop:
+		if intr, ok := plugin.(GenFieldDropStmter); ok {
+			return intr.GenFieldDropStmts(c)
+		}
+		of := c.OldField.InTable(c.New)
+		if len(of.EnumCheckSql()) > 0 {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s DROP CONSTRAINT %s", c.New.DB.Schema,
+				c.New.Name, of.EnumCheckName()))
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s DROP COLUMN %s", c.New.DB.Schema,
+			c.New.Name, c.OldField.Name))
+		if s := of.EnumDropSql(); len(s) > 0 {
+			stmts = append(stmts, s)
+		}
+	case dbJson.FieldRename:
+		if intr, ok := plugin.(GenFieldRenameStmter); ok {
+			return intr.GenFieldRenameStmts(c)
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s RENAME COLUMN %s TO %s", c.New.DB.Schema,
+			c.New.Name, c.OldField.Name, c.NewField.Name))
+		stmts = append(stmts, genEnumRenameStmts(c.OldField.InTable(c.New), c.NewField)...)
+	case dbJson.IndexAdd:
+		if intr, ok := plugin.(GenIndexAddStmter); ok {
+			return intr.GenIndexAddStmts(c)
+		}
+		if c.NewIndex.IsConstraint() {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s ADD %s", c.New.DB.Schema,
+				c.New.Name, c.NewIndex.ConstraintSql()))
+		} else {
+			stmts = append(stmts, c.NewIndex.CreateSql(c.New.DB.Schema+c.New.Name))
+		}
+	case dbJson.IndexDrop:
+		if intr, ok := plugin.(GenIndexDropStmter); ok {
+			return intr.GenIndexDropStmts(c)
+		}
+		if c.OldIndex.IsConstraint() {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s%s DROP CONSTRAINT %s", c.New.DB.Schema,
+				c.New.Name, c.OldIndex.Name))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("DROP INDEX %s%s", c.New.DB.Schema, c.OldIndex.Name))
+		}
+	}
+
+	return stmts
+}
+
+// GenMigration generates the text of a migration file for the given
+// changes. Each change is preceded by a comment describing it.
+func GenMigration(chgs dbJson.DbChanges) string {
+	var str strings.Builder
+
+	for _, c := range chgs {
+		fmt.Fprintf(&str, "-- %s\n", c.String())
+		for _, s := range GenMigrationStmts(&c) {
+			fmt.Fprintf(&str, "%s;\n", s)
+		}
+		str.WriteString("\n")
+	}
+
+	return str.String()
+}
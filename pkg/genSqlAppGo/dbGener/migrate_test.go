@@ -0,0 +1,215 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the generation of the migration statements
+
+package dbGener
+
+import (
+	"genapp/pkg/genSqlAppGo/dbJson"
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+	"genapp/pkg/sharedData"
+	"log"
+	"strings"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//								GenMigration
+//----------------------------------------------------------------------------
+
+func TestGenMigration(t *testing.T) {
+	var from, to *dbJson.Database
+	var err error
+
+	log.Printf("dbGener::TestGenMigration()..\n")
+	sharedData.SetDebug(true)
+
+	if from, err = dbJson.ReadMigrationJsonFile(jsonTestPath); err != nil {
+		t.Fatalf("TestGenMigration() reading from failed: %s\n", err)
+	}
+	if to, err = dbJson.ReadMigrationJsonFile(jsonTestPath); err != nil {
+		t.Fatalf("TestGenMigration() reading to failed: %s\n", err)
+	}
+
+	// Add a field, lengthen a field and drop the partial index.
+	tb := to.FindTable("Invoice")
+	tb.Fields = append(tb.Fields, dbJson.DbField{Name: "Memo", TypeDefn: "text", Len: 40,
+		Nullable: true, Typ: tb.Fields[2].Typ})
+	tb.Fields[2].Len = 30
+	tb.Indexes = tb.Indexes[:1]
+
+	chgs, p := dbJson.Diff(from, to)
+	if p.ErrorCount() > 0 {
+		t.Fatalf("TestGenMigration() Diff() failed:\n%s\n", p.String())
+	}
+	if len(chgs) != 3 {
+		t.Fatalf("TestGenMigration() should have 3 changes but has %d\n", len(chgs))
+	}
+
+	up := GenMigration(chgs)
+	t.Logf("Up:\n%s\n", up)
+	tests := []string{
+		"-- drop index Invoice.IX_Invoice_Amount\nDROP INDEX IF EXISTS IX_Invoice_Amount;\n",
+		"ALTER TABLE Invoice ADD COLUMN Memo VARCHAR(40);\n",
+		"PRAGMA foreign_keys=OFF;\n",
+		"INSERT INTO Invoice_new (Num, CustNum, PoNum, Amount, Memo) SELECT Num, CustNum, PoNum, Amount, Memo FROM Invoice;\n",
+		"DROP TABLE Invoice;\nALTER TABLE Invoice_new RENAME TO Invoice;\n",
+	}
+	for _, s := range tests {
+		if !strings.Contains(up, s) {
+			t.Errorf("TestGenMigration() missing: %q\n", s)
+		}
+	}
+	if strings.Index(up, "ADD COLUMN") > strings.Index(up, "INSERT INTO") {
+		t.Errorf("TestGenMigration() the field must be added before the table is rebuilt\n")
+	}
+
+	down := GenMigration(chgs.Down())
+	t.Logf("Down:\n%s\n", down)
+	tests = []string{
+		"ALTER TABLE Invoice DROP COLUMN Memo;\n",
+		"CREATE INDEX IX_Invoice_Amount ON Invoice(Amount) WHERE Amount <> '0';\n",
+	}
+	for _, s := range tests {
+		if !strings.Contains(down, s) {
+			t.Errorf("TestGenMigration() down missing: %q\n", s)
+		}
+	}
+
+	t.Log("...end of dbGener::TestGenMigration\n")
+
+}
+
+//----------------------------------------------------------------------------
+//								GenMigrationEnum
+//----------------------------------------------------------------------------
+
+// readMigrationDb reads the test database for the SQL Server.
+func readMigrationDb(t *testing.T, sqlType string) *dbJson.Database {
+
+	db, err := dbJson.ReadMigrationJsonFile(jsonTestPath)
+	if err != nil {
+		t.Fatalf("readMigrationDb() failed: %s\n", err)
+	}
+	plg, err := dbPlugin.FindPlugin(sqlType)
+	if err != nil {
+		t.Fatalf("readMigrationDb() FindPlugin() failed: %s\n", err.Error())
+	}
+	db.SqlType = sqlType
+	db.Plugin = plg
+
+	return db
+}
+
+// addStatus adds an enumerated Status field to the Invoice table of the
+// database.
+func addStatus(db *dbJson.Database, name, oldName string, vals ...string) {
+	tb := db.FindTable("Invoice")
+	tb.Fields = append(tb.Fields, dbJson.DbField{Name: name, OldName: oldName, TypeDefn: "text",
+		Len: 6, Nullable: true, Enum: vals, Tbl: tb, Typ: tb.Fields[2].Typ})
+}
+
+func TestGenMigrationEnum(t *testing.T) {
+
+	log.Printf("dbGener::TestGenMigrationEnum()..\n")
+	sharedData.SetDebug(true)
+
+	tests := []struct {
+		sqlType string
+		from    []string // Values of Status before (nil if not present)
+		to      []string // Values of Status after (nil if not present)
+		rename  bool     // Status is renamed to State
+		up      []string
+		down    []string
+	}{
+		{"sqlite", nil, []string{"open", "closed"}, false,
+			[]string{"ALTER TABLE Invoice ADD COLUMN Status VARCHAR(6) CONSTRAINT CK_Invoice_Status " +
+				"CHECK (Status IN ('open', 'closed'));\n"},
+			[]string{"INSERT INTO Invoice_new (Num, CustNum, PoNum, Amount) SELECT Num, CustNum, PoNum, Amount FROM Invoice;\n"}},
+		{"postgres", nil, []string{"open", "closed"}, false,
+			[]string{"DO $$ BEGIN CREATE TYPE invoice_status AS ENUM ('open', 'closed'); " +
+				"EXCEPTION WHEN duplicate_object THEN NULL; END $$;\n" +
+				"ALTER TABLE Invoice ADD COLUMN Status invoice_status;\n"},
+			[]string{"ALTER TABLE Invoice DROP COLUMN Status;\nDROP TYPE IF EXISTS invoice_status;\n"}},
+		{"mssql", nil, []string{"open", "closed"}, false,
+			[]string{"ALTER TABLE Invoice ADD Status VARCHAR(6) CONSTRAINT CK_Invoice_Status " +
+				"CHECK (Status IN ('open', 'closed'));\n"},
+			[]string{"ALTER TABLE Invoice DROP CONSTRAINT CK_Invoice_Status;\n" +
+				"ALTER TABLE Invoice DROP COLUMN Status;\n"}},
+		{"postgres", []string{"open", "closed"}, []string{"open", "closed", "void"}, false,
+			[]string{"ALTER TYPE invoice_status RENAME TO invoice_status_old;\n" +
+				"DO $$ BEGIN CREATE TYPE invoice_status AS ENUM ('open', 'closed', 'void'); " +
+				"EXCEPTION WHEN duplicate_object THEN NULL; END $$;\n" +
+				"ALTER TABLE Invoice ALTER COLUMN Status TYPE invoice_status USING Status::text::invoice_status;\n" +
+				"DROP TYPE IF EXISTS invoice_status_old;\n"},
+			[]string{"ALTER TYPE invoice_status RENAME TO invoice_status_old;\n"}},
+		{"mssql", []string{"open", "closed"}, []string{"open", "closed", "void"}, false,
+			[]string{"ALTER TABLE Invoice DROP CONSTRAINT CK_Invoice_Status;\n" +
+				"ALTER TABLE Invoice ALTER COLUMN Status VARCHAR(6) NULL;\n" +
+				"ALTER TABLE Invoice ADD CONSTRAINT CK_Invoice_Status CHECK (Status IN ('open', 'closed', 'void'));\n"},
+			nil},
+		{"sqlite", []string{"open", "closed"}, []string{"open", "void"}, false,
+			[]string{"CONSTRAINT CK_Invoice_Status CHECK (Status IN ('open', 'void'))"}, nil},
+		{"postgres", []string{"open"}, []string{"open"}, true,
+			[]string{"ALTER TABLE Invoice RENAME COLUMN Status TO State;\n" +
+				"ALTER TYPE invoice_status RENAME TO invoice_state;\n"},
+			[]string{"ALTER TYPE invoice_state RENAME TO invoice_status;\n"}},
+		{"mssql", []string{"open"}, []string{"open"}, true,
+			[]string{"EXEC sp_rename 'CK_Invoice_Status', 'CK_Invoice_State', 'OBJECT';\n"},
+			[]string{"EXEC sp_rename 'CK_Invoice_State', 'CK_Invoice_Status', 'OBJECT';\n"}},
+	}
+	for i, tst := range tests {
+		from := readMigrationDb(t, tst.sqlType)
+		to := readMigrationDb(t, tst.sqlType)
+		if tst.from != nil {
+			addStatus(from, "Status", "", tst.from...)
+		}
+		if tst.to != nil && tst.rename {
+			addStatus(to, "State", "Status", tst.to...)
+		} else if tst.to != nil {
+			addStatus(to, "Status", "", tst.to...)
+		}
+
+		chgs, p := dbJson.Diff(from, to)
+		if p.ErrorCount() > 0 {
+			t.Fatalf("TestGenMigrationEnum() %d Diff() failed:\n%s\n", i, p.String())
+		}
+		if len(chgs) != 1 {
+			t.Errorf("TestGenMigrationEnum() %d should have 1 change but has %d\n", i, len(chgs))
+		}
+		up := GenMigration(chgs)
+		down := GenMigration(chgs.Down())
+		t.Logf("%d %s Up:\n%s\nDown:\n%s\n", i, tst.sqlType, up, down)
+		for _, s := range tst.up {
+			if !strings.Contains(up, s) {
+				t.Errorf("TestGenMigrationEnum() %d %s up missing %q:\n%s\n", i, tst.sqlType, s, up)
+			}
+		}
+		for _, s := range tst.down {
+			if !strings.Contains(down, s) {
+				t.Errorf("TestGenMigrationEnum() %d %s down missing %q:\n%s\n", i, tst.sqlType, s, down)
+			}
+		}
+	}
+
+	// Renaming the table renames the types of its enumerated fields.
+	from := readMigrationDb(t, "postgres")
+	to := readMigrationDb(t, "postgres")
+	addStatus(from, "Status", "", "open")
+	addStatus(to, "Status", "", "open")
+	tb := to.FindTable("Invoice")
+	tb.OldName = tb.Name
+	tb.Name = "Bill"
+	chgs, p := dbJson.Diff(from, to)
+	if p.ErrorCount() > 0 {
+		t.Fatalf("TestGenMigrationEnum() rename Diff() failed:\n%s\n", p.String())
+	}
+	up := GenMigration(chgs)
+	t.Logf("Rename Up:\n%s\n", up)
+	if !strings.Contains(up, "ALTER TABLE Invoice RENAME TO Bill;\nALTER TYPE invoice_status RENAME TO bill_status;\n") {
+		t.Errorf("TestGenMigrationEnum() rename is missing the type:\n%s\n", up)
+	}
+
+	t.Log("...end of dbGener::TestGenMigrationEnum\n")
+}
@@ -0,0 +1,404 @@
+// See License.txt in main repository directory
+
+// analyze contains the semantic analysis phase of the database
+// definitions. ValidateData() only insures that the minimum needed
+// to generate is present. Analyze() looks at the definitions as a
+// whole and reports every problem that it finds in one pass so that
+// the definitions can be corrected before any code is generated.
+
+// Notes:
+//	*	Analyze() does not require that SetupPlugin() has been run.
+//		It looks up the plugin itself and works from the plugin's
+//		type definitions with the Types merged into them.
+//	*	Plugins may add their own checks by supporting the
+//		TableAnalyzer interface.
+
+package dbJson
+
+import (
+	"fmt"
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/2kranki/go_util"
+)
+
+//============================================================================
+//								Interfaces
+//============================================================================
+
+// TableAnalyzer is an optional plugin interface which allows the plugin
+// to add checks for a table which are specific to its SQL Server.
+type TableAnalyzer interface {
+	AnalyzeTable(t *DbTable, p *Problems)
+}
+
+//============================================================================
+//								Problems
+//============================================================================
+
+// Problem describes one error or warning found in the definitions. Path
+// locates the problem as "database", "database.table" or
+// "database.table.field".
+type Problem struct {
+	Path    string
+	Msg     string
+	Warning bool
+}
+
+func (p Problem) String() string {
+	lvl := "Error"
+	if p.Warning {
+		lvl = "Warning"
+	}
+	return fmt.Sprintf("%s: %s: %s", lvl, p.Path, p.Msg)
+}
+
+// Problems accumulates the problems found during the analysis.
+type Problems []Problem
+
+// AddError adds an error for the given path.
+func (p *Problems) AddError(path string, format string, a ...interface{}) {
+	*p = append(*p, Problem{Path: path, Msg: fmt.Sprintf(format, a...)})
+}
+
+// AddWarning adds a warning for the given path.
+func (p *Problems) AddWarning(path string, format string, a ...interface{}) {
+	*p = append(*p, Problem{Path: path, Msg: fmt.Sprintf(format, a...), Warning: true})
+}
+
+// Error allows Problems to be returned as an error. Only the errors
+// are included.
+func (p Problems) Error() string {
+	var str strings.Builder
+
+	for _, v := range p {
+		if !v.Warning {
+			str.WriteString(v.String())
+			str.WriteString("\n")
+		}
+	}
+	return str.String()
+}
+
+// ErrorCount returns the number of errors (not warnings).
+func (p Problems) ErrorCount() int {
+	var cnt int
+
+	for _, v := range p {
+		if !v.Warning {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// Err returns the problems as an error if there are any errors,
+// otherwise nil.
+func (p Problems) Err() error {
+	if p.ErrorCount() > 0 {
+		return p
+	}
+	return nil
+}
+
+func (p Problems) String() string {
+	var str strings.Builder
+
+	for _, v := range p {
+		str.WriteString(v.String())
+		str.WriteString("\n")
+	}
+	return str.String()
+}
+
+//============================================================================
+//								Analysis
+//============================================================================
+
+// Analyze performs the semantic analysis of the database definition and
+// returns all the problems found.
+func (d *Database) Analyze() Problems {
+	var p Problems
+	var plg dbPlugin.PluginData
+	var err error
+	var reserved map[string]bool
+
+	dbPath := d.Name
+	if dbPath == "" {
+		dbPath = "(database)"
+		p.AddError(dbPath, "Name is missing")
+	}
+	if d.SqlType == "" {
+		p.AddError(dbPath, "SqlType is missing")
+	} else if plg, err = dbPlugin.FindPlugin(d.SqlType); err != nil {
+		p.AddError(dbPath, "SqlType, %s, is not supported", d.SqlType)
+	}
+	d.analyzeTypes(dbPath, plg, &p)
+	if plg.Types != nil {
+		plg.Types = d.TypeDefns(plg.Types)
+	}
+	if len(d.Tables) == 0 {
+		p.AddError(dbPath, "there are no tables defined")
+	}
+	if d.NullType != "" && d.NullType != "sql" && d.NullType != "pointer" {
+		p.AddError(dbPath, "NullType, %s, is not one of %s", d.NullType, strings.Join(NullTypes, ", "))
+	}
+
+	if intr, ok := plg.Plugin.(dbPlugin.ReservedWorder); ok {
+		reserved = map[string]bool{}
+		for _, w := range intr.ReservedWords() {
+			reserved[strings.ToUpper(w)] = true
+		}
+	}
+
+	// Table names become part of the generated package and struct names
+	// which are titled. So, the names must be unique ignoring case.
+	tblNames := map[string]string{}
+	// Some SQL Servers require index names to be unique within the
+	// database, not just the table.
+	idxNames := map[string]string{}
+	for i := range d.Tables {
+		t := &d.Tables[i]
+		if t.DB == nil {
+			t.DB = d
+		}
+		tblPath := fmt.Sprintf("%s.%s", dbPath, t.Name)
+		if t.Name == "" {
+			tblPath = fmt.Sprintf("%s.Tables[%d]", dbPath, i)
+			p.AddError(tblPath, "table name is missing")
+		} else {
+			if nm, ok := tblNames[strings.ToLower(t.Name)]; ok {
+				p.AddError(tblPath, "duplicate table name (see %s)", nm)
+			} else {
+				tblNames[strings.ToLower(t.Name)] = t.Name
+			}
+			if reserved[strings.ToUpper(t.Name)] {
+				p.AddError(tblPath, "table name is a reserved word in %s", d.SqlType)
+			}
+		}
+		d.analyzeTable(t, tblPath, plg, reserved, &p)
+		d.analyzeReferences(t, tblPath, plg, &p)
+		d.analyzeIndexes(t, tblPath, idxNames, &p)
+		d.analyzePerms(t, tblPath, &p)
+		d.analyzeRules(t, tblPath, plg, &p)
+		d.analyzeNulls(t, tblPath, &p)
+		if intr, ok := plg.Plugin.(TableAnalyzer); ok {
+			intr.AnalyzeTable(t, &p)
+		}
+	}
+
+	if _, err = d.TablesCreateOrder(); err != nil {
+		p.AddError(dbPath, "the table references form a cycle")
+	}
+
+	return p
+}
+
+// analyzeIndexes checks the secondary indexes of one table.
+func (d *Database) analyzeIndexes(t *DbTable, tblPath string, idxNames map[string]string,
+	p *Problems) {
+
+	keys, _ := t.Keys()
+	for _, x := range t.IndexDefns() {
+		idxPath := fmt.Sprintf("%s.%s", tblPath, x.Name)
+		if nm, ok := idxNames[strings.ToLower(x.Name)]; ok {
+			p.AddError(idxPath, "duplicate index name (see %s)", nm)
+		} else {
+			idxNames[strings.ToLower(x.Name)] = idxPath
+		}
+		if len(x.Columns) == 0 {
+			p.AddError(idxPath, "index has no columns")
+			continue
+		}
+		cols := map[string]bool{}
+		for _, c := range x.Columns {
+			if t.FindField(c) == nil {
+				p.AddError(idxPath, "column, %s, is not defined", c)
+			}
+			if cols[c] {
+				p.AddError(idxPath, "column, %s, is used more than once", c)
+			}
+			cols[c] = true
+		}
+		if len(x.Where) == 0 && strings.Join(keys, ",") == strings.Join(x.Columns, ",") {
+			p.AddWarning(idxPath, "index is the same as the primary key")
+		}
+	}
+}
+
+// analyzeReferences checks the relationships from one table to others.
+func (d *Database) analyzeReferences(t *DbTable, tblPath string, plg dbPlugin.PluginData,
+	p *Problems) {
+
+	for _, r := range t.ForeignKeys() {
+		refPath := fmt.Sprintf("%s.%s", tblPath, r.Name)
+		if len(r.Fields) == 0 {
+			p.AddError(refPath, "reference has no fields")
+			continue
+		}
+		if !IsValidAction(r.OnDelete) {
+			p.AddError(refPath, "OnDelete, %s, is not one of %s", r.OnDelete,
+				strings.Join(ReferenceActions, ", "))
+		}
+		if !IsValidAction(r.OnUpdate) {
+			p.AddError(refPath, "OnUpdate, %s, is not one of %s", r.OnUpdate,
+				strings.Join(ReferenceActions, ", "))
+		}
+		parent := d.FindTable(r.Table)
+		if parent == nil {
+			p.AddError(refPath, "referenced table, %s, is not defined", r.Table)
+			continue
+		}
+		if len(r.Columns) != len(r.Fields) {
+			p.AddError(refPath, "has %d field(s) but references %d field(s) of %s",
+				len(r.Fields), len(r.Columns), r.Table)
+			continue
+		}
+
+		// The parent fields must be the parent's key or a unique field.
+		keys, _ := parent.Keys()
+		if strings.Join(keys, ",") != strings.Join(r.Columns, ",") {
+			if !parent.IsUnique(r.Columns) {
+				p.AddError(refPath, "referenced field(s), %s, must be the key of %s or unique",
+					strings.Join(r.Columns, ", "), r.Table)
+			}
+		}
+
+		for i, fn := range r.Fields {
+			f := t.FindField(fn)
+			if f == nil {
+				p.AddError(refPath, "field, %s, is not defined", fn)
+				continue
+			}
+			pf := parent.FindField(r.Columns[i])
+			if pf == nil {
+				p.AddError(refPath, "referenced field, %s.%s, is not defined", r.Table, r.Columns[i])
+				continue
+			}
+			if plg.Types != nil {
+				td := plg.Types.FindDefn(f.TypeDefn)
+				ptd := plg.Types.FindDefn(pf.TypeDefn)
+				if td != nil && ptd != nil && td.Sql != ptd.Sql {
+					p.AddError(refPath, "field, %s, is %s but %s.%s is %s", fn, f.TypeDefn,
+						r.Table, pf.Name, pf.TypeDefn)
+				}
+			}
+			if strings.ToLower(r.OnDelete) == "set null" || strings.ToLower(r.OnUpdate) == "set null" {
+				if !f.Nullable {
+					p.AddError(refPath, "field, %s, must be Null for set null", fn)
+				}
+			}
+		}
+	}
+}
+
+// analyzeTable checks the fields and keys of one table.
+func (d *Database) analyzeTable(t *DbTable, tblPath string, plg dbPlugin.PluginData,
+	reserved map[string]bool, p *Problems) {
+	var keyNums []int
+	var incrCnt int
+
+	if len(t.Fields) == 0 {
+		p.AddError(tblPath, "there are no fields defined")
+		return
+	}
+
+	fldNames := map[string]string{}
+	keys := map[int]string{}
+	for j := range t.Fields {
+		f := &t.Fields[j]
+		fldPath := fmt.Sprintf("%s.%s", tblPath, f.Name)
+		if f.Name == "" {
+			fldPath = fmt.Sprintf("%s.Fields[%d]", tblPath, j)
+			p.AddError(fldPath, "field name is missing")
+		} else {
+			if nm, ok := fldNames[strings.ToLower(f.Name)]; ok {
+				p.AddError(fldPath, "duplicate field name (see %s)", nm)
+			} else {
+				fldNames[strings.ToLower(f.Name)] = f.Name
+			}
+			if reserved[strings.ToUpper(f.Name)] {
+				p.AddError(fldPath, "field name is a reserved word in %s", d.SqlType)
+			}
+		}
+
+		// Keys
+		if f.KeyNum < 0 {
+			p.AddError(fldPath, "KeyNum, %d, must not be negative", f.KeyNum)
+		} else if f.KeyNum > 0 {
+			if nm, ok := keys[f.KeyNum]; ok {
+				p.AddError(fldPath, "KeyNum, %d, is also used by %s", f.KeyNum, nm)
+			} else {
+				keys[f.KeyNum] = f.Name
+				keyNums = append(keyNums, f.KeyNum)
+			}
+		}
+
+		// Lengths
+		if f.Len < 0 {
+			p.AddError(fldPath, "Len, %d, must not be negative", f.Len)
+		}
+		if f.Dec < 0 {
+			p.AddError(fldPath, "Dec, %d, must not be negative", f.Dec)
+		} else if f.Dec > f.Len {
+			p.AddError(fldPath, "Dec, %d, is larger than Len, %d", f.Dec, f.Len)
+		}
+
+		// Type
+		var isInteger bool
+		if f.TypeDefn == "" {
+			p.AddError(fldPath, "TypeDef is missing")
+		} else if plg.Types != nil {
+			td := plg.Types.FindDefn(f.TypeDefn)
+			if td == nil {
+				p.AddError(fldPath, "TypeDef, %s, is not defined for %s", f.TypeDefn, d.SqlType)
+			} else {
+				isInteger = td.IsInteger()
+			}
+		}
+
+		// Auto-Increment
+		if f.Incr {
+			incrCnt++
+			if f.KeyNum == 0 {
+				p.AddError(fldPath, "Incr is only allowed on a key field")
+			}
+			if plg.Types != nil && !isInteger && plg.Types.FindDefn(f.TypeDefn) != nil {
+				p.AddError(fldPath, "Incr is only allowed on an integer field, not %s", f.TypeDefn)
+			}
+		}
+	}
+
+	if incrCnt > 1 {
+		p.AddError(tblPath, "only one field may be Incr, found %d", incrCnt)
+	}
+
+	// Key numbers must be 1..n without any gaps.
+	sort.Ints(keyNums)
+	for i, k := range keyNums {
+		if k != i+1 {
+			p.AddError(tblPath, "KeyNum ordering has a gap, expected %d but found %d (%s)",
+				i+1, k, keys[k])
+			break
+		}
+	}
+}
+
+// AnalyzeJsonFile reads the given JSON file into a new Database and
+// analyzes it. Unlike ReadJsonFile(), the global Database is not touched
+// and ValidateData() is not run so that all of the problems are reported.
+// An error is only returned if the file could not be read.
+func AnalyzeJsonFile(fn string) (Problems, error) {
+	var err error
+	var db Database
+
+	jsonPath, _ := filepath.Abs(fn)
+	if err = util.ReadJsonFileToData(jsonPath, &db); err != nil {
+		return nil, fmt.Errorf("Error: unmarshalling: %s : %s\n", jsonPath, err)
+	}
+
+	return db.Analyze(), nil
+}
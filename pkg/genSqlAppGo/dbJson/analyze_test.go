@@ -0,0 +1,133 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the semantic analysis of the database definitions
+
+// Warning: The real plugins can not be used because it causes
+// circular imports. So, a test plugin is registered instead.
+
+package dbJson
+
+import (
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+	"genapp/pkg/genSqlAppGo/dbType"
+	"genapp/pkg/sharedData"
+	"log"
+	"strings"
+	"testing"
+)
+
+type testPlugin struct{}
+
+func (pd testPlugin) ReservedWords() []string {
+	return []string{"SELECT", "TABLE"}
+}
+
+func init() {
+	dbPlugin.Register("analyze", dbPlugin.PluginData{Name: "analyze",
+		Types: &dbType.DefaultTable, Plugin: testPlugin{}})
+}
+
+// hasProblem looks for a problem with the given path whose message
+// contains msg.
+func hasProblem(p Problems, path, msg string) bool {
+	for _, v := range p {
+		if v.Path == path && strings.Contains(v.Msg, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+//----------------------------------------------------------------------------
+//								TestAnalyze
+//----------------------------------------------------------------------------
+
+func TestAnalyze(t *testing.T) {
+	var p Problems
+	var db Database
+
+	log.Printf("dbJson::TestAnalyze()..\n")
+	sharedData.SetDebug(true)
+
+	db = Database{Name: "app", SqlType: "analyze",
+		Tables: []DbTable{
+			{Name: "customer",
+				Fields: []DbField{
+					{Name: "num", TypeDefn: "int", KeyNum: 1, Incr: true},
+					{Name: "name", TypeDefn: "text", Len: 30},
+					{Name: "curbal", TypeDefn: "money", Len: 15, Dec: 2},
+				},
+			},
+		},
+	}
+	p = db.Analyze()
+	if len(p) != 0 {
+		t.Fatalf("TestAnalyze() valid data had problems:\n%s\n", p.String())
+	}
+	if p.Err() != nil {
+		t.Fatalf("TestAnalyze() valid data returned an error\n")
+	}
+
+	db = Database{Name: "app", SqlType: "analyze",
+		Tables: []DbTable{
+			{Name: "customer",
+				Fields: []DbField{
+					{Name: "num", TypeDefn: "text", KeyNum: 1, Incr: true},
+					{Name: "Num", TypeDefn: "int"},
+					{Name: "seq", TypeDefn: "int", KeyNum: 3, Incr: true},
+					{Name: "curbal", TypeDefn: "money", Len: 2, Dec: 4},
+					{Name: "flag", TypeDefn: "bogus"},
+					{Name: "select", TypeDefn: "int"},
+					{Name: "cnt", TypeDefn: "int", Incr: true},
+				},
+			},
+			{Name: "Customer",
+				Fields: []DbField{
+					{Name: "id", TypeDefn: "int", KeyNum: 1},
+				},
+			},
+			{Name: "table",
+				Fields: []DbField{
+					{Name: "id", TypeDefn: "int", KeyNum: 1},
+				},
+			},
+		},
+	}
+	p = db.Analyze()
+	t.Logf("Problems:\n%s\n", p.String())
+	tests := []struct {
+		path string
+		msg  string
+	}{
+		{"app.customer.num", "integer"},
+		{"app.customer.Num", "duplicate field"},
+		{"app.customer", "gap"},
+		{"app.customer", "only one field may be Incr"},
+		{"app.customer.curbal", "larger than Len"},
+		{"app.customer.flag", "not defined"},
+		{"app.customer.select", "reserved"},
+		{"app.customer.cnt", "key field"},
+		{"app.Customer", "duplicate table"},
+		{"app.table", "reserved"},
+	}
+	for _, tst := range tests {
+		if !hasProblem(p, tst.path, tst.msg) {
+			t.Errorf("TestAnalyze() missing problem: %s: %s\n", tst.path, tst.msg)
+		}
+	}
+	if p.ErrorCount() != len(tests) {
+		t.Errorf("TestAnalyze() should have %d errors but has %d\n", len(tests), p.ErrorCount())
+	}
+	if p.Err() == nil {
+		t.Fatalf("TestAnalyze() invalid data did not return an error\n")
+	}
+
+	db = Database{}
+	p = db.Analyze()
+	if p.ErrorCount() != 3 {
+		t.Fatalf("TestAnalyze() empty database should have 3 errors but has %d\n", p.ErrorCount())
+	}
+
+	t.Log("...end of dbJson::TestAnalyze\n")
+}
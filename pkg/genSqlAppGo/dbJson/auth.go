@@ -0,0 +1,88 @@
+// See License.txt in main repository directory
+
+// auth contains the support for the authentication generated
+// into the application's HTTP server.
+
+// Notes:
+//	*	The users table is not part of the JSON definition. It is
+//		built here so that its SQL statements are generated by the
+//		plugins the same as the defined tables.
+//	*	The password is stored as a bcrypt hash which is always 60
+//		characters.
+//	*	The role of the user selects the operations permitted on
+//		the tables as given in their Perms.
+
+package dbJson
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+)
+
+// AuthModes are the valid authentication modes of the generated server.
+var AuthModes = []string{"none", "basic", "session", "apikey"}
+
+// AuthTableName is the name of the table holding the users.
+const AuthTableName = "authUsers"
+
+// IsAuthMode returns true if the given mode is one of AuthModes.
+func IsAuthMode(mode string) bool {
+	for _, m := range AuthModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthTable returns the table holding the users who may log into
+// the generated server.
+func (d *Database) AuthTable() *DbTable {
+	t := &DbTable{
+		Name: AuthTableName,
+		Fields: []DbField{
+			{Name: "name", TypeDefn: "text", Len: 50, KeyNum: 1},
+			{Name: "hash", TypeDefn: "text", Len: 60},
+			{Name: "role", TypeDefn: "text", Len: 20},
+		},
+		DB: d,
+	}
+	plg, ok := d.Plugin.(dbPlugin.PluginData)
+	for i := range t.Fields {
+		t.Fields[i].Tbl = t
+		if ok && plg.Types != nil {
+			t.Fields[i].Typ = plg.Types.FindDefn(t.Fields[i].TypeDefn)
+		}
+	}
+	return t
+}
+
+// ValidateAuth checks that the given authentication mode is valid and
+// that the users table does not conflict with a defined table. Without
+// authentication, any table permissions are not generated.
+func (d *Database) ValidateAuth(mode string) error {
+
+	if !IsAuthMode(mode) {
+		return fmt.Errorf("Error: Authentication mode, %s, must be one of %v!\n", mode, AuthModes)
+	}
+	if mode == "none" && !d.Context().Quiet() {
+		for i := range d.Tables {
+			if d.Tables[i].HasPerms() {
+				log.Printf("Warning: Table %s Perms are ignored without authentication!\n",
+					d.Tables[i].Name)
+			}
+		}
+	}
+	if mode != "basic" && mode != "session" {
+		return nil
+	}
+	for i := range d.Tables {
+		if strings.EqualFold(d.Tables[i].Name, AuthTableName) {
+			return fmt.Errorf("Error: Table %s conflicts with the users table!\n", d.Tables[i].Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the authentication support
+
+package dbJson
+
+import (
+	"log"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//								TestAuth
+//----------------------------------------------------------------------------
+
+func TestAuth(t *testing.T) {
+
+	log.Printf("dbJson::TestAuth()..\n")
+	db := newRefDatabase()
+
+	tb := db.AuthTable()
+	if tb.Name != AuthTableName || tb.DB != db {
+		t.Errorf("TestAuth() invalid users table: %+v\n", tb)
+	}
+	if keys, err := tb.Keys(); err != nil || len(keys) != 1 || keys[0] != "name" {
+		t.Errorf("TestAuth() invalid users table keys: %v %v\n", keys, err)
+	}
+	if f := tb.FindField("hash"); f == nil || f.Len != 60 || f.Tbl != tb {
+		t.Errorf("TestAuth() invalid hash field: %+v\n", f)
+	}
+	if f := tb.FindField("role"); f == nil || f.KeyNum != 0 {
+		t.Errorf("TestAuth() invalid role field: %+v\n", f)
+	}
+
+	for _, m := range AuthModes {
+		if err := db.ValidateAuth(m); err != nil {
+			t.Errorf("TestAuth() %s should be valid: %s\n", m, err)
+		}
+	}
+	if err := db.ValidateAuth("oauth"); err == nil {
+		t.Errorf("TestAuth() oauth should not be valid\n")
+	}
+	db.Tables[3].Name = "AuthUsers"
+	if err := db.ValidateAuth("session"); err == nil {
+		t.Errorf("TestAuth() AuthUsers should conflict with the users table\n")
+	}
+	if err := db.ValidateAuth("apikey"); err != nil {
+		t.Errorf("TestAuth() apikey does not use the users table: %s\n", err)
+	}
+
+	t.Log("...end of dbJson::TestAuth\n")
+}
@@ -46,6 +46,13 @@ type DbField struct {
 	Incr     bool             `json:"Incr,omitempty"`     // true == Auto Increment Field
 	SQLParms string           `json:"SQLParms,omitempty"` // Extra SQL Parameters
 	List     bool             `json:"List,omitempty"`     // Include in List Report
+	Ref      string           `json:"Ref,omitempty"`      // Referenced table or table.field
+	OldName  string           `json:"OldName,omitempty"`  // Prior Field Name (migrate only)
+	Required bool             `json:"Required,omitempty"` // Must be entered
+	Min      *float64         `json:"Min,omitempty"`      // Minimum numeric value
+	Max      *float64         `json:"Max,omitempty"`      // Maximum numeric value
+	Pattern  string           `json:"Pattern,omitempty"`  // Regular expression matching all of the text
+	Enum     []string         `json:"Enum,omitempty"`     // Values permitted for the text
 	Tbl      *DbTable         `json:"-"`                  // (ignored)  Filled in after JSON is parsed
 	Typ      *dbType.TypeDefn `json:"-"`                  // (ignored) Filled in after JSON is parsed
 }
@@ -59,20 +66,10 @@ func (f *DbField) CreateSql(cm string) string {
 
 	td := f.Typ
 	if td == nil {
-		log.Fatalln("Error - Could not find Type definition for field,",
-			f.Name, "type:", f.TypeDefn)
-	}
-	tdd := f.Typ.SqlType()
-
-	if f.Len > 0 {
-		if f.Dec > 0 {
-			ft = fmt.Sprintf("%s(%d,%d)", tdd, f.Len, f.Dec)
-		} else {
-			ft = fmt.Sprintf("%s(%d)", tdd, f.Len)
-		}
-	} else {
-		ft = tdd
+		panic(fmt.Sprintf("Error - Could not find Type definition for field, %s type: %s",
+			f.Name, f.TypeDefn))
 	}
+	ft = f.ColumnType()
 	nl = " NOT NULL"
 	if f.Nullable {
 		nl = ""
@@ -91,29 +88,61 @@ func (f *DbField) CreateSql(cm string) string {
 	return str.String()
 }
 
+// ColumnDefn returns the column definition of the field as used in
+// ALTER TABLE including the CHECK constraint of an enumerated field.
+func (f *DbField) ColumnDefn() string {
+	var str strings.Builder
+
+	fmt.Fprintf(&str, "%s %s", f.Name, f.ColumnType())
+	if !f.Nullable {
+		str.WriteString(" NOT NULL")
+	}
+	if len(f.SQLParms) > 0 {
+		fmt.Fprintf(&str, " %s", f.SQLParms)
+	}
+	if ck := f.EnumCheckSql(); len(ck) > 0 {
+		fmt.Fprintf(&str, " %s", ck)
+	}
+
+	return str.String()
+}
+
+// ColumnType returns the SQL type of the field including its length unless
+// the type is fixed size.
+func (f *DbField) ColumnType() string {
+
+	if ft := f.enumSqlType(); len(ft) > 0 {
+		return ft
+	}
+	if f.Len > 0 && !f.Typ.IsFixedSize() {
+		if f.Dec > 0 {
+			return fmt.Sprintf("%s(%d,%d)", f.Typ.SqlType(), f.Len, f.Dec)
+		}
+		return fmt.Sprintf("%s(%d)", f.Typ.SqlType(), f.Len)
+	}
+
+	return f.Typ.SqlType()
+}
+
 func (f *DbField) CreateStruct() string {
 	var str 	strings.Builder
 	var json	string
 
 	if len(f.JsonName) > 0 {
-		json = fmt.Sprintf("\t`json:\"%s,omitempty\"`", f.JsonName)
+		json = "\t" + f.JsonTag()
 	}
-	fmt.Fprintf(&str,"\t%s\t%s%s\n", strings.Title(f.Name), f.GoType(), json)
+	fmt.Fprintf(&str,"\t%s\t%s%s\n", strings.Title(f.Name), f.StructType(), json)
 
 	return str.String()
 }
 
+// FormInput returns the HTML input element of the field including the
+// HTML5 validation attributes of its rules.
 func (f *DbField) FormInput() string {
 	var str strings.Builder
-	var lbl string
 	var m string
 
 	tdd := f.Typ.Html
-	if len(f.Label) > 0 {
-		lbl = strings.Title(f.Label)
-	} else {
-		lbl = strings.Title(f.Name)
-	}
 	switch f.Typ.GoType() {
 	case "float64":
 		m = "m=\"0\" step=\"0.01\" "
@@ -121,12 +150,46 @@ func (f *DbField) FormInput() string {
 		m = ""
 	}
 
-	if f.Hidden {
-		fmt.Fprintf(&str,"\t<input type=\"hidden\" name=\"%s\" id=\"%s\" %svalue=\"{{.Rcd.%s}}\">\n",
-			f.TitledName(), f.TitledName(), m, f.TitledName())
-	} else {
-		fmt.Fprintf(&str,"\t<label>%s: <input type=\"%s\" name=\"%s\" id=\"%s\" %svalue=\"{{.Rcd.%s}}\"></label>\n",
-			lbl, tdd, f.TitledName(), f.TitledName(), m, f.TitledName())
+	// Time values must be given in the layout of the input element. NULL
+	// is shown as empty and decimals with their scale which ToString()
+	// gives.
+	val := fmt.Sprintf("{{.Rcd.%s}}", f.TitledName())
+	switch {
+	case f.GoType() == "time.Time" && f.IsNull() && f.nullPointers():
+		val = fmt.Sprintf("{{with .Rcd.%s}}{{.Format %q}}{{end}}", f.TitledName(), f.FormLayout())
+	case f.GoType() == "time.Time" && f.IsNull():
+		val = fmt.Sprintf("{{if .Rcd.%s.Valid}}{{.Rcd.%s.Time.Format %q}}{{end}}",
+			f.TitledName(), f.TitledName(), f.FormLayout())
+	case f.GoType() == "time.Time":
+		val = fmt.Sprintf("{{.Rcd.%s.Format %q}}", f.TitledName(), f.FormLayout())
+	case f.IsNull() || f.IsDec():
+		val = fmt.Sprintf("{{.Rcd.ToString %q}}", f.TitledName())
+	}
+
+	switch {
+	case f.Hidden:
+		fmt.Fprintf(&str,"<input type=\"hidden\" name=\"%s\" id=\"%s\" %svalue=\"%s\">",
+			f.TitledName(), f.TitledName(), m, val)
+	case f.IsBool() && f.IsNull():
+		// A checkbox can not be NULL.
+		fmt.Fprintf(&str,"<select name=\"%s\" id=\"%s\">{{$v := .Rcd.ToString %q}}"+
+			"<option value=\"\"></option>"+
+			"<option value=\"true\"{{if eq $v \"true\"}} selected{{end}}>true</option>"+
+			"<option value=\"false\"{{if eq $v \"false\"}} selected{{end}}>false</option></select>",
+			f.TitledName(), f.TitledName(), f.TitledName())
+	case f.IsBool():
+		// An unchecked checkbox is not sent at all.
+		fmt.Fprintf(&str,"<input type=\"checkbox\" name=\"%s\" id=\"%s\" value=\"true\"{{if .Rcd.%s}} checked{{end}}>",
+			f.TitledName(), f.TitledName(), f.TitledName())
+	case f.IsEnum():
+		str.WriteString(f.genEnumSelect(val))
+	case f.IsBlob():
+		// A file input can not be given a value.
+		fmt.Fprintf(&str,"<input type=\"file\" name=\"%s\" id=\"%s\"%s>",
+			f.TitledName(), f.TitledName(), f.FormAttrs())
+	default:
+		fmt.Fprintf(&str,"<input type=\"%s\" name=\"%s\" id=\"%s\" %svalue=\"%s\"%s>",
+			tdd, f.TitledName(), f.TitledName(), m, val, f.FormAttrs())
 	}
 
 	return str.String()
@@ -147,6 +210,14 @@ func (f *DbField) GenFromStringArray(dn, sn string, n int) string {
 // GenFromString generates the code to go from a string (sn) to
 // a field of (dn).  sn and dn are variable names.
 func (f *DbField) GenFromString(dn, sn string) string {
+	return f.genNullFrom(dn+"."+f.TitledName(), sn, func(fn string) string {
+		return f.genFromString(fn, sn)
+	})
+}
+
+// genFromString generates the code to go from a string (sn) to the
+// variable (fn) of the field's Go type.
+func (f *DbField) genFromString(fn, sn string) string {
 	var str string
 
 	switch f.Typ.GoType() {
@@ -156,21 +227,27 @@ func (f *DbField) GenFromString(dn, sn string) string {
 		fallthrough
 	case "int64":
 		{
-			wrk := "\t%s.%s, _ = strconv.ParseInt(%s,0,64)\n"
-			str = fmt.Sprintf(wrk, dn, f.TitledName(), sn)
+			wrk := "\t%s, _ = strconv.ParseInt(%s,0,64)\n"
+			str = fmt.Sprintf(wrk, fn, sn)
 		}
 	case "float64":
 		{
-			wrk := "\t\t%s.%s, _ = strconv.ParseFloat(%s, 64)\n"
-			str = fmt.Sprintf(wrk, dn, f.TitledName(), sn)
+			wrk := "\t\t%s, _ = strconv.ParseFloat(%s, 64)\n"
+			str = fmt.Sprintf(wrk, fn, sn)
 		}
 	case "time.Time":
 		{
-			wrk := "\t%s.%s, _ = time.Parse(time.RFC3339, %s)\n"
-			str = fmt.Sprintf(wrk, dn, f.TitledName(), sn)
+			wrk := "\t%s, _ = time.Parse(time.RFC3339, %s)\n"
+			str = fmt.Sprintf(wrk, fn, sn)
 		}
+	case "decimal.Decimal":
+		str = fmt.Sprintf("\t%s, _ = decimal.NewFromString(%s)\n", fn, sn)
+	case "bool":
+		str = fmt.Sprintf("\t%s, _ = strconv.ParseBool(%s)\n", fn, sn)
+	case "[]byte":
+		str = fmt.Sprintf("\t%s, _ = base64.StdEncoding.DecodeString(%s)\n", fn, sn)
 	default:
-		str = fmt.Sprintf("\t%s.%s = %s\n", dn, f.TitledName(), sn)
+		str = fmt.Sprintf("\t%s = %s\n", fn, sn)
 	}
 
 	return str
@@ -201,6 +278,15 @@ func (f *DbField) GenFromStringSlice(dn string, sn string, idx int) string {
 			wrk := "\t%s.%s, _ = time.Parse(time.RFC3339, %s[%d])\n"
 			str = fmt.Sprintf(wrk, dn, f.TitledName(), sn, idx-1)
 		}
+	case "decimal.Decimal":
+		wrk := "\t%s.%s, _ = decimal.NewFromString(%s[%d])\n"
+		str = fmt.Sprintf(wrk, dn, f.TitledName(), sn, idx-1)
+	case "bool":
+		wrk := "\t%s.%s, _ = strconv.ParseBool(%s[%d])\n"
+		str = fmt.Sprintf(wrk, dn, f.TitledName(), sn, idx-1)
+	case "[]byte":
+		wrk := "\t%s.%s, _ = base64.StdEncoding.DecodeString(%s[%d])\n"
+		str = fmt.Sprintf(wrk, dn, f.TitledName(), sn, idx-1)
 	default:
 		str = fmt.Sprintf("\t%s.%s = %s[%d]\n", dn, f.TitledName(), sn, idx-1)
 	}
@@ -210,7 +296,6 @@ func (f *DbField) GenFromStringSlice(dn string, sn string, idx int) string {
 
 /// GenToString generates code to convert the struct st.f field to string in variable, v.
 func (f *DbField) GenToString(v string, st string) string {
-	var str string
 	var fldName string
 
 	fldName = st + "." + f.TitledName()
@@ -218,6 +303,16 @@ func (f *DbField) GenToString(v string, st string) string {
 		fldName = f.TitledName()
 	}
 
+	return f.genNullTo(v, fldName, func(fldName string) string {
+		return f.genToString(v, fldName)
+	})
+}
+
+// genToString generates code to convert the value (fldName) of the
+// field's Go type to string in variable, v.
+func (f *DbField) genToString(v string, fldName string) string {
+	var str string
+
 	switch f.Typ.GoType() {
 	case "int":
 		fallthrough
@@ -233,11 +328,13 @@ func (f *DbField) GenToString(v string, st string) string {
 		str += fmt.Sprintf("\t\t%s = strings.TrimRight(strings.TrimRight(s, \"0\"), \".\")\n", v)
 		str += "\t}\n"
 	case "time.Time":
-		{
-			wrk := "\t{\n\t\twrk, _ := %s.MarshalText()\n" +
-				"\t\t%s = wrk\n\t}\n"
-			str = fmt.Sprintf(wrk, fldName, v)
-		}
+		str = fmt.Sprintf("\t%s = %s.Format(time.RFC3339Nano)\n", v, fldName)
+	case "decimal.Decimal":
+		str = f.genDecToString(v, fldName)
+	case "bool":
+		str = fmt.Sprintf("\t%s = strconv.FormatBool(%s)\n", v, fldName)
+	case "[]byte":
+		str = fmt.Sprintf("\t%s = base64.StdEncoding.EncodeToString(%s)\n", v, fldName)
 	default:
 		str = fmt.Sprintf("\t%s = %s\n", v, fldName)
 	}
@@ -245,16 +342,66 @@ func (f *DbField) GenToString(v string, st string) string {
 	return str
 }
 
+// GenTestValue generates the test data of the field from the integer
+// variable (i) for the types whose test data is not derived from the
+// shared test variables (ie the bools, blobs, JSON, UUIDs and text time
+// stamps). An empty string is returned for the other types.
+func (f *DbField) GenTestValue(i string) string {
+
+	switch {
+	case f.Typ == nil:
+		return ""
+	case f.IsBool():
+		return fmt.Sprintf("(%s%%2 == 1)", i)
+	case f.IsBlob():
+		return fmt.Sprintf("[]byte(fmt.Sprintf(\"blob %%d\", %s))", i)
+	case f.IsJson():
+		// This is the form that the servers give JSON back in.
+		return fmt.Sprintf("fmt.Sprintf(`{\"n\": %%d}`, %s)", i)
+	case f.IsUuid():
+		return fmt.Sprintf("fmt.Sprintf(\"00000000-0000-0000-0000-%%012d\", %s)", i)
+	case f.IsDec():
+		// i is kept within the scale so that it fits any precision.
+		return fmt.Sprintf("decimal.New(int64(%s), %d)", i, -f.Dec)
+	case f.IsDate() && f.IsText() && f.BaseType() == "timestamptz":
+		return "\"2001-01-01 00:00:00\""
+	}
+
+	return ""
+}
+
 func (f *DbField) GoType() string {
 	return f.Typ.GoType()
 }
 
+// IsBlob returns true if the field is kept as a slice of bytes.
+func (f *DbField) IsBlob() bool {
+	return f.Typ != nil && f.Typ.IsBinary()
+}
+
+// IsBool returns true if the field is a boolean.
+func (f *DbField) IsBool() bool {
+	return f.Typ != nil && f.Typ.IsBool()
+}
+
+// BaseType returns the name of the type that the field's type was
+// derived from (see Database.Types) or its type if it was not derived.
+func (f *DbField) BaseType() string {
+	if f.Typ != nil && len(f.Typ.Base) > 0 {
+		return f.Typ.Base
+	}
+	return f.TypeDefn
+}
+
 func (f *DbField) IsDate() bool {
 
-	if f.TypeDefn == "date" {
+	if f.BaseType() == "date" {
+		return true
+	}
+	if f.BaseType() == "datetime" {
 		return true
 	}
-	if f.TypeDefn == "datetime" {
+	if f.BaseType() == "timestamptz" {
 		return true
 	}
 
@@ -263,13 +410,13 @@ func (f *DbField) IsDate() bool {
 
 func (f *DbField) IsDec() bool {
 
-	if f.TypeDefn == "dec" {
+	if f.BaseType() == "dec" {
 		return true
 	}
-	if f.TypeDefn == "decimal" {
+	if f.BaseType() == "decimal" {
 		return true
 	}
-	if f.TypeDefn == "money" {
+	if f.BaseType() == "money" {
 		return true
 	}
 
@@ -302,6 +449,16 @@ func (f *DbField) IsInteger() bool {
 	return false
 }
 
+// IsJson returns true if the field holds a JSON document as text.
+func (f *DbField) IsJson() bool {
+	return f.BaseType() == "json"
+}
+
+// IsUuid returns true if the field holds a UUID as text.
+func (f *DbField) IsUuid() bool {
+	return f.BaseType() == "uuid"
+}
+
 func (f *DbField) IsText() bool {
 
 	tdd := f.Typ.GoType()
@@ -325,10 +482,14 @@ func (f *DbField) TitledName() string {
 // Fields should be in the order in which they are to
 // be displayed in the list form and the main form.
 type DbTable struct {
-	Name     string    `json:"Name,omitempty"`
-	Fields   []DbField `json:"Fields,omitempty"`
-	SQLParms []string  `json:"SQLParms,omitempty"` // Extra SQL Parameters
-	DB       *Database `json:"-"`
+	Name       string              `json:"Name,omitempty"`
+	Fields     []DbField           `json:"Fields,omitempty"`
+	SQLParms   []string            `json:"SQLParms,omitempty"`   // Extra SQL Parameters
+	References []DbReference       `json:"References,omitempty"` // Foreign Keys
+	Indexes    []DbIndex           `json:"Indexes,omitempty"`    // Secondary Indexes
+	Perms      map[string][]string `json:"Perms,omitempty"`      // Operations permitted by role
+	OldName    string              `json:"OldName,omitempty"`    // Prior Table Name (migrate only)
+	DB         *Database           `json:"-"`
 }
 
 func (t *DbTable) CreateStruct() string {
@@ -363,7 +524,7 @@ func (t *DbTable) DeleteSql() string {
 	var str strings.Builder
 
 	fmt.Fprintf(&str,"DROP TABLE IF EXISTS %s;\\n", t.Name)
-	if dbStruct.SqlType == "mssql" {
+	if t.DB != nil && t.DB.SqlType == "mssql" {
 		str.WriteString("GO\\n")
 	}
 	return str.String()
@@ -436,7 +597,7 @@ func (t *DbTable) HasDate() bool {
 }
 
 // HasDec returns true if any of the fields are a
-// decimal type which will need string conversion
+// decimal type which is kept as a decimal.Decimal
 func (t *DbTable) HasDec() bool {
 
 	for i, _ := range t.Fields {
@@ -459,6 +620,30 @@ func (t *DbTable) HasFloat() bool {
 	return false
 }
 
+// HasBlob returns true if any of the fields are a
+// blob which will need base64 conversion
+func (t *DbTable) HasBlob() bool {
+
+	for i := range t.Fields {
+		if t.Fields[i].IsBlob() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTime returns true if any of the fields are kept
+// as a time.Time.
+func (t *DbTable) HasTime() bool {
+
+	for i := range t.Fields {
+		if t.Fields[i].GoType() == "time.Time" {
+			return true
+		}
+	}
+	return false
+}
+
 // HasIncr returns true if any of the key fields are a
 // auto-increment field
 func (t *DbTable) HasIncr() bool {
@@ -471,6 +656,17 @@ func (t *DbTable) HasIncr() bool {
 	return false
 }
 
+// IncrField returns the auto-increment field or nil if there is none.
+func (t *DbTable) IncrField() *DbField {
+
+	for i := range t.Fields {
+		if t.Fields[i].Incr {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
 // HasInteger returns true if any of the fields are a
 // integers which will need float to string conversion
 func (t *DbTable) HasInteger() bool {
@@ -620,6 +816,49 @@ func (t *DbTable) TitledFieldNameList(prefix string) string {
 	return str.String()
 }
 
+// ScanNameList returns the struct fields separated by commas
+// with a per field prefix (ie "&rcd.") as the destinations of a
+// row scan. UUIDs of T-SQL are scanned through scanUuid() since
+// the driver returns them as bytes (see HasUuidScan()). NULL UUIDs
+// use scanNullUuid() or scanUuidPtr().
+func (t *DbTable) ScanNameList(prefix string) string {
+	var str strings.Builder
+
+	for i, f := range t.Fields {
+		if i > 0 {
+			str.WriteString(", ")
+		}
+		if t.scansUuid(&f) {
+			scan := "scanUuid"
+			if f.IsNull() && f.nullPointers() {
+				scan = "scanUuidPtr"
+			} else if f.IsNull() {
+				scan = "scanNullUuid"
+			}
+			fmt.Fprintf(&str, "%s(%s%s)", scan, prefix, f.TitledName())
+		} else {
+			fmt.Fprintf(&str, "%s%s", prefix, f.TitledName())
+		}
+	}
+	return str.String()
+}
+
+// HasUuidScan returns true if ScanNameList() uses scanUuid().
+func (t *DbTable) HasUuidScan() bool {
+
+	for i := range t.Fields {
+		if t.scansUuid(&t.Fields[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// scansUuid returns true if the field is a T-SQL UUID.
+func (t *DbTable) scansUuid(f *DbField) bool {
+	return f.IsUuid() && t.DB != nil && t.DB.SqlType == "mssql"
+}
+
 // InsertNameList returns struct fields separated by
 // commas with an optional per field prefix. If a field
 // is an auto-increment field then it is skipped.
@@ -642,6 +881,21 @@ func (t *DbTable) TitledInsertNameList(prefix string) string {
 	return str.String()
 }
 
+// TitledUpdateNameList returns struct fields separated by commas with an
+// optional per field prefix in the order of the place holders of the
+// UPDATE statement which is the fields being set followed by the keys.
+func (t *DbTable) TitledUpdateNameList(prefix string) string {
+	var strs []string
+
+	for _, f := range t.UpdateFields() {
+		strs = append(strs, prefix+f.TitledName())
+	}
+	if len(t.TitledKeysList(prefix, "")) > 0 {
+		strs = append(strs, t.TitledKeysList(prefix, ""))
+	}
+	return strings.Join(strs, ", ")
+}
+
 // TitledKeysList returns the table's keys in number order as
 // a comma separated list.
 func (t *DbTable) TitledKeysList(prefix, suffix string) string {
@@ -671,6 +925,26 @@ func (t *DbTable) TitledName() string {
 	return strings.Title(t.Name)
 }
 
+// UpdateFields returns the fields set by an UPDATE of a row which are
+// the fields that are not keys. If all of the fields are keys, then the
+// keys are returned so that the statement is still valid.
+func (t *DbTable) UpdateFields() []*DbField {
+	var flds []*DbField
+
+	for i := range t.Fields {
+		if t.Fields[i].KeyNum == 0 {
+			flds = append(flds, &t.Fields[i])
+		}
+	}
+	if len(flds) == 0 {
+		keys, _ := t.Keys()
+		for _, k := range keys {
+			flds = append(flds, t.FindField(k))
+		}
+	}
+	return flds
+}
+
 //============================================================================
 //                        	JSON Database Support
 //============================================================================
@@ -683,10 +957,23 @@ type Database struct {
 	Server   string    `json:"Server,omitempty"`
 	Port     string    `json:"Port,omitempty"`
 	PW       string    `json:"PW,omitempty"`
+	NullType string    `json:"NullType,omitempty"` // sql (default) or pointer (see null.go)
+	Types    []DbType  `json:"Types,omitempty"`    // Types added to the plugin's (see types.go)
 	Tables   []DbTable `json:"Tables,omitempty"`
 	// There can only be one Plugin per Database Definition.  Once we have decoded
 	// the JSON, we will establish which plugin works with this JSON data if any.
 	Plugin interface{} `json:"-"`
+	// Ctx holds the definitions of the generation using this Database. If it
+	// is nil, the sharedData.Default Context is used.
+	Ctx *sharedData.Context `json:"-"`
+}
+
+// Context returns the definitions of the generation using this Database.
+func (d *Database) Context() *sharedData.Context {
+	if d.Ctx == nil {
+		return sharedData.Default
+	}
+	return d.Ctx
 }
 
 func (d *Database) FindTable(name string) *DbTable {
@@ -713,6 +1000,15 @@ func (d *Database) HasDate() bool {
 	return false
 }
 
+func (d *Database) HasBlob() bool {
+	for _, t := range d.Tables {
+		if t.HasBlob() {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Database) HasDec() bool {
 	for _, t := range d.Tables {
 		if t.HasDec() {
@@ -731,6 +1027,15 @@ func (d *Database) HasFloat() bool {
 	return false
 }
 
+func (d *Database) HasTime() bool {
+	for _, t := range d.Tables {
+		if t.HasTime() {
+			return true
+		}
+	}
+	return false
+}
+
 // ReadJsonFile reads the input JSON file for app
 // and stores the generic JSON Table as well as the
 // decoded structs.
@@ -739,7 +1044,7 @@ func (d *Database) ReadJsonFile(fn string) error {
 	var jsonPath string
 
 	jsonPath, _ = filepath.Abs(fn)
-	if sharedData.Debug() {
+	if d.Context().Debug() {
 		log.Println("json path:", jsonPath)
 	}
 
@@ -751,18 +1056,18 @@ func (d *Database) ReadJsonFile(fn string) error {
 	// Fix up the tables with back pointers that we do not store externally.
 	for i, t := range d.Tables {
 		for ii, _ := range t.Fields {
-			t.Fields[ii].Tbl = &t
+			t.Fields[ii].Tbl = &d.Tables[i]
 		}
 		// Link each table back to the database.
-		d.Tables[i].DB = DbStruct()
+		d.Tables[i].DB = d
 	}
 
 	if err = d.ValidateData(); err != nil {
 		return err
 	}
 
-	if sharedData.Debug() {
-		log.Printf("\tdbStruct: %+v\n", dbStruct)
+	if d.Context().Debug() {
+		log.Printf("\tdbStruct: %+v\n", d)
 	}
 
 	return nil
@@ -776,7 +1081,7 @@ func (d *Database) SetupPlugin() error {
 	var plg dbPlugin.PluginData
 
 	// Indicate the plugin needed.
-	if sharedData.Debug() {
+	if d.Context().Debug() {
 		log.Printf("\t\tSqtype: %s\n", d.SqlType)
 	}
 
@@ -784,7 +1089,7 @@ func (d *Database) SetupPlugin() error {
 	if plg, err = dbPlugin.FindPlugin(d.SqlType); err != nil {
 		return fmt.Errorf("Error: Can't find plugin for %s!\n\n\n", d.SqlType)
 	}
-	if sharedData.Debug() {
+	if d.Context().Debug() {
 		log.Printf("\t\tPlugin Type: %T\n", plg)
 		log.Printf("\t\tPlugin: %+v\n", plg)
 		log.Printf("\t\tPlugin.Plugin: %+v\n", plg.Plugin)
@@ -795,8 +1100,11 @@ func (d *Database) SetupPlugin() error {
 		return fmt.Errorf("Error: Plugin missing types for %s!\n\n\n", d.SqlType)
 	}
 
-	// Save the plugin.
+	// Merge the Types into a copy of the plugin's types and save the plugin.
+	tds, dfts := d.mergeTypes(plg.Types)
+	plg.Types = tds
 	d.Plugin = plg
+	d.applyTypes(dfts)
 
 	if len(d.Schema) == 0 {
 		intr, ok = plg.Plugin.(dbPlugin.SchemaNamer)
@@ -840,7 +1148,7 @@ func (d *Database) ValidateData() error {
 		return fmt.Errorf("Error: SQL Type is missing!")
 	}
 	if len(d.Tables) == 0 {
-		return fmt.Errorf("There are no tables defined for %s!", dbStruct.Name)
+		return fmt.Errorf("There are no tables defined for %s!", d.Name)
 	}
 	for i, t := range d.Tables {
 		if t.Name == "" {
@@ -864,27 +1172,16 @@ func (d *Database) ValidateData() error {
 
 // ValidatePlugin checks the JSON built structures for errors with
 // respect to the plugin. This assumes that the data was previously
-// validated.
+// validated. All of the problems found are returned as one error.
 func (d *Database) ValidatePlugin() error {
 	var err error
-	var plg dbPlugin.PluginData
 
 	// Set up Plugin Support for this database type.
-	if plg, err = dbPlugin.FindPlugin(d.SqlType); err != nil {
+	if _, err = dbPlugin.FindPlugin(d.SqlType); err != nil {
 		return err
 	}
 
-	for _, t := range d.Tables {
-		for _, f := range t.Fields {
-			td := plg.Types.FindDefn(f.TypeDefn)
-			if td == nil {
-				fmt.Errorf("Error - Could not find Type definition for field: %s  type: %s\n",
-					f.Name, f.TypeDefn)
-			}
-		}
-	}
-
-	return nil
+	return d.Analyze().Err()
 }
 
 //----------------------------------------------------------------------------
@@ -897,6 +1194,9 @@ func NewDatabase() *Database {
 	return db
 }
 
+// dbStruct is the Database used by the package level functions. The
+// generators use their own Database so that more than one can be
+// generated at the same time.
 var dbStruct Database
 
 func DbStruct() *Database {
@@ -911,37 +1211,7 @@ func DefaultJsonFileName() string {
 // and stores the generic JSON Table as well as the
 // decoded structs.
 func ReadJsonFile(fn string) error {
-	var err error
-	var jsonPath string
-
-	jsonPath, _ = filepath.Abs(fn)
-	if sharedData.Debug() {
-		log.Println("json path:", jsonPath)
-	}
-
-	// Read in the json file structurally
-	if err = util.ReadJsonFileToData(jsonPath, &dbStruct); err != nil {
-		return fmt.Errorf("Error: unmarshalling: %s : %s", jsonPath, err)
-	}
-
-	// Fix up the tables with back pointers that we do not store externally.
-	for i, t := range dbStruct.Tables {
-		for ii, _ := range t.Fields {
-			t.Fields[ii].Tbl = &t
-		}
-		// Link each table back to the database.
-		dbStruct.Tables[i].DB = DbStruct()
-	}
-
-	if err = ValidateData(); err != nil {
-		return err
-	}
-
-	if sharedData.Debug() {
-		log.Printf("\tdbStruct: %+v\n", dbStruct)
-	}
-
-	return nil
+	return dbStruct.ReadJsonFile(fn)
 }
 
 func TableNames() []string {
@@ -959,33 +1229,5 @@ func TableNames() []string {
 // is ok, because this function can be used if the data is from a
 // different source.
 func ValidateData() error {
-	var err error
-
-	if dbStruct.Name == "" {
-		return fmt.Errorf("Error: Database Name is missing!")
-	}
-	if dbStruct.SqlType == "" {
-		return fmt.Errorf("Error: SQL Type is missing!")
-	}
-	if len(dbStruct.Tables) == 0 {
-		return fmt.Errorf("There are no tables defined for %s!", dbStruct.Name)
-	}
-	for i, t := range dbStruct.Tables {
-		if t.Name == "" {
-			return fmt.Errorf("%d Table Name is missing!", i)
-		}
-		if len(t.Fields) == 0 {
-			return fmt.Errorf("There are no fields defined for %s!", t.Name)
-		}
-		if _, err = t.Keys(); err != nil {
-			return err
-		}
-		for j, f := range t.Fields {
-			if f.Name == "" {
-				return fmt.Errorf("%d Field Name is missing from table %s!", j, t.Name)
-			}
-		}
-	}
-
-	return nil
+	return dbStruct.ValidateData()
 }
@@ -9,8 +9,10 @@
 package dbJson
 
 import (
+	"genapp/pkg/genSqlAppGo/dbType"
 	"genapp/pkg/sharedData"
 	"log"
+	"strings"
 	"testing"
 )
 
@@ -53,11 +55,11 @@ func TestReadJsonFile(t *testing.T) {
 		t.Fatalf("TestReadJsonFile() Validation failed: %s'\n", sharedData.MainPath())
 	}
 
-	if len(dbStruct.Tables) != 2 {
-		t.Fatalf("TestReadJsonFile() failed: len(Tables) should be 2 but is '%d'\n", len(dbStruct.Name))
+	if len(dbStruct.Tables) != 3 {
+		t.Fatalf("TestReadJsonFile() failed: len(Tables) should be 3 but is '%d'\n", len(dbStruct.Tables))
 	}
-	if len(dbStruct.Tables[0].Fields) != 8 {
-		t.Fatalf("TestReadJsonFile() failed: should be 8 Tables but is %d\n", len(dbStruct.Tables[0].Fields))
+	if len(dbStruct.Tables[0].Fields) != 9 {
+		t.Fatalf("TestReadJsonFile() failed: should be 9 Fields in Tables[0] but is %d\n", len(dbStruct.Tables[0].Fields))
 	}
 
 	keys, err = dbStruct.Tables[0].Keys()
@@ -76,3 +78,103 @@ func TestReadJsonFile(t *testing.T) {
 	t.Logf("dbJson::TestReadJsonFile: end of test\n")
 
 }
+
+//----------------------------------------------------------------------------
+//								TestFieldTypes
+//----------------------------------------------------------------------------
+
+func TestFieldTypes(t *testing.T) {
+
+	log.Printf("dbJson::TestFieldTypes()..\n")
+	db := &Database{Name: "app", SqlType: "mssql",
+		Tables: []DbTable{
+			{Name: "sample",
+				Fields: []DbField{
+					{Name: "id", TypeDefn: "int", KeyNum: 1},
+					{Name: "flag", TypeDefn: "bool"},
+					{Name: "ratio", TypeDefn: "float"},
+					{Name: "big", TypeDefn: "bigint"},
+					{Name: "ident", TypeDefn: "uuid"},
+					{Name: "stamp", TypeDefn: "timestamptz"},
+					{Name: "doc", TypeDefn: "json", Required: true},
+					{Name: "data", TypeDefn: "blob"},
+				},
+			},
+		},
+	}
+	tb := &db.Tables[0]
+	tb.DB = db
+	for i := range tb.Fields {
+		tb.Fields[i].Tbl = tb
+		tb.Fields[i].Typ = dbType.DefaultTable.FindDefn(tb.Fields[i].TypeDefn)
+		if tb.Fields[i].Typ == nil {
+			t.Fatalf("TestFieldTypes() %s is not in the default table\n", tb.Fields[i].TypeDefn)
+		}
+	}
+	if !tb.HasBlob() || !tb.HasTime() || !db.HasBlob() || !db.HasTime() {
+		t.Errorf("TestFieldTypes() invalid HasBlob() or HasTime()\n")
+	}
+
+	tests := []struct {
+		fld   string
+		to    string
+		from  string
+		input string
+		test  string
+	}{
+		{"flag", "strconv.FormatBool(s.Flag)", "strconv.ParseBool(str)", `type="checkbox"`, "(i%2 == 1)"},
+		{"ratio", "s.Ratio", "strconv.ParseFloat(str, 64)", `type="number"`, ""},
+		{"big", "s.Big", "strconv.ParseInt(str,0,64)", `type="number"`, ""},
+		{"ident", "s.Ident", "s.Ident = str", `type="text"`, "00000000-0000-0000-0000-"},
+		{"stamp", "s.Stamp.Format(time.RFC3339Nano)", "time.Parse(time.RFC3339, str)",
+			`value="{{.Rcd.Stamp.Format "2006-01-02T15:04"}}"`, ""},
+		{"doc", "s.Doc", "s.Doc = str", `type="text"`, `{"n": %d}`},
+		{"data", "base64.StdEncoding.EncodeToString(s.Data)", "base64.StdEncoding.DecodeString(str)",
+			`type="file"`, "blob %d"},
+	}
+	for _, tst := range tests {
+		f := tb.FindField(tst.fld)
+		if str := f.GenToString("str", "s"); !strings.Contains(str, tst.to) {
+			t.Errorf("TestFieldTypes() %s GenToString() should contain %q: %s\n", tst.fld, tst.to, str)
+		}
+		if str := f.GenFromString("s", "str"); !strings.Contains(str, tst.from) {
+			t.Errorf("TestFieldTypes() %s GenFromString() should contain %q: %s\n", tst.fld, tst.from, str)
+		}
+		if str := f.FormInput(); !strings.Contains(str, tst.input) {
+			t.Errorf("TestFieldTypes() %s FormInput() should contain %q: %s\n", tst.fld, tst.input, str)
+		}
+		if str := f.GenTestValue("i"); !strings.Contains(str, tst.test) || (len(tst.test) == 0) != (len(str) == 0) {
+			t.Errorf("TestFieldTypes() %s GenTestValue() should contain %q: %s\n", tst.fld, tst.test, str)
+		}
+	}
+
+	if str := tb.FindField("doc").GenValidate("s", "errs"); !strings.Contains(str, "json.Valid([]byte(s.Doc))") {
+		t.Errorf("TestFieldTypes() invalid JSON validation: %s\n", str)
+	}
+	if str := tb.FindField("data").GenFromFormFile("s", "r", "str", "errs"); !strings.Contains(str, `r.FormFile("Data")`) ||
+		!strings.Contains(str, "base64.StdEncoding.DecodeString(str)") {
+		t.Errorf("TestFieldTypes() invalid GenFromFormFile(): %s\n", str)
+	}
+	if str := tb.ScanNameList("&rcd."); !strings.Contains(str, "scanUuid(&rcd.Ident), &rcd.Stamp") || !tb.HasUuidScan() {
+		t.Errorf("TestFieldTypes() invalid ScanNameList(): %s\n", str)
+	}
+	db.SqlType = "postgres"
+	if tb.HasUuidScan() || tb.ScanNameList("&rcd.") != tb.TitledFieldNameList("&rcd.") {
+		t.Errorf("TestFieldTypes() only T-SQL should scan UUIDs\n")
+	}
+
+	// A length is only added to the SQL types which take one.
+	for _, fld := range []string{"flag", "ident", "stamp", "doc", "data"} {
+		f := tb.FindField(fld)
+		f.Len = 36
+		if str := f.ColumnType(); str != f.Typ.SqlType() {
+			t.Errorf("TestFieldTypes() %s ColumnType() should not have a length: %s\n", fld, str)
+		}
+	}
+	f := &DbField{Name: "name", TypeDefn: "text", Len: 30, Typ: dbType.DefaultTable.FindDefn("text")}
+	if str := f.ColumnType(); str != "VARCHAR(30)" {
+		t.Errorf("TestFieldTypes() invalid text ColumnType(): %s\n", str)
+	}
+
+	t.Log("dbJson::TestFieldTypes: end of test\n")
+}
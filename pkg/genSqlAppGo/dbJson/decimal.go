@@ -0,0 +1,85 @@
+// See License.txt in main repository directory
+
+// decimal contains the support for the exact decimal fields (dec, decimal
+// and money) which are kept as decimal.Decimal from
+// github.com/shopspring/decimal in the generated application.
+
+// Notes:
+//	*	decimal.Decimal implements sql.Scanner and driver.Valuer so the
+//		fields are read and written by the SQL drivers as given. NULL
+//		fields are kept as decimal.NullDecimal or *decimal.Decimal.
+//	*	SQLite has no decimal type so they are stored as TEXT which keeps
+//		them exact, but they should not be used for calculations or
+//		sorting in SQL.
+//	*	Len is the precision (total digits) and Dec the scale (digits
+//		after the decimal point). If Len is given, both are enforced by
+//		Validate() and the value is formatted with Dec digits.
+//	*	JSON gives them as strings so that no precision is lost.
+
+package dbJson
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// HasDecScale returns true if the precision and scale of the decimal
+// field are given and so are enforced.
+func (f *DbField) HasDecScale() bool {
+	return f.IsDec() && f.Len > 0
+}
+
+// GenDecValue generates the decimal.Decimal of a numeric string constant.
+func (f *DbField) GenDecValue(val string) string {
+	return fmt.Sprintf("decimal.RequireFromString(%s)", strconv.Quote(val))
+}
+
+// DecInvalidValues returns the decimal.Decimal values which break the
+// precision and scale of the field (one too many decimal places and one
+// too many digits before the decimal point) for the generated tests.
+func (f *DbField) DecInvalidValues() []string {
+	if !f.HasDecScale() {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("decimal.New(1, %d)", -f.Dec-1),
+		fmt.Sprintf("decimal.New(1, %d)", f.Len-f.Dec),
+	}
+}
+
+// genDecToString generates the code to convert the decimal (fn) to a
+// string in variable, v, using the field's scale if it has one.
+func (f *DbField) genDecToString(v, fn string) string {
+	if f.HasDecScale() {
+		return fmt.Sprintf("\t%s = %s.StringFixed(%d)\n", v, fn, f.Dec)
+	}
+	return fmt.Sprintf("\t%s = %s.String()\n", v, fn)
+}
+
+// decRules returns the conditions and messages of the rules of the
+// decimal value (v) given its Min, Max, precision and scale.
+func (f *DbField) decRules(v string) [][2]string {
+	var rules [][2]string
+
+	add := func(msg, format string, a ...interface{}) {
+		rules = append(rules, [2]string{fmt.Sprintf(format, a...), msg})
+	}
+	if f.HasDecScale() {
+		if f.Dec == 0 {
+			add("must be a whole number", "!%s.Equal(%s.Truncate(0))", v, v)
+		} else {
+			add(fmt.Sprintf("must have at most %d decimal places", f.Dec),
+				"!%s.Equal(%s.Truncate(%d))", v, v, f.Dec)
+		}
+		add(fmt.Sprintf("must have at most %d digits before the decimal point", f.Len-f.Dec),
+			"%s.Abs().Cmp(decimal.New(1, %d)) >= 0", v, f.Len-f.Dec)
+	}
+	if f.Min != nil {
+		add("must be at least "+f.MinValue(), "%s.LessThan(%s)", v, f.GenDecValue(f.MinValue()))
+	}
+	if f.Max != nil {
+		add("must be at most "+f.MaxValue(), "%s.GreaterThan(%s)", v, f.GenDecValue(f.MaxValue()))
+	}
+
+	return rules
+}
@@ -0,0 +1,111 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the support of the exact decimal fields
+
+package dbJson
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"genapp/pkg/genSqlAppGo/dbType"
+)
+
+//----------------------------------------------------------------------------
+//								TestDecimal
+//----------------------------------------------------------------------------
+
+func TestDecimal(t *testing.T) {
+	var min = 0.5
+
+	log.Printf("dbJson::TestDecimal()..\n")
+	db := newNullDatabase("")
+	tb := &db.Tables[0]
+	tb.Fields = append(tb.Fields,
+		DbField{Name: "amt", TypeDefn: "money", Len: 7, Dec: 2, Min: &min},
+		DbField{Name: "qty", TypeDefn: "dec", Len: 4},
+		DbField{Name: "rate", TypeDefn: "decimal"},
+		DbField{Name: "bal", TypeDefn: "money", Len: 9, Dec: 2, Nullable: true},
+	)
+	for i := range tb.Fields {
+		tb.Fields[i].Tbl = tb
+		tb.Fields[i].Typ = dbType.DefaultTable.FindDefn(tb.Fields[i].TypeDefn)
+	}
+
+	f := tb.FindField("amt")
+	if !tb.HasDec() || f.StructType() != "decimal.Decimal" || !f.HasDecScale() || f.IsText() {
+		t.Errorf("TestDecimal() invalid decimal type: %s\n", f.StructType())
+	}
+	if str := f.GenToString("str", "s"); str != "\tstr = s.Amt.StringFixed(2)\n" {
+		t.Errorf("TestDecimal() invalid GenToString(): %s\n", str)
+	}
+	if str := tb.FindField("rate").GenToString("str", "s"); str != "\tstr = s.Rate.String()\n" {
+		t.Errorf("TestDecimal() invalid unscaled GenToString(): %s\n", str)
+	}
+	if str := f.GenFromString("s", "str"); str != "\ts.Amt, _ = decimal.NewFromString(str)\n" {
+		t.Errorf("TestDecimal() invalid GenFromString(): %s\n", str)
+	}
+	if str := f.GenFromFormString("s", "str", "errs"); !strings.Contains(str, "s.Amt, err = decimal.NewFromString(str); err != nil") ||
+		!strings.Contains(str, `errs["Amt"] = "must be a number"`) {
+		t.Errorf("TestDecimal() invalid GenFromFormString(): %s\n", str)
+	}
+	if str := f.GenNotEqual("s", "r"); str != "!s.Amt.Equal(r.Amt)" {
+		t.Errorf("TestDecimal() invalid GenNotEqual(): %s\n", str)
+	}
+	if str := f.GenTestValue("i"); str != "decimal.New(int64(i), -2)" {
+		t.Errorf("TestDecimal() invalid GenTestValue(): %s\n", str)
+	}
+	if str := f.FormInput(); !strings.Contains(str, `value="{{.Rcd.ToString "Amt"}}" min="0.5" step="0.01">`) {
+		t.Errorf("TestDecimal() invalid FormInput(): %s\n", str)
+	}
+	if v := f.DecInvalidValues(); len(v) != 2 || v[0] != "decimal.New(1, -3)" || v[1] != "decimal.New(1, 5)" {
+		t.Errorf("TestDecimal() invalid DecInvalidValues(): %q\n", v)
+	}
+	if str := tb.FindField("rate").FormAttrs(); str != ` step="any"` {
+		t.Errorf("TestDecimal() an unscaled decimal should allow any step: %s\n", str)
+	}
+
+	validates := []struct {
+		fld  string
+		code []string
+	}{
+		{"amt", []string{`if !s.Amt.Equal(s.Amt.Truncate(2)) {`, `errs["Amt"] = "must have at most 2 decimal places"`,
+			`} else if s.Amt.Abs().Cmp(decimal.New(1, 5)) >= 0 {`,
+			`errs["Amt"] = "must have at most 5 digits before the decimal point"`,
+			`} else if s.Amt.LessThan(decimal.RequireFromString("0.5")) {`}},
+		{"qty", []string{`if !s.Qty.Equal(s.Qty.Truncate(0)) {`, `errs["Qty"] = "must be a whole number"`,
+			`} else if s.Qty.Abs().Cmp(decimal.New(1, 4)) >= 0 {`}},
+		{"rate", nil},
+		{"bal", []string{`if s.Bal.Valid && !s.Bal.Decimal.Equal(s.Bal.Decimal.Truncate(2)) {`}},
+	}
+	for _, tst := range validates {
+		str := tb.FindField(tst.fld).GenValidate("s", "errs")
+		if len(tst.code) == 0 && len(str) > 0 {
+			t.Errorf("TestDecimal() %s should not generate validation:\n%s\n", tst.fld, str)
+		}
+		for _, c := range tst.code {
+			if !strings.Contains(str, c) {
+				t.Errorf("TestDecimal() %s validation is missing %q:\n%s\n", tst.fld, c, str)
+			}
+		}
+	}
+
+	f = tb.FindField("bal")
+	if f.StructType() != "decimal.NullDecimal" || f.NullValue() != "decimal.NullDecimal{}" {
+		t.Errorf("TestDecimal() invalid NULL decimal: %s\n", f.StructType())
+	}
+	if str := f.GenSet("s", "d"); str != "s.Bal = decimal.NullDecimal{Decimal: d, Valid: true}\n" {
+		t.Errorf("TestDecimal() invalid GenSet(): %s\n", str)
+	}
+	if str := f.GenToString("str", "s"); !strings.Contains(str, "str = s.Bal.Decimal.StringFixed(2)") {
+		t.Errorf("TestDecimal() invalid NULL GenToString(): %s\n", str)
+	}
+	db.NullType = "pointer"
+	if f.StructType() != "*decimal.Decimal" {
+		t.Errorf("TestDecimal() invalid NULL decimal pointer: %s\n", f.StructType())
+	}
+
+	t.Log("dbJson::TestDecimal: end of test\n")
+}
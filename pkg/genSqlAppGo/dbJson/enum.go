@@ -0,0 +1,222 @@
+// See License.txt in main repository directory
+
+// enum contains the support for the enumerated fields, text fields whose
+// values are limited to those given in Enum.
+
+// Notes:
+//	*	An enumerated field is given such as:
+//			"Name":"status", "TypeDef":"text", "Len":10,
+//			"Enum":["open", "closed", "on hold"]
+//	*	The SQL Servers with a native type use it. MariaDB and MySQL use
+//		an ENUM column and Postgres a type of its own, <table>_<field>,
+//		which is created with the table. The others are given a CHECK
+//		constraint. Since only Required stops a text field from being
+//		empty, '' is one of the SQL values of a NOT NULL field which is
+//		not Required.
+//	*	The field is kept as a string in the struct so that NULL and the
+//		conversions work as for any other text field. The generated type,
+//		<Table><Field>, with its constants, String() and Parse...() may
+//		be used to work with the values.
+//	*	The constants are named from the values with anything other than
+//		letters and digits removed, so "on hold" becomes ...OnHold.
+//	*	The migrations rename, replace and drop the type or CHECK
+//		constraint along with the field (see dbGener.GenMigrationStmts()).
+//	*	The values must be distinct since each is a constant and MariaDB
+//		and MySQL do not allow a value to be repeated in an ENUM.
+
+package dbJson
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// IsEnum returns true if the field's values are limited to Enum.
+func (f *DbField) IsEnum() bool {
+	return len(f.Enum) > 0
+}
+
+// HasEnum returns true if any of the fields of the table are enumerated.
+func (t *DbTable) HasEnum() bool {
+	for i := range t.Fields {
+		if t.Fields[i].IsEnum() {
+			return true
+		}
+	}
+	return false
+}
+
+// EnumSqlList returns the Enum values as a list of SQL strings. An empty
+// value is added if the field is NOT NULL, but not Required.
+func (f *DbField) EnumSqlList() string {
+	var vals []string
+
+	if !f.Required && !f.Nullable {
+		vals = append(vals, "''")
+	}
+	for _, v := range f.Enum {
+		vals = append(vals, "'"+strings.Replace(v, "'", "''", -1)+"'")
+	}
+	return strings.Join(vals, ", ")
+}
+
+// EnumTypeName returns the name of the SQL type of the field for the
+// SQL Servers which define one.
+func (f *DbField) EnumTypeName() string {
+	return strings.ToLower(f.Tbl.Name + "_" + f.Name)
+}
+
+// enumSqlType returns the SQL type of an enumerated field if the field's
+// SQL Server has a native one, otherwise "".
+func (f *DbField) enumSqlType() string {
+
+	if !f.IsEnum() || f.Tbl == nil || f.Tbl.DB == nil {
+		return ""
+	}
+	switch f.Tbl.DB.SqlType {
+	case "mariadb", "mysql":
+		return fmt.Sprintf("ENUM(%s)", f.EnumSqlList())
+	case "postgres":
+		return f.Tbl.DB.Schema + f.EnumTypeName()
+	}
+
+	return ""
+}
+
+// EnumCheckName returns the name of the CHECK constraint of the field.
+func (f *DbField) EnumCheckName() string {
+	return fmt.Sprintf("CK_%s_%s", f.Tbl.Name, f.Name)
+}
+
+// EnumCheckSql returns the CHECK constraint limiting the field to its Enum
+// values or "" if the field's SQL Server has a native type for it.
+func (f *DbField) EnumCheckSql() string {
+	if !f.IsEnum() || len(f.enumSqlType()) > 0 {
+		return ""
+	}
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s IN (%s))", f.EnumCheckName(), f.Name,
+		f.EnumSqlList())
+}
+
+// EnumCreateSql returns the statement creating the SQL type of the field
+// if it is not already defined or "" if its SQL Server does not use one.
+func (f *DbField) EnumCreateSql() string {
+	if !f.IsEnum() || f.Tbl.DB.SqlType != "postgres" {
+		return ""
+	}
+	return fmt.Sprintf("DO $$ BEGIN CREATE TYPE %s AS ENUM (%s); "+
+		"EXCEPTION WHEN duplicate_object THEN NULL; END $$", f.enumSqlType(), f.EnumSqlList())
+}
+
+// EnumDropSql returns the statement dropping the SQL type of the field or
+// "" if its SQL Server does not use one.
+func (f *DbField) EnumDropSql() string {
+	if !f.IsEnum() || f.Tbl.DB.SqlType != "postgres" {
+		return ""
+	}
+	return fmt.Sprintf("DROP TYPE IF EXISTS %s", f.enumSqlType())
+}
+
+// genEnumSelect generates the select element of the field in a form
+// given the template action of its current value (val). The empty option
+// is only given if the field may be left empty.
+func (f *DbField) genEnumSelect(val string) string {
+	var str strings.Builder
+	var req string
+
+	if f.Required {
+		req = " required"
+	}
+	fmt.Fprintf(&str, "<select name=\"%s\" id=\"%s\"%s>{{$v := %s}}",
+		f.TitledName(), f.TitledName(), req, strings.TrimSuffix(strings.TrimPrefix(val, "{{"), "}}"))
+	if !f.Required {
+		str.WriteString("<option value=\"\"></option>")
+	}
+	for _, e := range f.Enum {
+		h := html.EscapeString(e)
+		fmt.Fprintf(&str, "<option value=\"%s\"{{if eq $v %s}} selected{{end}}>%s</option>",
+			h, strconv.Quote(e), h)
+	}
+	str.WriteString("</select>")
+
+	return str.String()
+}
+
+// EnumConstName returns the Go name of the constant for the i'th Enum
+// value given the name of the type (tn).
+func (f *DbField) EnumConstName(tn string, i int) string {
+	var str strings.Builder
+
+	upper := true
+	for _, r := range f.Enum[i] {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			str.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	if str.Len() == 0 {
+		fmt.Fprintf(&str, "Value%d", i+1)
+	}
+
+	return tn + str.String()
+}
+
+// GenEnumType generates the Go type (tn) of the field with a constant for
+// each of its values, a slice of them and the String() and Parse methods.
+func (f *DbField) GenEnumType(tn string) string {
+	var str strings.Builder
+
+	if !f.IsEnum() {
+		return ""
+	}
+	fmt.Fprintf(&str, "// %s is a value of the %s field.\n", tn, f.TitledName())
+	fmt.Fprintf(&str, "type %s string\n\n", tn)
+	str.WriteString("const (\n")
+	for i, v := range f.Enum {
+		fmt.Fprintf(&str, "\t%s %s = %s\n", f.EnumConstName(tn, i), tn, strconv.Quote(v))
+	}
+	str.WriteString(")\n\n")
+	fmt.Fprintf(&str, "// %sValues are the values of the %s field in order.\n", tn, f.TitledName())
+	fmt.Fprintf(&str, "var %sValues = []%s{", tn, tn)
+	for i := range f.Enum {
+		if i > 0 {
+			str.WriteString(", ")
+		}
+		str.WriteString(f.EnumConstName(tn, i))
+	}
+	str.WriteString("}\n\n")
+	fmt.Fprintf(&str, "// String returns the value as a string.\n")
+	fmt.Fprintf(&str, "func (v %s) String() string {\n\treturn string(v)\n}\n\n", tn)
+	fmt.Fprintf(&str, "// Parse%s returns the %s of the string or an error if it is not one\n", tn, tn)
+	fmt.Fprintf(&str, "// of the values.\n")
+	fmt.Fprintf(&str, "func Parse%s(str string) (%s, error) {\n", tn, tn)
+	fmt.Fprintf(&str, "\tfor _, v := range %sValues {\n", tn)
+	str.WriteString("\t\tif string(v) == str {\n\t\t\treturn v, nil\n\t\t}\n\t}\n")
+	msg := "Error: %q is not one of " + strings.Replace(strings.Join(f.Enum, ", "), "%", "%%", -1) + "!\n"
+	fmt.Fprintf(&str, "\treturn \"\", fmt.Errorf(%s, str)\n}\n", strconv.Quote(msg))
+
+	return str.String()
+}
+
+// analyzeEnum checks that the distinct Enum values of the field have
+// distinct Go constant names. A repeated value is reported by the rules.
+func (f *DbField) analyzeEnum(fldPath string, p *Problems) {
+	names := map[string]string{}
+
+	for i, v := range f.Enum {
+		n := f.EnumConstName("", i)
+		if w, ok := names[n]; ok && w != v {
+			p.AddError(fldPath, "Enum values, %s and %s, have the same Go name, %s", w, v, n)
+		}
+		names[n] = v
+	}
+}
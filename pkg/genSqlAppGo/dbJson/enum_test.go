@@ -0,0 +1,152 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the support of the enumerated fields
+
+package dbJson
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"genapp/pkg/genSqlAppGo/dbType"
+)
+
+//----------------------------------------------------------------------------
+//								TestEnum
+//----------------------------------------------------------------------------
+
+func TestEnum(t *testing.T) {
+
+	log.Printf("dbJson::TestEnum()..\n")
+	db := newNullDatabase("")
+	tb := &db.Tables[0]
+	tb.Fields = append(tb.Fields,
+		DbField{Name: "status", TypeDefn: "text", Len: 10, Enum: []string{"open", "on hold", "it's"}},
+		DbField{Name: "kind", TypeDefn: "text", Len: 1, Required: true, Enum: []string{"a", "b"}},
+		DbField{Name: "grade", TypeDefn: "text", Len: 1, Nullable: true, Enum: []string{"x"}},
+	)
+	for i := range tb.Fields {
+		tb.Fields[i].Tbl = tb
+		tb.Fields[i].Typ = dbType.DefaultTable.FindDefn(tb.Fields[i].TypeDefn)
+	}
+
+	f := tb.FindField("status")
+	if !tb.HasEnum() || !f.IsEnum() || tb.FindField("name").IsEnum() {
+		t.Errorf("TestEnum() invalid IsEnum()\n")
+	}
+	if str := f.EnumSqlList(); str != "'', 'open', 'on hold', 'it''s'" {
+		t.Errorf("TestEnum() invalid EnumSqlList(): %s\n", str)
+	}
+	if str := tb.FindField("kind").EnumSqlList(); str != "'a', 'b'" {
+		t.Errorf("TestEnum() a Required field should not allow '': %s\n", str)
+	}
+	if str := tb.FindField("grade").EnumSqlList(); str != "'x'" {
+		t.Errorf("TestEnum() a NULL field should not allow '': %s\n", str)
+	}
+	if n := f.EnumConstName("SampleStatus", 1); n != "SampleStatusOnHold" {
+		t.Errorf("TestEnum() invalid EnumConstName(): %s\n", n)
+	}
+	if n := f.EnumConstName("SampleStatus", 2); n != "SampleStatusItS" {
+		t.Errorf("TestEnum() invalid EnumConstName(): %s\n", n)
+	}
+
+	// The SQL depends on the SQL Server.
+	sqls := []struct {
+		sqlType string
+		typ     string
+		check   string
+		create  string
+	}{
+		{"sqlite", "VARCHAR(10)", "CONSTRAINT CK_sample_status CHECK (status IN ('', 'open', 'on hold', 'it''s'))", ""},
+		{"mysql", "ENUM('', 'open', 'on hold', 'it''s')", "", ""},
+		{"mariadb", "ENUM('', 'open', 'on hold', 'it''s')", "", ""},
+		{"postgres", "sample_status", "",
+			"DO $$ BEGIN CREATE TYPE sample_status AS ENUM ('', 'open', 'on hold', 'it''s'); " +
+				"EXCEPTION WHEN duplicate_object THEN NULL; END $$"},
+	}
+	for _, tst := range sqls {
+		db.SqlType = tst.sqlType
+		if str := f.ColumnType(); str != tst.typ {
+			t.Errorf("TestEnum() invalid %s ColumnType(): %s\n", tst.sqlType, str)
+		}
+		if str := f.EnumCheckSql(); str != tst.check {
+			t.Errorf("TestEnum() invalid %s EnumCheckSql(): %s\n", tst.sqlType, str)
+		}
+		if str := f.EnumCreateSql(); str != tst.create {
+			t.Errorf("TestEnum() invalid %s EnumCreateSql(): %s\n", tst.sqlType, str)
+		}
+	}
+	if str := f.EnumDropSql(); str != "DROP TYPE IF EXISTS sample_status" {
+		t.Errorf("TestEnum() invalid EnumDropSql(): %s\n", str)
+	}
+	db.SqlType = "analyze"
+
+	str := f.GenEnumType("AppSampleStatus")
+	for _, c := range []string{"type AppSampleStatus string\n",
+		"\tAppSampleStatusOnHold AppSampleStatus = \"on hold\"\n",
+		"var AppSampleStatusValues = []AppSampleStatus{AppSampleStatusOpen, AppSampleStatusOnHold, AppSampleStatusItS}\n",
+		"func (v AppSampleStatus) String() string {\n",
+		"func ParseAppSampleStatus(str string) (AppSampleStatus, error) {\n",
+		`return "", fmt.Errorf("Error: %q is not one of open, on hold, it's!\n", str)`} {
+		if !strings.Contains(str, c) {
+			t.Errorf("TestEnum() GenEnumType() is missing %q:\n%s\n", c, str)
+		}
+	}
+	if str := tb.FindField("name").GenEnumType("AppSampleName"); len(str) > 0 {
+		t.Errorf("TestEnum() GenEnumType() of a text field should be empty: %s\n", str)
+	}
+
+	str = f.FormInput()
+	for _, c := range []string{`<select name="Status" id="Status">{{$v := .Rcd.Status}}<option value=""></option>`,
+		`<option value="it&#39;s"{{if eq $v "it's"}} selected{{end}}>it&#39;s</option></select>`} {
+		if !strings.Contains(str, c) {
+			t.Errorf("TestEnum() FormInput() is missing %q:\n%s\n", c, str)
+		}
+	}
+	str = tb.FindField("kind").FormInput()
+	if !strings.HasPrefix(str, `<select name="Kind" id="Kind" required>{{$v := .Rcd.Kind}}<option value="a"`) {
+		t.Errorf("TestEnum() invalid Required FormInput(): %s\n", str)
+	}
+	str = tb.FindField("grade").FormInput()
+	if !strings.Contains(str, `{{$v := .Rcd.ToString "Grade"}}<option value=""></option>`) {
+		t.Errorf("TestEnum() invalid NULL FormInput(): %s\n", str)
+	}
+
+	t.Log("dbJson::TestEnum: end of test\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestAnalyzeEnum
+//----------------------------------------------------------------------------
+
+func TestAnalyzeEnum(t *testing.T) {
+
+	log.Printf("dbJson::TestAnalyzeEnum()..\n")
+	db := newNullDatabase("")
+	tb := &db.Tables[0]
+	tb.Fields = append(tb.Fields,
+		DbField{Name: "status", TypeDefn: "text", Len: 10, Enum: []string{"on hold", "on-hold", "open"}},
+	)
+	p := db.Analyze()
+	msg := "Enum values, on hold and on-hold, have the same Go name, OnHold"
+	if !strings.Contains(p.String(), msg) {
+		t.Errorf("TestAnalyzeEnum() should report %q:\n%s\n", msg, p.String())
+	}
+
+	tb.FindField("status").Enum = []string{"on hold", "open"}
+	p = db.Analyze()
+	if strings.Contains(p.String(), "the same Go name") {
+		t.Errorf("TestAnalyzeEnum() should not report distinct names:\n%s\n", p.String())
+	}
+
+	tb.FindField("status").Enum = []string{"open", "on hold", "open"}
+	p = db.Analyze()
+	msg = "Error: app.sample.status: Enum value, open, is duplicated"
+	if !strings.Contains(p.String(), msg) || p.Err() == nil {
+		t.Errorf("TestAnalyzeEnum() should report %q as an error:\n%s\n", msg, p.String())
+	}
+
+	t.Log("dbJson::TestAnalyzeEnum: end of test\n")
+}
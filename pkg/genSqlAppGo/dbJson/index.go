@@ -0,0 +1,125 @@
+// See License.txt in main repository directory
+
+// index contains the support for the secondary indexes and unique
+// constraints of a table.
+
+// Notes:
+//	*	A unique index without a Where clause is generated as a UNIQUE
+//		table constraint within the CREATE TABLE statement. All other
+//		indexes are generated as separate CREATE INDEX statements which
+//		are issued after the table is created.
+//	*	Where creates a partial (filtered) index. It is copied to the
+//		SQL as is and is not supported by all SQL Servers.
+//	*	Unique on a field is generated as UNIQUE on the field's column
+//		definition.
+
+package dbJson
+
+import (
+	"fmt"
+	"strings"
+)
+
+//============================================================================
+//                        JSON Database Index Support
+//============================================================================
+
+// DbIndex defines a secondary index or unique constraint on one or more
+// fields of a table.
+type DbIndex struct {
+	Name    string   `json:"Name,omitempty"`    // Index Name (optional)
+	Columns []string `json:"Columns,omitempty"` // Field Names in index order
+	Unique  bool     `json:"Unique,omitempty"`  // true == the fields must be unique
+	Where   string   `json:"Where,omitempty"`   // Partial Index Condition (optional)
+}
+
+// ConstraintSql returns the table constraint for a unique index.
+func (x *DbIndex) ConstraintSql() string {
+	return fmt.Sprintf("CONSTRAINT %s UNIQUE(%s)", x.Name, strings.Join(x.Columns, ", "))
+}
+
+// CreateSql returns the CREATE INDEX statement for the index given the
+// fully qualified table name.
+func (x *DbIndex) CreateSql(table string) string {
+	var str strings.Builder
+
+	str.WriteString("CREATE ")
+	if x.Unique {
+		str.WriteString("UNIQUE ")
+	}
+	fmt.Fprintf(&str, "INDEX %s ON %s(%s)", x.Name, table, strings.Join(x.Columns, ", "))
+	if len(x.Where) > 0 {
+		fmt.Fprintf(&str, " WHERE %s", x.Where)
+	}
+
+	return str.String()
+}
+
+// IsConstraint returns true if the index is generated as a table
+// constraint rather than as a CREATE INDEX statement.
+func (x *DbIndex) IsConstraint() bool {
+	return x.Unique && len(x.Where) == 0
+}
+
+//----------------------------------------------------------------------------
+//								Table Support
+//----------------------------------------------------------------------------
+
+// IndexDefns returns the indexes of the table with the missing names
+// generated.
+func (t *DbTable) IndexDefns() []DbIndex {
+	var idxs []DbIndex
+
+	for i, x := range t.Indexes {
+		if len(x.Name) == 0 {
+			if x.IsConstraint() {
+				x.Name = fmt.Sprintf("UQ_%s_%d", t.Name, i+1)
+			} else {
+				x.Name = fmt.Sprintf("IX_%s_%d", t.Name, i+1)
+			}
+		}
+		idxs = append(idxs, x)
+	}
+
+	return idxs
+}
+
+// HasIndexStmts returns true if the table has any indexes which must be
+// created after the table.
+func (t *DbTable) HasIndexStmts() bool {
+	for i := range t.Indexes {
+		if !t.Indexes[i].IsConstraint() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUnique returns true if the given fields are declared unique either
+// by Unique on a single field or by a unique index with the same fields
+// in any order that is not partial.
+func (t *DbTable) IsUnique(names []string) bool {
+	if len(names) == 1 {
+		if f := t.FindField(names[0]); f != nil && f.Unique {
+			return true
+		}
+	}
+	for _, x := range t.Indexes {
+		if !x.IsConstraint() || len(x.Columns) != len(names) {
+			continue
+		}
+		found := 0
+		for _, c := range x.Columns {
+			for _, n := range names {
+				if c == n {
+					found++
+					break
+				}
+			}
+		}
+		if found == len(names) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the secondary indexes of a table
+
+package dbJson
+
+import (
+	"genapp/pkg/sharedData"
+	"log"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//								TestIndexDefns
+//----------------------------------------------------------------------------
+
+func TestIndexDefns(t *testing.T) {
+	var idxs []DbIndex
+
+	log.Printf("dbJson::TestIndexDefns()..\n")
+	sharedData.SetDebug(true)
+	db := newRefDatabase()
+	tb := db.FindTable("customer")
+	tb.Indexes = []DbIndex{
+		{Columns: []string{"name"}},
+		{Columns: []string{"name", "num"}, Unique: true},
+		{Name: "ix_part", Columns: []string{"name"}, Unique: true, Where: "num > 0"},
+	}
+
+	idxs = tb.IndexDefns()
+	if idxs[0].Name != "IX_customer_1" || idxs[1].Name != "UQ_customer_2" || idxs[2].Name != "ix_part" {
+		t.Fatalf("TestIndexDefns() invalid names: %+v\n", idxs)
+	}
+	if idxs[0].IsConstraint() || !idxs[1].IsConstraint() || idxs[2].IsConstraint() {
+		t.Fatalf("TestIndexDefns() invalid constraints\n")
+	}
+	if !tb.HasIndexStmts() {
+		t.Fatalf("TestIndexDefns() should have index statements\n")
+	}
+	if str := idxs[1].ConstraintSql(); str != "CONSTRAINT UQ_customer_2 UNIQUE(name, num)" {
+		t.Fatalf("TestIndexDefns() invalid constraint: %s\n", str)
+	}
+	if str := idxs[2].CreateSql("customer"); str != "CREATE UNIQUE INDEX ix_part ON customer(name) WHERE num > 0" {
+		t.Fatalf("TestIndexDefns() invalid index: %s\n", str)
+	}
+
+	if !tb.IsUnique([]string{"num", "name"}) {
+		t.Fatalf("TestIndexDefns() num, name should be unique\n")
+	}
+	if tb.IsUnique([]string{"name"}) {
+		t.Fatalf("TestIndexDefns() name should not be unique\n")
+	}
+
+	t.Log("...end of dbJson::TestIndexDefns\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestAnalyzeIndexes
+//----------------------------------------------------------------------------
+
+func TestAnalyzeIndexes(t *testing.T) {
+
+	log.Printf("dbJson::TestAnalyzeIndexes()..\n")
+	sharedData.SetDebug(true)
+	db := newRefDatabase()
+
+	db.FindTable("customer").Indexes = []DbIndex{
+		{Name: "ix_name", Columns: []string{"name"}},
+		{Columns: []string{}},
+		{Columns: []string{"name", "bogus", "name"}},
+		{Columns: []string{"num"}, Unique: true},
+	}
+	db.FindTable("item").Indexes = []DbIndex{
+		{Name: "IX_NAME", Columns: []string{"code"}, Where: "code > 'a'"},
+	}
+	p := db.Analyze()
+	t.Logf("Problems:\n%s\n", p.String())
+	tests := []struct {
+		path string
+		msg  string
+	}{
+		{"app.customer.IX_customer_2", "no columns"},
+		{"app.customer.IX_customer_3", "bogus, is not defined"},
+		{"app.customer.IX_customer_3", "more than once"},
+		{"app.item.IX_NAME", "duplicate index"},
+	}
+	for _, tst := range tests {
+		if !hasProblem(p, tst.path, tst.msg) {
+			t.Errorf("TestAnalyzeIndexes() missing problem: %s: %s\n", tst.path, tst.msg)
+		}
+	}
+	if p.ErrorCount() != len(tests) {
+		t.Errorf("TestAnalyzeIndexes() should have %d errors but has %d\n", len(tests), p.ErrorCount())
+	}
+	if !hasProblem(p, "app.customer.UQ_customer_4", "same as the primary key") {
+		t.Errorf("TestAnalyzeIndexes() missing primary key warning\n")
+	}
+
+	t.Log("...end of dbJson::TestAnalyzeIndexes\n")
+}
@@ -0,0 +1,115 @@
+// See License.txt in main repository directory
+
+// introspect builds the database definitions by reading an existing
+// database so that the JSON does not have to be written by hand for a
+// legacy database.
+
+// Notes:
+//	*	Introspection is done by the plugin for the SQL Server since it
+//		requires the server's catalog. Plugins support it through the
+//		Introspecter interface.
+//	*	Anything which can not be represented in the definitions is
+//		reported as a warning and left out.
+
+package dbJson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+	"genapp/pkg/genSqlAppGo/dbType"
+	"io/ioutil"
+	"strings"
+)
+
+//============================================================================
+//								Interfaces
+//============================================================================
+
+// Introspecter is an optional plugin interface which allows the plugin
+// to read the definitions of the tables from an existing database given
+// its data source name (ie file path or connection string). The tables
+// are added to db and any problems are added to p.
+type Introspecter interface {
+	Introspect(db *Database, dsn string, p *Problems) error
+}
+
+//============================================================================
+//								Introspection
+//============================================================================
+
+// Introspect reads the definitions of an existing database of the given
+// SQL type. The database is named name.
+func Introspect(sqlType, name, dsn string) (*Database, Problems, error) {
+	var p Problems
+
+	plg, err := dbPlugin.FindPlugin(sqlType)
+	if err != nil {
+		return nil, p, fmt.Errorf("Error: Can't find plugin for %s!\n", sqlType)
+	}
+	intr, ok := plg.Plugin.(Introspecter)
+	if !ok {
+		return nil, p, fmt.Errorf("Error: %s does not support introspection!\n", sqlType)
+	}
+
+	db := NewDatabase()
+	db.Name = name
+	db.SqlType = plg.Name
+	if err = intr.Introspect(db, dsn, &p); err != nil {
+		return nil, p, err
+	}
+	if len(db.Tables) == 0 {
+		return nil, p, fmt.Errorf("Error: %s has no tables!\n", dsn)
+	}
+	for i := range db.Tables {
+		db.Tables[i].DB = db
+		for j := range db.Tables[i].Fields {
+			db.Tables[i].Fields[j].Tbl = &db.Tables[i]
+		}
+	}
+
+	return db, p, nil
+}
+
+// IntrospectType returns the name of the type in the given plugin types
+// for an SQL type such as "VARCHAR". Only the types in dbType.DefaultTable
+// are considered. If more than one type has the SQL type, the one without
+// a default length is preferred (ie "text" rather than "email"). An empty
+// string is returned if there is no type.
+func IntrospectType(tds *dbType.TypeDefns, sqlType string) string {
+	var name string
+
+	for _, td := range *tds {
+		if !strings.EqualFold(td.Sql, sqlType) || dbType.DefaultTable.FindDefn(td.Name) == nil {
+			continue
+		}
+		if len(name) == 0 {
+			name = td.Name
+		}
+		if td.DftLen == 0 {
+			return td.Name
+		}
+	}
+
+	return name
+}
+
+// WriteJsonFile writes the definitions to the given JSON file in the
+// form read by ReadJsonFile().
+func (d *Database) WriteJsonFile(fn string) error {
+	var data bytes.Buffer
+
+	// The Where clauses would be hard to read with HTML escaping.
+	enc := json.NewEncoder(&data)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(d); err != nil {
+		return fmt.Errorf("Error: marshalling: %s : %s\n", fn, err)
+	}
+	if err := ioutil.WriteFile(fn, data.Bytes(), 0644); err != nil {
+		return fmt.Errorf("Error: writing: %s : %s\n", fn, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the introspection support
+
+package dbJson
+
+import (
+	"genapp/pkg/genSqlAppGo/dbType"
+	"genapp/pkg/sharedData"
+	"log"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//								TestIntrospectType
+//----------------------------------------------------------------------------
+
+func TestIntrospectType(t *testing.T) {
+
+	log.Printf("dbJson::TestIntrospectType()..\n")
+	sharedData.SetDebug(true)
+
+	tests := []struct {
+		sql  string
+		name string
+	}{
+		{"VARCHAR", "text"},
+		{"varchar", "text"},
+		{"INT", "int"},
+		{"DEC", "dec"},
+		{"DATE", "date"},
+		{"BLOB", "blob"},
+		{"BOOLEAN", "bool"},
+		{"BIGINT", "bigint"},
+		{"GEOMETRY", ""},
+	}
+	for _, tst := range tests {
+		if name := IntrospectType(&dbType.DefaultTable, tst.sql); name != tst.name {
+			t.Errorf("TestIntrospectType() %s should be %q but is %q\n", tst.sql, tst.name, name)
+		}
+	}
+
+	// The test plugin does not support introspection.
+	if _, _, err := Introspect("analyze", "app", "app.db"); err == nil {
+		t.Errorf("TestIntrospectType() Introspect() should have failed\n")
+	}
+
+	t.Log("...end of dbJson::TestIntrospectType\n")
+}
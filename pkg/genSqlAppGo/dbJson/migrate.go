@@ -0,0 +1,451 @@
+// See License.txt in main repository directory
+
+// migrate contains the comparison of two versions of the database
+// definitions which produces the changes needed to migrate a database
+// from one version to the other.
+
+// Notes:
+//	*	Tables and fields are matched by name. A rename is only found
+//		if the new table or field gives its prior name in OldName.
+//	*	Indexes are matched by name (see IndexDefns()). A changed
+//		index is dropped and added again.
+//	*	The changes are ordered in phases so that the database is in
+//		a known state at each phase. In particular, when the fields
+//		of a table are altered, all of the table's renames, drops and
+//		adds have already been done. The plugins rely on this.
+//	*	Changing the keys of a table or the references of an existing
+//		table is not supported.
+
+package dbJson
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//============================================================================
+//                              Database Changes
+//============================================================================
+
+// ChangeKind is the kind of a change. The kinds are declared in the
+// order (phase) that they must be applied.
+type ChangeKind int
+
+const (
+	TableRename ChangeKind = iota
+	IndexDrop
+	FieldRename
+	TableDrop
+	FieldDrop
+	FieldAdd
+	FieldAlter
+	TableAdd
+	IndexAdd
+)
+
+var changeKindNames = []string{
+	"rename table",
+	"drop index",
+	"rename field",
+	"drop table",
+	"drop field",
+	"add field",
+	"alter field",
+	"add table",
+	"add index",
+}
+
+func (k ChangeKind) String() string {
+	if int(k) < len(changeKindNames) {
+		return changeKindNames[k]
+	}
+	return fmt.Sprintf("change(%d)", int(k))
+}
+
+// inverse returns the kind which undoes the change.
+func (k ChangeKind) inverse() ChangeKind {
+	switch k {
+	case TableAdd:
+		return TableDrop
+	case TableDrop:
+		return TableAdd
+	case FieldAdd:
+		return FieldDrop
+	case FieldDrop:
+		return FieldAdd
+	case IndexAdd:
+		return IndexDrop
+	case IndexDrop:
+		return IndexAdd
+	}
+	return k
+}
+
+// DbChange is one change between two versions of a database. Old and
+// New are the table before and after the migration. For field and index
+// changes, both are present and the table has New's name since the
+// table renames are done first.
+type DbChange struct {
+	Kind     ChangeKind
+	Old      *DbTable // Table before the change (nil if added)
+	New      *DbTable // Table after the change (nil if dropped)
+	OldField *DbField
+	NewField *DbField
+	OldIndex *DbIndex
+	NewIndex *DbIndex
+}
+
+// Inverse returns the change which undoes this change.
+func (c DbChange) Inverse() DbChange {
+	return DbChange{
+		Kind:     c.Kind.inverse(),
+		Old:      c.New,
+		New:      c.Old,
+		OldField: c.NewField,
+		NewField: c.OldField,
+		OldIndex: c.NewIndex,
+		NewIndex: c.OldIndex,
+	}
+}
+
+// Slug returns a short name for the change suitable for a file name.
+func (c DbChange) Slug() string {
+	var names []string
+
+	names = append(names, strings.Replace(c.Kind.String(), " ", "_", -1))
+	if c.New != nil {
+		names = append(names, c.New.Name)
+	} else if c.Old != nil {
+		names = append(names, c.Old.Name)
+	}
+	switch {
+	case c.NewField != nil:
+		names = append(names, c.NewField.Name)
+	case c.OldField != nil:
+		names = append(names, c.OldField.Name)
+	case c.NewIndex != nil:
+		names = append(names, c.NewIndex.Name)
+	case c.OldIndex != nil:
+		names = append(names, c.OldIndex.Name)
+	}
+
+	return strings.ToLower(strings.Join(names, "_"))
+}
+
+func (c DbChange) String() string {
+	switch c.Kind {
+	case TableAdd:
+		return fmt.Sprintf("%s %s", c.Kind, c.New.Name)
+	case TableDrop:
+		return fmt.Sprintf("%s %s", c.Kind, c.Old.Name)
+	case TableRename:
+		return fmt.Sprintf("%s %s to %s", c.Kind, c.Old.Name, c.New.Name)
+	case FieldRename:
+		return fmt.Sprintf("%s %s.%s to %s", c.Kind, c.New.Name, c.OldField.Name, c.NewField.Name)
+	case FieldAdd, FieldAlter:
+		return fmt.Sprintf("%s %s.%s", c.Kind, c.New.Name, c.NewField.Name)
+	case FieldDrop:
+		return fmt.Sprintf("%s %s.%s", c.Kind, c.New.Name, c.OldField.Name)
+	case IndexAdd:
+		return fmt.Sprintf("%s %s.%s", c.Kind, c.New.Name, c.NewIndex.Name)
+	case IndexDrop:
+		return fmt.Sprintf("%s %s.%s", c.Kind, c.New.Name, c.OldIndex.Name)
+	}
+	return c.Kind.String()
+}
+
+// KeptIndexes returns the indexes which are unchanged between the Old
+// and New tables. These are the indexes that exist on the table between
+// the IndexDrop and IndexAdd phases. They are returned as defined in the
+// Old table if old is true or else as defined in the New table.
+func (c DbChange) KeptIndexes(old bool) []DbIndex {
+	var idxs []DbIndex
+
+	if c.Old == nil || c.New == nil {
+		return idxs
+	}
+	oldDefs := c.Old.IndexDefns()
+	for i, o := range renamedIndexDefns(c.Old, c.New) {
+		for _, x := range c.New.IndexDefns() {
+			if o.Name == x.Name && o.equal(&x) {
+				if old {
+					x = oldDefs[i]
+				}
+				idxs = append(idxs, x)
+				break
+			}
+		}
+	}
+
+	return idxs
+}
+
+// DbChanges are all of the changes between two versions of a database.
+type DbChanges []DbChange
+
+// Down returns the changes which undo these changes in the order that
+// they must be applied.
+func (c DbChanges) Down() DbChanges {
+	var down DbChanges
+
+	for i := len(c) - 1; i >= 0; i-- {
+		down = append(down, c[i].Inverse())
+	}
+	down.sort()
+
+	return down
+}
+
+// sort orders the changes by phase keeping the order within each phase.
+func (c DbChanges) sort() {
+	sort.SliceStable(c, func(i, j int) bool {
+		return c[i].Kind < c[j].Kind
+	})
+}
+
+//----------------------------------------------------------------------------
+//								Comparison
+//----------------------------------------------------------------------------
+
+// equal returns true if the index definitions are the same ignoring
+// the name.
+func (x *DbIndex) equal(o *DbIndex) bool {
+	return strings.Join(x.Columns, ",") == strings.Join(o.Columns, ",") &&
+		x.Unique == o.Unique && x.Where == o.Where
+}
+
+// renamedIndexDefns returns the indexes of the old table with their
+// columns under the names that they have in the new table so that
+// renaming a field does not change its indexes.
+func renamedIndexDefns(ot, nt *DbTable) []DbIndex {
+	names := map[string]string{}
+	for _, f := range nt.Fields {
+		if len(f.OldName) > 0 && ot.FindField(f.OldName) != nil {
+			names[f.OldName] = f.Name
+		}
+	}
+
+	idxs := ot.IndexDefns()
+	for i := range idxs {
+		cols := make([]string, len(idxs[i].Columns))
+		for j, c := range idxs[i].Columns {
+			if n, ok := names[c]; ok {
+				c = n
+			}
+			cols[j] = c
+		}
+		idxs[i].Columns = cols
+	}
+
+	return idxs
+}
+
+// InTable returns a copy of the field as a field of the table so that the
+// names taken from the table, such as those of its enumerated type or CHECK
+// constraint, are those that it has in the table.
+func (f *DbField) InTable(t *DbTable) *DbField {
+	g := *f
+	g.Tbl = t
+	return &g
+}
+
+// fieldAltered returns true if the column definition of the field
+// changed. Unique and Incr are not included since they can not be
+// altered portably. The SQL of the types is compared since the Types of
+// the database may change it without changing the type's name. The Enum
+// values are compared as SQL since they are part of the type or a CHECK
+// constraint.
+func fieldAltered(o, n *DbField) bool {
+	return !strings.EqualFold(o.TypeDefn, n.TypeDefn) || o.Len != n.Len || o.Dec != n.Dec ||
+		o.Nullable != n.Nullable || o.SQLParms != n.SQLParms ||
+		o.Typ != nil && n.Typ != nil && !strings.EqualFold(o.Typ.Sql, n.Typ.Sql) ||
+		o.IsEnum() != n.IsEnum() || o.IsEnum() && o.EnumSqlList() != n.EnumSqlList()
+}
+
+// Diff compares two versions of a database and returns the changes
+// needed to migrate from the first to the second. Any problems which
+// prevent the migration are returned as errors. Changes which are not
+// migrated are returned as warnings.
+func Diff(from, to *Database) (DbChanges, Problems) {
+	var chgs DbChanges
+	var p Problems
+
+	if !strings.EqualFold(from.SqlType, to.SqlType) {
+		p.AddError(to.Name, "SqlType changed from %s to %s", from.SqlType, to.SqlType)
+		return nil, p
+	}
+
+	newTbls, err := to.TablesCreateOrder()
+	if err != nil {
+		p.AddError(to.Name, "the table references form a cycle")
+		return nil, p
+	}
+	oldTbls, err := from.TablesDeleteOrder()
+	if err != nil {
+		p.AddError(from.Name, "the table references form a cycle")
+		return nil, p
+	}
+
+	matched := map[string]bool{}
+	for _, nt := range newTbls {
+		tblPath := fmt.Sprintf("%s.%s", to.Name, nt.Name)
+		var ot *DbTable
+		if len(nt.OldName) > 0 {
+			if ot = from.FindTable(nt.OldName); ot == nil {
+				if ot = from.FindTable(nt.Name); ot == nil {
+					p.AddError(tblPath, "OldName, %s, is not defined in %s", nt.OldName, from.Name)
+					continue
+				}
+			}
+		} else {
+			ot = from.FindTable(nt.Name)
+		}
+		if ot == nil {
+			chgs = append(chgs, DbChange{Kind: TableAdd, New: nt})
+			continue
+		}
+		matched[ot.Name] = true
+		if ot.Name != nt.Name {
+			chgs = append(chgs, DbChange{Kind: TableRename, Old: ot, New: nt})
+		}
+		chgs = append(chgs, diffTable(ot, nt, tblPath, &p)...)
+	}
+	for _, ot := range oldTbls {
+		if !matched[ot.Name] {
+			chgs = append(chgs, DbChange{Kind: TableDrop, Old: ot})
+		}
+	}
+	chgs.sort()
+
+	return chgs, p
+}
+
+// diffTable compares the fields and indexes of two versions of a table.
+func diffTable(ot, nt *DbTable, tblPath string, p *Problems) DbChanges {
+	var chgs DbChanges
+
+	oldKeys, _ := ot.Keys()
+	newKeys, _ := nt.Keys()
+	matched := map[string]string{}
+
+	for i := range nt.Fields {
+		nf := &nt.Fields[i]
+		fldPath := fmt.Sprintf("%s.%s", tblPath, nf.Name)
+		var of *DbField
+		if len(nf.OldName) > 0 {
+			if of = ot.FindField(nf.OldName); of == nil {
+				if of = ot.FindField(nf.Name); of == nil {
+					p.AddError(fldPath, "OldName, %s, is not defined in %s", nf.OldName, ot.Name)
+					continue
+				}
+			}
+		} else {
+			of = ot.FindField(nf.Name)
+		}
+		if of == nil {
+			if nf.KeyNum == 0 && !nf.Nullable {
+				p.AddWarning(fldPath, "is not Null, existing rows will need a value")
+			}
+			chgs = append(chgs, DbChange{Kind: FieldAdd, Old: ot, New: nt, NewField: nf})
+			continue
+		}
+		matched[of.Name] = nf.Name
+		if of.Name != nf.Name {
+			chgs = append(chgs, DbChange{Kind: FieldRename, Old: ot, New: nt, OldField: of, NewField: nf})
+		}
+		if of.Unique != nf.Unique || of.Incr != nf.Incr {
+			p.AddWarning(fldPath, "the Unique or Incr changed and is not migrated, use an index")
+		}
+		if fieldAltered(of, nf) {
+			chgs = append(chgs, DbChange{Kind: FieldAlter, Old: ot, New: nt, OldField: of, NewField: nf})
+		}
+	}
+	for i := range ot.Fields {
+		of := &ot.Fields[i]
+		if _, ok := matched[of.Name]; !ok {
+			// The down migration adds the field back.
+			if of.KeyNum == 0 && !of.Nullable {
+				p.AddWarning(fmt.Sprintf("%s.%s", tblPath, of.Name),
+					"is not Null, existing rows will need a value if the migration is reversed")
+			}
+			chgs = append(chgs, DbChange{Kind: FieldDrop, Old: ot, New: nt, OldField: of})
+		}
+	}
+
+	// The keys are compared after allowing for renames.
+	for i, k := range oldKeys {
+		oldKeys[i] = matched[k]
+	}
+	if strings.Join(oldKeys, ",") != strings.Join(newKeys, ",") {
+		p.AddError(tblPath, "the key changed from %s to %s which is not supported",
+			ot.KeysList("", ""), nt.KeysList("", ""))
+	}
+
+	oldRefs := ot.ForeignKeys()
+	newRefs := nt.ForeignKeys()
+	if len(oldRefs) != len(newRefs) {
+		p.AddWarning(tblPath, "the references changed and are not migrated")
+	} else {
+		for i := range oldRefs {
+			if oldRefs[i].CreateSql(oldRefs[i].Table) != newRefs[i].CreateSql(newRefs[i].Table) {
+				p.AddWarning(tblPath, "the references changed and are not migrated")
+				break
+			}
+		}
+	}
+
+	// The old indexes are compared under the new field names, but are
+	// dropped as defined.
+	oldDefs := ot.IndexDefns()
+	oldIdxs := renamedIndexDefns(ot, nt)
+	newIdxs := nt.IndexDefns()
+	for i := range oldIdxs {
+		ox := &oldIdxs[i]
+		found := false
+		for j := range newIdxs {
+			if newIdxs[j].Name == ox.Name && newIdxs[j].equal(ox) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			chgs = append(chgs, DbChange{Kind: IndexDrop, Old: ot, New: nt, OldIndex: &oldDefs[i]})
+		}
+	}
+	for j := range newIdxs {
+		nx := &newIdxs[j]
+		found := false
+		for i := range oldIdxs {
+			if oldIdxs[i].Name == nx.Name && oldIdxs[i].equal(nx) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			chgs = append(chgs, DbChange{Kind: IndexAdd, Old: ot, New: nt, NewIndex: nx})
+		}
+	}
+
+	return chgs
+}
+
+// ReadMigrationJsonFile reads the given JSON file into a new Database
+// setting up its tables and plugin so that it may be compared with
+// another version.
+func ReadMigrationJsonFile(fn string) (*Database, error) {
+	var err error
+
+	db := NewDatabase()
+	if err = db.ReadJsonFile(fn); err != nil {
+		return nil, err
+	}
+	if err = db.SetupPlugin(); err != nil {
+		return nil, err
+	}
+	if err = db.ValidatePlugin(); err != nil {
+		return nil, fmt.Errorf("Error: %s:\n%s", fn, err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,156 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the differences between two versions of a database
+
+package dbJson
+
+import (
+	"genapp/pkg/sharedData"
+	"log"
+	"testing"
+)
+
+// newMigrateDatabases returns two versions of the reference database.
+func newMigrateDatabases() (*Database, *Database) {
+
+	from := newRefDatabase()
+	from.Tables = append(from.Tables, DbTable{Name: "old",
+		Fields: []DbField{
+			{Name: "num", TypeDefn: "int", KeyNum: 1},
+		},
+	})
+	from.FindTable("invoice").Fields = append(from.FindTable("invoice").Fields,
+		DbField{Name: "notes", TypeDefn: "text", Len: 80, Nullable: true})
+	for i := range from.Tables {
+		from.Tables[i].DB = from
+	}
+
+	to := newRefDatabase()
+	tb := to.FindTable("customer")
+	tb.Name = "client"
+	tb.OldName = "customer"
+	tb.Fields[1] = DbField{Name: "fullname", OldName: "name", TypeDefn: "text", Len: 40}
+	tb.Indexes = []DbIndex{{Columns: []string{"fullname"}}}
+	to.FindTable("invoice").Fields[1].Ref = "client.num"
+	to.FindTable("item").Fields = append(to.FindTable("item").Fields,
+		DbField{Name: "descr", TypeDefn: "text", Len: 40, Nullable: true})
+	to.Tables = append(to.Tables, DbTable{Name: "payment",
+		Fields: []DbField{
+			{Name: "num", TypeDefn: "int", KeyNum: 1},
+			{Name: "invnum", TypeDefn: "int", Ref: "invoice"},
+		},
+	})
+	for i := range to.Tables {
+		to.Tables[i].DB = to
+	}
+
+	return from, to
+}
+
+//----------------------------------------------------------------------------
+//								TestDiff
+//----------------------------------------------------------------------------
+
+func TestDiff(t *testing.T) {
+
+	log.Printf("dbJson::TestDiff()..\n")
+	sharedData.SetDebug(true)
+	from, to := newMigrateDatabases()
+
+	chgs, p := Diff(from, to)
+	t.Logf("Problems:\n%s\n", p.String())
+	if p.ErrorCount() != 0 {
+		t.Fatalf("TestDiff() should not have errors\n")
+	}
+	if !hasProblem(p, "app.invoice", "references changed") {
+		t.Errorf("TestDiff() missing references warning\n")
+	}
+
+	up := []string{
+		"rename table customer to client",
+		"rename field client.name to fullname",
+		"drop table old",
+		"drop field invoice.notes",
+		"add field item.descr",
+		"alter field client.fullname",
+		"add table payment",
+		"add index client.IX_client_1",
+	}
+	for i, c := range chgs {
+		t.Logf("\tup: %s\n", c.String())
+		if i < len(up) && c.String() != up[i] {
+			t.Errorf("TestDiff() change %d should be %q but is %q\n", i, up[i], c.String())
+		}
+	}
+	if len(chgs) != len(up) {
+		t.Fatalf("TestDiff() should have %d changes but has %d\n", len(up), len(chgs))
+	}
+	if chgs[1].Slug() != "rename_field_client_fullname" {
+		t.Errorf("TestDiff() invalid slug: %s\n", chgs[1].Slug())
+	}
+
+	down := []string{
+		"rename table client to customer",
+		"drop index customer.IX_client_1",
+		"rename field customer.fullname to name",
+		"drop table payment",
+		"drop field item.descr",
+		"add field invoice.notes",
+		"alter field customer.name",
+		"add table old",
+	}
+	for i, c := range chgs.Down() {
+		t.Logf("\tdown: %s\n", c.String())
+		if i < len(down) && c.String() != down[i] {
+			t.Errorf("TestDiff() down change %d should be %q but is %q\n", i, down[i], c.String())
+		}
+	}
+
+	t.Log("...end of dbJson::TestDiff\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestDiffProblems
+//----------------------------------------------------------------------------
+
+func TestDiffProblems(t *testing.T) {
+
+	log.Printf("dbJson::TestDiffProblems()..\n")
+	sharedData.SetDebug(true)
+	from := newRefDatabase()
+	to := newRefDatabase()
+
+	to.FindTable("item").Fields[0].KeyNum = 0
+	to.FindTable("item").Fields = append(to.FindTable("item").Fields,
+		DbField{Name: "id", TypeDefn: "int", KeyNum: 1})
+	to.FindTable("customer").Fields[1] = DbField{Name: "fullname", OldName: "bogus", TypeDefn: "text"}
+	to.FindTable("invoice").Fields = append(to.FindTable("invoice").Fields,
+		DbField{Name: "total", TypeDefn: "money"})
+
+	chgs, p := Diff(from, to)
+	t.Logf("Problems:\n%s\n", p.String())
+	if !hasProblem(p, "app.item", "key changed") {
+		t.Errorf("TestDiffProblems() missing key problem\n")
+	}
+	if !hasProblem(p, "app.customer.fullname", "OldName, bogus") {
+		t.Errorf("TestDiffProblems() missing OldName problem\n")
+	}
+	if !hasProblem(p, "app.invoice.total", "is not Null") {
+		t.Errorf("TestDiffProblems() missing not Null warning\n")
+	}
+	if !hasProblem(p, "app.customer.name", "if the migration is reversed") {
+		t.Errorf("TestDiffProblems() missing reversed not Null warning\n")
+	}
+	if p.ErrorCount() != 2 {
+		t.Errorf("TestDiffProblems() should have 2 errors but has %d\n", p.ErrorCount())
+	}
+	t.Logf("\tchanges: %d\n", len(chgs))
+
+	to.SqlType = "sqlite"
+	if _, p = Diff(from, to); !hasProblem(p, "app", "SqlType changed") {
+		t.Errorf("TestDiffProblems() missing SqlType problem\n")
+	}
+
+	t.Log("...end of dbJson::TestDiffProblems\n")
+}
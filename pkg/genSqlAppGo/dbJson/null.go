@@ -0,0 +1,302 @@
+// See License.txt in main repository directory
+
+// null contains the support for the fields which may be NULL ("Null":true).
+// Their struct fields are kept as either the sql.Null types (ie
+// sql.NullString or decimal.NullDecimal for decimals) or as pointers to
+// the field's Go type selected by the Database's NullType so that NULL
+// can be read and written back.
+
+// Notes:
+//	*	NullType is "sql" (default) or "pointer".
+//	*	An empty string in a form, CSV or URL value gives NULL. NULL is
+//		given back as an empty string except in JSON where it is null.
+//	*	Blobs are not changed since a nil slice is already NULL.
+//	*	Key fields may not be NULL.
+//	*	Required fields may be NULL in the database, but are not valid
+//		if they are NULL.
+
+package dbJson
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NullTypes are the valid values of Database.NullType.
+var NullTypes = []string{"sql", "pointer"}
+
+// NullPointers returns true if the NULL fields are kept as pointers
+// instead of the sql.Null types.
+func (d *Database) NullPointers() bool {
+	return d.NullType == "pointer"
+}
+
+// HasNull returns true if any field of the database is kept as a
+// NULL type.
+func (d *Database) HasNull() bool {
+	for i := range d.Tables {
+		if d.Tables[i].HasNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNull returns true if any field of the table is kept as a NULL type.
+func (t *DbTable) HasNull() bool {
+	for i := range t.Fields {
+		if t.Fields[i].IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNullable returns true if any field of the table may be NULL
+// including the blobs.
+func (t *DbTable) HasNullable() bool {
+	for i := range t.Fields {
+		if t.Fields[i].Nullable && t.Fields[i].KeyNum == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNull returns true if the field is kept as a NULL type.
+func (f *DbField) IsNull() bool {
+	return f.Nullable && f.KeyNum == 0 && f.Typ != nil && !f.IsBlob()
+}
+
+// nullPointers returns true if the field's database keeps the NULL
+// fields as pointers.
+func (f *DbField) nullPointers() bool {
+	return f.Tbl != nil && f.Tbl.DB != nil && f.Tbl.DB.NullPointers()
+}
+
+// nullMember returns the name of the value within the sql.Null type or
+// decimal.NullDecimal.
+func (f *DbField) nullMember() string {
+
+	switch f.GoType() {
+	case "int", "int32", "int64":
+		return "Int64"
+	case "float64":
+		return "Float64"
+	case "bool":
+		return "Bool"
+	case "time.Time":
+		return "Time"
+	case "decimal.Decimal":
+		return "Decimal"
+	}
+
+	return "String"
+}
+
+// StructType returns the Go type of the field within the table's struct.
+func (f *DbField) StructType() string {
+
+	switch {
+	case !f.IsNull():
+		return f.GoType()
+	case f.nullPointers():
+		return "*" + f.GoType()
+	case f.GoType() == "decimal.Decimal":
+		return "decimal.NullDecimal"
+	}
+
+	return "sql.Null" + f.nullMember()
+}
+
+// NullValue returns the Go value of NULL for the field. Blobs are nil.
+func (f *DbField) NullValue() string {
+
+	switch {
+	case f.IsBlob():
+		return "nil"
+	case !f.IsNull():
+		return ""
+	case f.nullPointers():
+		return "nil"
+	}
+
+	return f.StructType() + "{}"
+}
+
+// GenNotNull generates the condition that the field of (dn) is not NULL.
+func (f *DbField) GenNotNull(dn string) string {
+	fn := dn + "." + f.TitledName()
+
+	switch {
+	case f.IsBlob():
+		return fn + " != nil"
+	case !f.IsNull():
+		return "true"
+	case f.nullPointers():
+		return fn + " != nil"
+	}
+
+	return fn + ".Valid"
+}
+
+// GenIsNull generates the condition that the field of (dn) is NULL.
+func (f *DbField) GenIsNull(dn string) string {
+	fn := dn + "." + f.TitledName()
+
+	switch {
+	case f.IsBlob():
+		return fn + " == nil"
+	case !f.IsNull():
+		return "false"
+	case f.nullPointers():
+		return fn + " == nil"
+	}
+
+	return "!" + fn + ".Valid"
+}
+
+// ValueName returns the expression for the value of the field of (dn)
+// which, for a NULL type, is only valid if GenNotNull() is true.
+func (f *DbField) ValueName(dn string) string {
+	fn := dn + "." + f.TitledName()
+
+	switch {
+	case !f.IsNull():
+		return fn
+	case f.nullPointers():
+		return "(*" + fn + ")"
+	}
+
+	return fn + "." + f.nullMember()
+}
+
+// GenSet generates the code to set the field of (dn) to a value of its
+// Go type (val).
+func (f *DbField) GenSet(dn, val string) string {
+	fn := dn + "." + f.TitledName()
+
+	switch {
+	case !f.IsNull():
+		return fmt.Sprintf("%s = %s\n", fn, val)
+	case f.nullPointers():
+		return fmt.Sprintf("%s = new(%s)\n*%s = %s\n", fn, f.GoType(), fn, val)
+	}
+
+	return fmt.Sprintf("%s = %s{%s: %s, Valid: true}\n", fn, f.StructType(), f.nullMember(), val)
+}
+
+// GenSetNull generates the code to set the field of (dn) to NULL.
+func (f *DbField) GenSetNull(dn string) string {
+	return fmt.Sprintf("%s.%s = %s\n", dn, f.TitledName(), f.NullValue())
+}
+
+// GenNotEqual generates the condition that the field of (an) is not the
+// same as the one of (bn).
+func (f *DbField) GenNotEqual(an, bn string) string {
+	var cond string
+
+	a := f.ValueName(an)
+	b := f.ValueName(bn)
+	switch {
+	case f.GoType() == "time.Time" || f.GoType() == "decimal.Decimal":
+		cond = fmt.Sprintf("!%s.Equal(%s)", a, b)
+	case f.IsBlob():
+		return fmt.Sprintf("!bytes.Equal(%s, %s)", a, b)
+	default:
+		cond = fmt.Sprintf("%s != %s", a, b)
+	}
+	switch {
+	case !f.IsNull():
+		return cond
+	case f.nullPointers():
+		return fmt.Sprintf("(%s) != (%s) || %s && %s", f.GenNotNull(an), f.GenNotNull(bn),
+			f.GenNotNull(an), cond)
+	}
+
+	return fmt.Sprintf("%s != %s || %s && %s", f.GenNotNull(an), f.GenNotNull(bn), f.GenNotNull(an), cond)
+}
+
+// JsonTag returns the struct tag giving the JSON name of the field or ""
+// if it has none. NULL types are not omitted when empty so that they are
+// given as null.
+func (f *DbField) JsonTag() string {
+
+	switch {
+	case len(f.JsonName) == 0:
+		return ""
+	case f.IsNull():
+		return fmt.Sprintf("`json:\"%s\"`", f.JsonName)
+	}
+
+	return fmt.Sprintf("`json:\"%s,omitempty\"`", f.JsonName)
+}
+
+// genNullFrom wraps the code generated by gen which converts a string
+// (sn) to the field (fn) so that an empty string gives NULL. gen is
+// given the variable to convert the string into.
+func (f *DbField) genNullFrom(fn, sn string, gen func(vn string) string) string {
+	var str strings.Builder
+
+	if !f.IsNull() {
+		return gen(fn)
+	}
+
+	// Text is not trimmed since blanks may be a valid value.
+	empty := fmt.Sprintf("len(%s) == 0", sn)
+	if !f.IsText() {
+		empty = fmt.Sprintf("len(strings.TrimSpace(%s)) == 0", sn)
+	}
+	fmt.Fprintf(&str, "\tif %s {\n", empty)
+	fmt.Fprintf(&str, "\t\t%s = %s\n", fn, f.NullValue())
+	str.WriteString("\t} else {\n")
+	if f.nullPointers() {
+		fmt.Fprintf(&str, "\t\t%s = new(%s)\n", fn, f.GoType())
+		str.WriteString(gen("*" + fn))
+	} else {
+		str.WriteString(gen(fn + "." + f.nullMember()))
+		fmt.Fprintf(&str, "\t\t%s.Valid = true\n", fn)
+	}
+	str.WriteString("\t}\n")
+
+	return str.String()
+}
+
+// genNullTo wraps the code generated by gen which converts the field
+// (fn) to a string (v) so that NULL gives an empty string. gen is given
+// the value to convert.
+func (f *DbField) genNullTo(v, fn string, gen func(vn string) string) string {
+	var str strings.Builder
+
+	if !f.IsNull() {
+		return gen(fn)
+	}
+
+	if f.nullPointers() {
+		fmt.Fprintf(&str, "\tif %s != nil {\n", fn)
+		str.WriteString(gen("(*" + fn + ")"))
+	} else {
+		fmt.Fprintf(&str, "\tif %s.Valid {\n", fn)
+		str.WriteString(gen(fn + "." + f.nullMember()))
+	}
+	str.WriteString("\t} else {\n")
+	fmt.Fprintf(&str, "\t\t%s = \"\"\n", v)
+	str.WriteString("\t}\n")
+
+	return str.String()
+}
+
+// analyzeNulls checks the fields of one table which may be NULL.
+func (d *Database) analyzeNulls(t *DbTable, tblPath string, p *Problems) {
+
+	for j := range t.Fields {
+		f := &t.Fields[j]
+		if !f.Nullable {
+			continue
+		}
+		fldPath := fmt.Sprintf("%s.%s", tblPath, f.Name)
+		if f.KeyNum > 0 {
+			p.AddError(fldPath, "Null is not allowed on a key field")
+		}
+	}
+}
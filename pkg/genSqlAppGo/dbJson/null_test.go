@@ -0,0 +1,179 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the support of the fields which may be NULL
+
+package dbJson
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"genapp/pkg/genSqlAppGo/dbType"
+)
+
+// newNullDatabase returns a database with a table of fields which may be
+// NULL kept as the given NullType.
+func newNullDatabase(nullType string) *Database {
+
+	db := &Database{Name: "app", SqlType: "analyze", NullType: nullType,
+		Tables: []DbTable{
+			{Name: "sample",
+				Fields: []DbField{
+					{Name: "id", TypeDefn: "int", KeyNum: 1, Nullable: true},
+					{Name: "name", TypeDefn: "text", Len: 10, Nullable: true},
+					{Name: "cnt", TypeDefn: "int", Nullable: true},
+					{Name: "stamp", TypeDefn: "timestamptz", Nullable: true},
+					{Name: "flag", TypeDefn: "bool", Nullable: true},
+					{Name: "data", TypeDefn: "blob", Nullable: true},
+					{Name: "req", TypeDefn: "text"},
+				},
+			},
+		},
+	}
+	tb := &db.Tables[0]
+	tb.DB = db
+	for i := range tb.Fields {
+		tb.Fields[i].Tbl = tb
+		tb.Fields[i].Typ = dbType.DefaultTable.FindDefn(tb.Fields[i].TypeDefn)
+	}
+
+	return db
+}
+
+//----------------------------------------------------------------------------
+//								TestNull
+//----------------------------------------------------------------------------
+
+func TestNull(t *testing.T) {
+
+	log.Printf("dbJson::TestNull()..\n")
+	db := newNullDatabase("")
+	tb := &db.Tables[0]
+
+	if !tb.HasNull() || !db.HasNull() || db.NullPointers() {
+		t.Errorf("TestNull() invalid HasNull() or NullPointers()\n")
+	}
+	tests := []struct {
+		fld  string
+		null bool
+		typ  string
+	}{
+		{"id", false, "int64"}, // keys are never NULL
+		{"name", true, "sql.NullString"},
+		{"cnt", true, "sql.NullInt64"},
+		{"stamp", true, "sql.NullTime"},
+		{"flag", true, "sql.NullBool"},
+		{"data", false, "[]byte"}, // nil is NULL
+		{"req", false, "string"},
+	}
+	for _, tst := range tests {
+		f := tb.FindField(tst.fld)
+		if f.IsNull() != tst.null || f.StructType() != tst.typ {
+			t.Errorf("TestNull() %s should be %v %s, but is %v %s\n", tst.fld, tst.null, tst.typ,
+				f.IsNull(), f.StructType())
+		}
+	}
+
+	f := tb.FindField("cnt")
+	if str := f.GenFromString("s", "str"); !strings.Contains(str, "s.Cnt = sql.NullInt64{}") ||
+		!strings.Contains(str, "s.Cnt.Int64, _ = strconv.ParseInt(str,0,64)") ||
+		!strings.Contains(str, "s.Cnt.Valid = true") {
+		t.Errorf("TestNull() invalid GenFromString(): %s\n", str)
+	}
+	if str := f.GenToString("str", "s"); !strings.Contains(str, "if s.Cnt.Valid {") ||
+		!strings.Contains(str, "fmt.Sprintf(\"%d\", s.Cnt.Int64)") || !strings.Contains(str, "str = \"\"") {
+		t.Errorf("TestNull() invalid GenToString(): %s\n", str)
+	}
+	if str := f.GenFromFormString("s", "str", "errs"); !strings.Contains(str, "len(strings.TrimSpace(str)) == 0") ||
+		!strings.Contains(str, "s.Cnt.Int64, err = strconv.ParseInt(str, 0, 64)") {
+		t.Errorf("TestNull() invalid GenFromFormString(): %s\n", str)
+	}
+	if str := f.GenSet("s", "i64"); str != "s.Cnt = sql.NullInt64{Int64: i64, Valid: true}\n" {
+		t.Errorf("TestNull() invalid GenSet(): %s\n", str)
+	}
+	if str := f.GenNotEqual("s", "r"); str != "s.Cnt.Valid != r.Cnt.Valid || s.Cnt.Valid && s.Cnt.Int64 != r.Cnt.Int64" {
+		t.Errorf("TestNull() invalid GenNotEqual(): %s\n", str)
+	}
+	f.Max = new(float64)
+	if str := f.GenValidate("s", "errs"); !strings.Contains(str, "if s.Cnt.Valid && float64(s.Cnt.Int64) > 0 {") {
+		t.Errorf("TestNull() rules should only be checked if not NULL: %s\n", str)
+	}
+	f = tb.FindField("name")
+	f.Required = true
+	if str := f.GenValidate("s", "errs"); !strings.Contains(str, "if !s.Name.Valid {") ||
+		!strings.Contains(str, "} else if s.Name.Valid && len(strings.TrimSpace(s.Name.String)) == 0 {") {
+		t.Errorf("TestNull() a Required field should not be NULL: %s\n", str)
+	}
+	if str := tb.FindField("stamp").FormInput(); !strings.Contains(str, "{{if .Rcd.Stamp.Valid}}") {
+		t.Errorf("TestNull() invalid time FormInput(): %s\n", str)
+	}
+	if str := tb.FindField("flag").FormInput(); !strings.Contains(str, "<select") ||
+		!strings.Contains(str, "<option value=\"\"></option>") {
+		t.Errorf("TestNull() a NULL bool should be selected: %s\n", str)
+	}
+	if str := tb.FindField("name").FormInput(); !strings.Contains(str, "value=\"{{.Rcd.ToString \"Name\"}}\"") {
+		t.Errorf("TestNull() invalid FormInput(): %s\n", str)
+	}
+
+	t.Log("dbJson::TestNull: end of test\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestNullPointers
+//----------------------------------------------------------------------------
+
+func TestNullPointers(t *testing.T) {
+
+	log.Printf("dbJson::TestNullPointers()..\n")
+	db := newNullDatabase("pointer")
+	tb := &db.Tables[0]
+
+	f := tb.FindField("stamp")
+	if !db.NullPointers() || f.StructType() != "*time.Time" || f.NullValue() != "nil" {
+		t.Errorf("TestNullPointers() invalid StructType() or NullValue()\n")
+	}
+	if str := f.GenToString("str", "s"); !strings.Contains(str, "if s.Stamp != nil {") ||
+		!strings.Contains(str, "(*s.Stamp).Format(time.RFC3339Nano)") {
+		t.Errorf("TestNullPointers() invalid GenToString(): %s\n", str)
+	}
+	if str := f.GenFromString("s", "str"); !strings.Contains(str, "s.Stamp = new(time.Time)") ||
+		!strings.Contains(str, "*s.Stamp, _ = time.Parse(time.RFC3339, str)") {
+		t.Errorf("TestNullPointers() invalid GenFromString(): %s\n", str)
+	}
+	if str := f.GenSet("s", "date"); str != "s.Stamp = new(time.Time)\n*s.Stamp = date\n" {
+		t.Errorf("TestNullPointers() invalid GenSet(): %s\n", str)
+	}
+	if str := f.GenNotEqual("s", "r"); !strings.HasPrefix(str, "(s.Stamp != nil) != (r.Stamp != nil) ||") {
+		t.Errorf("TestNullPointers() invalid GenNotEqual(): %s\n", str)
+	}
+	if str := f.FormInput(); !strings.Contains(str, "{{with .Rcd.Stamp}}{{.Format") {
+		t.Errorf("TestNullPointers() invalid FormInput(): %s\n", str)
+	}
+
+	t.Log("dbJson::TestNullPointers: end of test\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestAnalyzeNull
+//----------------------------------------------------------------------------
+
+func TestAnalyzeNull(t *testing.T) {
+
+	log.Printf("dbJson::TestAnalyzeNull()..\n")
+	db := newNullDatabase("bogus")
+
+	p := db.Analyze()
+	if !hasProblem(p, "app", "NullType, bogus, is not one of sql, pointer") {
+		t.Errorf("TestAnalyzeNull() missing NullType error:\n%s\n", p.String())
+	}
+	if !hasProblem(p, "app.sample.id", "Null is not allowed on a key field") {
+		t.Errorf("TestAnalyzeNull() missing key error:\n%s\n", p.String())
+	}
+	if p.ErrorCount() != 2 {
+		t.Errorf("TestAnalyzeNull() should have 2 errors:\n%s\n", p.String())
+	}
+
+	t.Log("dbJson::TestAnalyzeNull: end of test\n")
+}
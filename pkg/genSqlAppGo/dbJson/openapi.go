@@ -0,0 +1,151 @@
+// See License.txt in main repository directory
+
+// openapi supplies the functions used by the OpenAPI specification
+// template to describe the tables as served by the JSON API handlers.
+
+// Notes:
+//	*	The specification is OpenAPI 3.0 which uses "nullable" rather than
+//		a type list for fields which may be NULL.
+//	*	Decimal fields are given as strings in JSON by decimal.Decimal and
+//		are given a pattern limiting their digits.
+
+package dbJson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ApiName returns the name of the field in the JSON of a row.
+func (f *DbField) ApiName() string {
+	if len(f.JsonName) > 0 {
+		return f.JsonName
+	}
+	return f.TitledName()
+}
+
+// ApiSchema returns the OpenAPI schema of the field as a property of a
+// row as YAML lines each indented by the given number of spaces.
+func (f *DbField) ApiSchema(indent int) string {
+	return f.apiSchema(indent, true)
+}
+
+// ApiParmSchema returns the OpenAPI schema of the field as a path
+// parameter as YAML lines each indented by the given number of spaces.
+func (f *DbField) ApiParmSchema(indent int) string {
+	return f.apiSchema(indent, false)
+}
+
+// apiSchema returns the schema of the field adding the attributes which
+// only apply to a property if prop is true.
+func (f *DbField) apiSchema(indent int, prop bool) string {
+	var lines []string
+	var length int
+
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	length = f.Len
+	if length == 0 && f.Typ != nil {
+		length = f.Typ.DftLen
+	}
+	switch {
+	case f.IsDec():
+		add("type: string")
+		add("format: decimal")
+		if f.Len > 0 {
+			add("pattern: %s", strconv.Quote(fmt.Sprintf(`^-?[0-9]{0,%d}(\.[0-9]{0,%d})?$`,
+				f.Len-f.Dec, f.Dec)))
+		} else {
+			add("pattern: %s", strconv.Quote(`^-?[0-9]*(\.[0-9]*)?$`))
+		}
+	case f.Typ != nil && f.IsInteger():
+		add("type: integer")
+		add("format: int64")
+	case f.Typ != nil && f.IsFloat():
+		add("type: number")
+		add("format: double")
+	default:
+		add("type: string")
+		switch f.BaseType() {
+		case "date":
+			add("format: date")
+		case "datetime":
+			add("format: date-time")
+		case "time":
+			add("format: time")
+		case "email":
+			add("format: email")
+		case "url":
+			add("format: uri")
+		}
+		if length > 0 {
+			add("maxLength: %d", length)
+		}
+		if len(f.Pattern) > 0 {
+			add("pattern: %s", strconv.Quote(f.PatternRegexp()))
+		}
+		if len(f.Enum) > 0 {
+			var vals []string
+			for _, v := range f.Enum {
+				vals = append(vals, strconv.Quote(v))
+			}
+			add("enum: [%s]", strings.Join(vals, ", "))
+		}
+	}
+	if f.Typ != nil && (f.IsInteger() || f.IsFloat()) {
+		if f.Min != nil {
+			add("minimum: %s", f.MinValue())
+		}
+		if f.Max != nil {
+			add("maximum: %s", f.MaxValue())
+		}
+	}
+	if prop {
+		if f.Nullable {
+			add("nullable: true")
+		}
+		if f.Incr {
+			add("readOnly: true")
+		}
+		if len(f.Label) > 0 {
+			add("description: %s", strconv.Quote(f.Label))
+		}
+	}
+
+	pad := strings.Repeat(" ", indent)
+	return pad + strings.Join(lines, "\n"+pad)
+}
+
+// ApiPath returns the path of the table's JSON API.
+func (t *DbTable) ApiPath() string {
+	return "/api/" + t.TitledName()
+}
+
+// ApiRequired returns the JSON names of the fields which must be present
+// in a row which are the ones that can not be NULL.
+func (t *DbTable) ApiRequired() []string {
+	var names []string
+
+	for i := range t.Fields {
+		if !t.Fields[i].Nullable {
+			names = append(names, t.Fields[i].ApiName())
+		}
+	}
+	return names
+}
+
+// ApiRowPath returns the path of a row in the table's JSON API with a
+// path parameter for each key in key order.
+func (t *DbTable) ApiRowPath() string {
+	var str strings.Builder
+
+	str.WriteString(t.ApiPath())
+	keys, _ := t.Keys()
+	for _, k := range keys {
+		fmt.Fprintf(&str, "/{%s}", k)
+	}
+	return str.String()
+}
@@ -0,0 +1,63 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the OpenAPI specification support
+
+package dbJson
+
+import (
+	"genapp/pkg/genSqlAppGo/dbType"
+	"genapp/pkg/sharedData"
+	"log"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//								TestApiSchema
+//----------------------------------------------------------------------------
+
+func TestApiSchema(t *testing.T) {
+
+	log.Printf("dbJson::TestApiSchema()..\n")
+	sharedData.SetDebug(true)
+	db := newRefDatabase()
+	tb := db.FindTable("line")
+	tb.Fields = append(tb.Fields,
+		DbField{Name: "price", TypeDefn: "money", Len: 9, Dec: 2, Nullable: true},
+		DbField{Name: "shipped", TypeDefn: "date", JsonName: "shipDate", Label: "Shipped"},
+		DbField{Name: "contact", TypeDefn: "email"},
+	)
+	for i := range tb.Fields {
+		tb.Fields[i].Typ = dbType.DefaultTable.FindDefn(tb.Fields[i].TypeDefn)
+	}
+	tb.Fields[0].Incr = true
+
+	tests := []struct {
+		fld    string
+		schema string
+	}{
+		{"invnum", "  type: integer\n  format: int64\n  readOnly: true"},
+		{"item", "  type: string\n  maxLength: 20"},
+		{"price", "  type: string\n  format: decimal\n  pattern: \"^-?[0-9]{0,7}(\\\\.[0-9]{0,2})?$\"\n  nullable: true"},
+		{"shipped", "  type: string\n  format: date\n  description: \"Shipped\""},
+		{"contact", "  type: string\n  format: email\n  maxLength: 50"},
+	}
+	for _, tst := range tests {
+		if str := tb.FindField(tst.fld).ApiSchema(2); str != tst.schema {
+			t.Errorf("TestApiSchema() %s should be:\n%s\nbut is:\n%s\n", tst.fld, tst.schema, str)
+		}
+	}
+	if str := tb.Fields[0].ApiParmSchema(0); str != "type: integer\nformat: int64" {
+		t.Errorf("TestApiSchema() invalid parameter schema: %s\n", str)
+	}
+
+	if str := tb.ApiRowPath(); str != "/api/Line/{invnum}/{seq}" {
+		t.Errorf("TestApiSchema() invalid row path: %s\n", str)
+	}
+	req := tb.ApiRequired()
+	if len(req) != 5 || req[3] != "shipDate" {
+		t.Errorf("TestApiSchema() invalid required fields: %v\n", req)
+	}
+
+	t.Log("...end of dbJson::TestApiSchema\n")
+}
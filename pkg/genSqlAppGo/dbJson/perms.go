@@ -0,0 +1,102 @@
+// See License.txt in main repository directory
+
+// perms contains the support for the operations on a table that
+// each role of user is permitted to perform.
+
+// Notes:
+//	*	Perms is given on the table as a map of role names to the
+//		operations that the role may perform such as:
+//			"Perms": {"clerk": ["list", "show"], "manager": ["all"]}
+//	*	A table without Perms may be used by everyone.
+//	*	Perms is only enforced if the server authenticates its users
+//		since the role comes from the authentication.
+
+package dbJson
+
+import (
+	"sort"
+	"strings"
+)
+
+// PermOps are the operations which may be permitted to a role in the
+// order that they are generated.
+//
+//	list	list pages of rows
+//	show	display rows in the form
+//	insert	add rows
+//	update	change rows
+//	delete	delete rows
+//	load	create the table and load CSV or test data into it
+//	save	save the table as CSV
+var PermOps = []string{"list", "show", "insert", "update", "delete", "load", "save"}
+
+// PermAll permits all of PermOps.
+const PermAll = "all"
+
+// IsPermOp returns true if the given operation is one of PermOps or
+// PermAll.
+func IsPermOp(op string) bool {
+	if op == PermAll {
+		return true
+	}
+	for _, o := range PermOps {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPerms returns true if the table limits its operations by role.
+func (t *DbTable) HasPerms() bool {
+	return len(t.Perms) > 0
+}
+
+// PermRoles returns the roles given permissions for the table in
+// sorted order.
+func (t *DbTable) PermRoles() []string {
+	var roles []string
+
+	for r := range t.Perms {
+		roles = append(roles, r)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// PermOps returns the operations that the role is permitted in the
+// order of PermOps with PermAll expanded.
+func (t *DbTable) PermOps(role string) []string {
+	var ops []string
+
+	given := map[string]bool{}
+	for _, op := range t.Perms[role] {
+		given[strings.ToLower(op)] = true
+	}
+	for _, op := range PermOps {
+		if given[op] || given[PermAll] {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// analyzePerms checks the permissions of one table.
+func (d *Database) analyzePerms(t *DbTable, tblPath string, p *Problems) {
+
+	for _, role := range t.PermRoles() {
+		if len(strings.TrimSpace(role)) == 0 {
+			p.AddError(tblPath, "permissions are given for an empty role")
+			continue
+		}
+		if len(t.Perms[role]) == 0 {
+			p.AddWarning(tblPath, "role, %s, is not permitted any operations", role)
+		}
+		for _, op := range t.Perms[role] {
+			if !IsPermOp(strings.ToLower(op)) {
+				p.AddError(tblPath, "role, %s, operation, %s, is not one of %s or %s",
+					role, op, strings.Join(PermOps, ", "), PermAll)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the table permissions support
+
+package dbJson
+
+import (
+	"log"
+	"strings"
+	"testing"
+)
+
+//----------------------------------------------------------------------------
+//								TestPerms
+//----------------------------------------------------------------------------
+
+func TestPerms(t *testing.T) {
+
+	log.Printf("dbJson::TestPerms()..\n")
+	db := newRefDatabase()
+	tb := &db.Tables[0]
+
+	if tb.HasPerms() {
+		t.Errorf("TestPerms() %s should not have permissions\n", tb.Name)
+	}
+	tb.Perms = map[string][]string{
+		"manager": {"all"},
+		"clerk":   {"Show", "list"},
+	}
+	if !tb.HasPerms() {
+		t.Errorf("TestPerms() %s should have permissions\n", tb.Name)
+	}
+	if roles := tb.PermRoles(); strings.Join(roles, ",") != "clerk,manager" {
+		t.Errorf("TestPerms() invalid roles: %v\n", roles)
+	}
+	if ops := tb.PermOps("clerk"); strings.Join(ops, ",") != "list,show" {
+		t.Errorf("TestPerms() invalid clerk operations: %v\n", ops)
+	}
+	if ops := tb.PermOps("manager"); len(ops) != len(PermOps) {
+		t.Errorf("TestPerms() invalid manager operations: %v\n", ops)
+	}
+	if ops := tb.PermOps("guest"); len(ops) != 0 {
+		t.Errorf("TestPerms() guest should have no operations: %v\n", ops)
+	}
+
+	var p Problems
+	db.analyzePerms(tb, "test", &p)
+	if p.Err() != nil {
+		t.Errorf("TestPerms() valid permissions had problems:\n%s\n", p.String())
+	}
+	tb.Perms["clerk"] = append(tb.Perms["clerk"], "purge")
+	tb.Perms[" "] = []string{"list"}
+	p = nil
+	db.analyzePerms(tb, "test", &p)
+	if p.Err() == nil || !strings.Contains(p.String(), "purge") ||
+		!strings.Contains(p.String(), "empty role") {
+		t.Errorf("TestPerms() invalid permissions not found:\n%s\n", p.String())
+	}
+
+	t.Log("...end of dbJson::TestPerms\n")
+}
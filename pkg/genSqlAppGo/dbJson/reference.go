@@ -0,0 +1,297 @@
+// See License.txt in main repository directory
+
+// reference contains the support for relationships (ie foreign
+// keys) between the tables of a database.
+
+// Notes:
+//	*	A relationship may be given on the table as a References
+//		entry or, for the simple case of one field referencing the
+//		key of another table, on the field as Ref ("table" or
+//		"table.field").
+//	*	If the parent's fields (Columns) are not given, they default
+//		to the parent's key fields in key order.
+//	*	Only single field relationships are displayed as lookup lists
+//		in the generated forms. Multiple field relationships still
+//		generate the constraints.
+
+package dbJson
+
+import (
+	"fmt"
+	"strings"
+)
+
+//============================================================================
+//                        JSON Database Reference Support
+//============================================================================
+
+// ReferenceActions are the valid OnDelete and OnUpdate actions.
+var ReferenceActions = []string{"cascade", "no action", "restrict", "set default", "set null"}
+
+// DbReference defines a relationship from one or more fields of a table
+// (the child) to the key or unique fields of another table (the parent).
+type DbReference struct {
+	Name     string   `json:"Name,omitempty"`     // Constraint Name (optional)
+	Fields   []string `json:"Fields,omitempty"`   // Child Field Names
+	Table    string   `json:"Table,omitempty"`    // Parent Table Name
+	Columns  []string `json:"Columns,omitempty"`  // Parent Field Names (optional)
+	OnDelete string   `json:"OnDelete,omitempty"` // See ReferenceActions (optional)
+	OnUpdate string   `json:"OnUpdate,omitempty"` // See ReferenceActions (optional)
+}
+
+// CreateSql returns the table constraint for the reference given the
+// fully qualified parent table name.
+func (r *DbReference) CreateSql(parent string) string {
+	var str strings.Builder
+
+	fmt.Fprintf(&str, "CONSTRAINT %s FOREIGN KEY(%s) REFERENCES %s(%s)",
+		r.Name, strings.Join(r.Fields, ", "), parent, strings.Join(r.Columns, ", "))
+	if len(r.OnDelete) > 0 {
+		fmt.Fprintf(&str, " ON DELETE %s", strings.ToUpper(r.OnDelete))
+	}
+	if len(r.OnUpdate) > 0 {
+		fmt.Fprintf(&str, " ON UPDATE %s", strings.ToUpper(r.OnUpdate))
+	}
+
+	return str.String()
+}
+
+// IsValidAction returns true if the given action is empty or one of
+// ReferenceActions.
+func IsValidAction(action string) bool {
+	if len(action) == 0 {
+		return true
+	}
+	for _, a := range ReferenceActions {
+		if strings.ToLower(action) == a {
+			return true
+		}
+	}
+	return false
+}
+
+//----------------------------------------------------------------------------
+//								Table Support
+//----------------------------------------------------------------------------
+
+// ForeignKeys returns all of the references for the table combining the
+// table References and the field Refs. Missing parent fields default to
+// the parent's keys and missing names are generated.
+func (t *DbTable) ForeignKeys() []DbReference {
+	var refs []DbReference
+
+	for _, r := range t.References {
+		refs = append(refs, r)
+	}
+	for _, f := range t.Fields {
+		if len(f.Ref) == 0 {
+			continue
+		}
+		r := DbReference{Fields: []string{f.Name}}
+		s := strings.SplitN(f.Ref, ".", 2)
+		r.Table = s[0]
+		if len(s) > 1 {
+			r.Columns = []string{s[1]}
+		}
+		refs = append(refs, r)
+	}
+
+	for i := range refs {
+		r := &refs[i]
+		if len(r.Columns) == 0 && t.DB != nil {
+			if p := t.DB.FindTable(r.Table); p != nil {
+				r.Columns, _ = p.Keys()
+			}
+		}
+		if len(r.Name) == 0 {
+			r.Name = fmt.Sprintf("FK_%s_%d", t.Name, i+1)
+		}
+	}
+
+	return refs
+}
+
+// HasReferences returns true if the table references any other table.
+func (t *DbTable) HasReferences() bool {
+	if len(t.References) > 0 {
+		return true
+	}
+	for _, f := range t.Fields {
+		if len(f.Ref) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLookups returns true if any field of the table is displayed as
+// a lookup list.
+func (t *DbTable) HasLookups() bool {
+	for i := range t.Fields {
+		if t.LookupRef(t.Fields[i].Name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReferenced returns true if any table has a lookup list of this
+// table and therefore it needs to support lookups.
+func (t *DbTable) IsReferenced() bool {
+	if t.DB == nil {
+		return false
+	}
+	for i := range t.DB.Tables {
+		c := &t.DB.Tables[i]
+		for _, f := range c.Fields {
+			if r := c.LookupRef(f.Name); r != nil && r.Table == t.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LookupField returns the field to be displayed in lookup lists for this
+// table which is the first non-key text field or the first key if there
+// are no text fields.
+func (t *DbTable) LookupField() *DbField {
+	for i, f := range t.Fields {
+		if f.KeyNum == 0 && !f.Hidden && f.IsText() && !f.IsDec() {
+			return &t.Fields[i]
+		}
+	}
+	keys, _ := t.Keys()
+	if len(keys) > 0 {
+		return t.FindField(keys[0])
+	}
+	return nil
+}
+
+// ParentTables returns the tables which the table references directly or
+// indirectly in the order that they must be created. Self references are
+// ignored.
+func (t *DbTable) ParentTables() []*DbTable {
+	var tbls []*DbTable
+
+	if t.DB == nil {
+		return nil
+	}
+	parents := map[string]bool{}
+	var visit func(c *DbTable)
+	visit = func(c *DbTable) {
+		for _, r := range c.ForeignKeys() {
+			if r.Table == c.Name || r.Table == t.Name || parents[r.Table] {
+				continue
+			}
+			if p := t.DB.FindTable(r.Table); p != nil {
+				parents[r.Table] = true
+				visit(p)
+			}
+		}
+	}
+	visit(t)
+
+	order, _ := t.DB.TablesCreateOrder()
+	for _, tb := range order {
+		if parents[tb.Name] {
+			tbls = append(tbls, tb)
+		}
+	}
+	return tbls
+}
+
+// LookupRef returns the reference for the named field if the field is
+// displayed as a lookup list. That is only done if the field by itself
+// references the single key of the parent table.
+func (t *DbTable) LookupRef(name string) *DbReference {
+	if t.DB == nil {
+		return nil
+	}
+	for _, r := range t.ForeignKeys() {
+		if len(r.Fields) != 1 || r.Fields[0] != name {
+			continue
+		}
+		p := t.DB.FindTable(r.Table)
+		if p == nil {
+			continue
+		}
+		keys, _ := p.Keys()
+		if len(keys) == 1 && len(r.Columns) == 1 && r.Columns[0] == keys[0] {
+			return &r
+		}
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------
+//								Database Support
+//----------------------------------------------------------------------------
+
+// HasReferences returns true if any table references another.
+func (d *Database) HasReferences() bool {
+	for i := range d.Tables {
+		if d.Tables[i].HasReferences() {
+			return true
+		}
+	}
+	return false
+}
+
+// TablesCreateOrder returns the tables in the order that they must be
+// created so that each parent table is created before its children. The
+// order of the definitions is kept where possible. An error is returned
+// if the references form a cycle.
+func (d *Database) TablesCreateOrder() ([]*DbTable, error) {
+	var tbls []*DbTable
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+
+	var visit func(t *DbTable) error
+	visit = func(t *DbTable) error {
+		switch state[t.Name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("Error: table %s is part of a reference cycle!\n", t.Name)
+		}
+		state[t.Name] = visiting
+		for _, r := range t.ForeignKeys() {
+			if r.Table == t.Name {
+				continue
+			}
+			if p := d.FindTable(r.Table); p != nil {
+				if err := visit(p); err != nil {
+					return err
+				}
+			}
+		}
+		state[t.Name] = done
+		tbls = append(tbls, t)
+		return nil
+	}
+
+	for i := range d.Tables {
+		if err := visit(&d.Tables[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return tbls, nil
+}
+
+// TablesDeleteOrder returns the tables in the order that they must be
+// deleted so that each child table is deleted before its parents.
+func (d *Database) TablesDeleteOrder() ([]*DbTable, error) {
+	tbls, err := d.TablesCreateOrder()
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(tbls)-1; i < j; i, j = i+1, j-1 {
+		tbls[i], tbls[j] = tbls[j], tbls[i]
+	}
+	return tbls, nil
+}
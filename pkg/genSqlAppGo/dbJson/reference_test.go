@@ -0,0 +1,197 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the relationships between tables
+
+package dbJson
+
+import (
+	"genapp/pkg/sharedData"
+	"log"
+	"testing"
+)
+
+// newRefDatabase returns a database whose tables are defined children
+// first.
+func newRefDatabase() *Database {
+	db := &Database{Name: "app", SqlType: "analyze",
+		Tables: []DbTable{
+			{Name: "line",
+				Fields: []DbField{
+					{Name: "invnum", TypeDefn: "int", KeyNum: 1},
+					{Name: "seq", TypeDefn: "int", KeyNum: 2},
+					{Name: "item", TypeDefn: "text", Len: 20, Ref: "item"},
+				},
+				References: []DbReference{
+					{Fields: []string{"invnum"}, Table: "invoice", OnDelete: "cascade"},
+				},
+			},
+			{Name: "invoice",
+				Fields: []DbField{
+					{Name: "num", TypeDefn: "int", KeyNum: 1},
+					{Name: "custnum", TypeDefn: "int", Ref: "customer.num"},
+				},
+			},
+			{Name: "customer",
+				Fields: []DbField{
+					{Name: "num", TypeDefn: "int", KeyNum: 1},
+					{Name: "name", TypeDefn: "text", Len: 30},
+				},
+			},
+			{Name: "item",
+				Fields: []DbField{
+					{Name: "code", TypeDefn: "text", Len: 20, KeyNum: 1},
+				},
+			},
+		},
+	}
+	for i := range db.Tables {
+		db.Tables[i].DB = db
+	}
+	return db
+}
+
+//----------------------------------------------------------------------------
+//								TestForeignKeys
+//----------------------------------------------------------------------------
+
+func TestForeignKeys(t *testing.T) {
+	var refs []DbReference
+
+	log.Printf("dbJson::TestForeignKeys()..\n")
+	sharedData.SetDebug(true)
+	db := newRefDatabase()
+
+	refs = db.Tables[0].ForeignKeys()
+	if len(refs) != 2 {
+		t.Fatalf("TestForeignKeys() should have 2 references but has %d\n", len(refs))
+	}
+	if refs[0].Name != "FK_line_1" || refs[0].Columns[0] != "num" {
+		t.Fatalf("TestForeignKeys() invalid defaults: %+v\n", refs[0])
+	}
+	if refs[1].Table != "item" || refs[1].Columns[0] != "code" {
+		t.Fatalf("TestForeignKeys() invalid field Ref: %+v\n", refs[1])
+	}
+	str := refs[0].CreateSql("invoice")
+	if str != "CONSTRAINT FK_line_1 FOREIGN KEY(invnum) REFERENCES invoice(num) ON DELETE CASCADE" {
+		t.Fatalf("TestForeignKeys() invalid sql: %s\n", str)
+	}
+
+	if db.Tables[0].LookupRef("invnum") == nil {
+		t.Fatalf("TestForeignKeys() invnum should be a lookup\n")
+	}
+	if db.Tables[0].LookupRef("seq") != nil {
+		t.Fatalf("TestForeignKeys() seq should not be a lookup\n")
+	}
+	if !db.FindTable("customer").IsReferenced() {
+		t.Fatalf("TestForeignKeys() customer should be referenced\n")
+	}
+	if db.Tables[0].IsReferenced() {
+		t.Fatalf("TestForeignKeys() line should not be referenced\n")
+	}
+	tbls := db.Tables[0].ParentTables()
+	if len(tbls) != 3 || tbls[0].Name != "customer" || tbls[1].Name != "invoice" ||
+		tbls[2].Name != "item" {
+		t.Fatalf("TestForeignKeys() invalid parent tables: %d\n", len(tbls))
+	}
+	if len(db.FindTable("customer").ParentTables()) != 0 {
+		t.Fatalf("TestForeignKeys() customer should not have parents\n")
+	}
+
+	t.Log("...end of dbJson::TestForeignKeys\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestTablesCreateOrder
+//----------------------------------------------------------------------------
+
+func TestTablesCreateOrder(t *testing.T) {
+	var tbls []*DbTable
+	var err error
+	var names []string
+
+	log.Printf("dbJson::TestTablesCreateOrder()..\n")
+	sharedData.SetDebug(true)
+	db := newRefDatabase()
+
+	if tbls, err = db.TablesCreateOrder(); err != nil {
+		t.Fatalf("TestTablesCreateOrder() failed: %s\n", err)
+	}
+	for _, tb := range tbls {
+		names = append(names, tb.Name)
+	}
+	t.Logf("\tcreate order: %v\n", names)
+	if len(names) != 4 || names[0] != "customer" || names[1] != "invoice" ||
+		names[2] != "item" || names[3] != "line" {
+		t.Fatalf("TestTablesCreateOrder() invalid order: %v\n", names)
+	}
+
+	if tbls, err = db.TablesDeleteOrder(); err != nil {
+		t.Fatalf("TestTablesCreateOrder() delete failed: %s\n", err)
+	}
+	if tbls[0].Name != "line" || tbls[3].Name != "customer" {
+		t.Fatalf("TestTablesCreateOrder() invalid delete order\n")
+	}
+
+	if p := db.Analyze(); len(p) != 0 {
+		t.Fatalf("TestTablesCreateOrder() valid data had problems:\n%s\n", p.String())
+	}
+
+	// Make a cycle.
+	db.FindTable("customer").Fields[1].Ref = "line"
+	if _, err = db.TablesCreateOrder(); err == nil {
+		t.Fatalf("TestTablesCreateOrder() cycle was not found\n")
+	}
+	p := db.Analyze()
+	t.Logf("Problems:\n%s\n", p.String())
+	if !hasProblem(p, "app", "cycle") {
+		t.Errorf("TestTablesCreateOrder() missing cycle problem\n")
+	}
+	if !hasProblem(p, "app.customer.FK_customer_1", "has 1 field(s) but references 2") {
+		t.Errorf("TestTablesCreateOrder() missing field count problem\n")
+	}
+
+	t.Log("...end of dbJson::TestTablesCreateOrder\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestAnalyzeReferences
+//----------------------------------------------------------------------------
+
+func TestAnalyzeReferences(t *testing.T) {
+
+	log.Printf("dbJson::TestAnalyzeReferences()..\n")
+	sharedData.SetDebug(true)
+	db := newRefDatabase()
+
+	db.Tables[0].References = []DbReference{
+		{Fields: []string{"invnum"}, Table: "bogus"},
+		{Fields: []string{"invnum"}, Table: "invoice", OnDelete: "set null", OnUpdate: "explode"},
+		{Fields: []string{"seq"}, Table: "customer", Columns: []string{"name"}},
+		{Fields: []string{"item"}, Table: "customer", Columns: []string{"nothere"}},
+	}
+	p := db.Analyze()
+	t.Logf("Problems:\n%s\n", p.String())
+	tests := []struct {
+		path string
+		msg  string
+	}{
+		{"app.line.FK_line_1", "not defined"},
+		{"app.line.FK_line_2", "OnUpdate"},
+		{"app.line.FK_line_2", "must be Null"},
+		{"app.line.FK_line_3", "must be the key"},
+		{"app.line.FK_line_3", "is int but"},
+		{"app.line.FK_line_4", "must be the key"},
+		{"app.line.FK_line_4", "not defined"},
+	}
+	for _, tst := range tests {
+		if !hasProblem(p, tst.path, tst.msg) {
+			t.Errorf("TestAnalyzeReferences() missing problem: %s: %s\n", tst.path, tst.msg)
+		}
+	}
+	if p.ErrorCount() != len(tests) {
+		t.Errorf("TestAnalyzeReferences() should have %d errors but has %d\n", len(tests), p.ErrorCount())
+	}
+
+	t.Log("...end of dbJson::TestAnalyzeReferences\n")
+}
@@ -0,0 +1,405 @@
+// See License.txt in main repository directory
+
+// rules contains the support for the validation rules of the fields
+// which are checked both by the browser and by the generated server.
+
+// Notes:
+//	*	The rules are given on the field such as:
+//			"Required":true, "Min":0, "Max":100, "Pattern":"[A-Z]{2}",
+//			"Enum":["open", "closed"]
+//	*	The maximum length of text fields is taken from Len.
+//	*	Pattern must match the whole value the same as it does for the
+//		HTML5 pattern attribute.
+//	*	Empty values are only checked by Required. So, Pattern and Enum
+//		do not make a field required.
+//	*	The generated test data follows Required, Len and Enum, but not
+//		Pattern, Min or Max which must allow the test values.
+
+package dbJson
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+)
+
+// HasRules returns true if the field has any validation rules given.
+func (f *DbField) HasRules() bool {
+	return f.Required || f.Min != nil || f.Max != nil || len(f.Pattern) > 0 || len(f.Enum) > 0
+}
+
+// IsNumeric returns true if the field is entered as a number.
+func (f *DbField) IsNumeric() bool {
+	return f.Typ != nil && f.Typ.Html == "number"
+}
+
+// MaxLen returns the maximum number of characters that may be entered
+// into the field or 0 if there is no limit.
+func (f *DbField) MaxLen() int {
+	if f.Typ == nil || !f.IsText() || f.IsNumeric() || f.IsDate() || f.Typ.Html == "time" {
+		return 0
+	}
+	return f.Len
+}
+
+// MinValue returns the Min rule formatted as a Go and HTML number.
+func (f *DbField) MinValue() string {
+	if f.Min == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f.Min, 'f', -1, 64)
+}
+
+// MaxValue returns the Max rule formatted as a Go and HTML number.
+func (f *DbField) MaxValue() string {
+	if f.Max == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f.Max, 'f', -1, 64)
+}
+
+// PatternRegexp returns the Go regular expression which must match the
+// whole field.
+func (f *DbField) PatternRegexp() string {
+	if len(f.Pattern) == 0 {
+		return ""
+	}
+	return "^(?:" + f.Pattern + ")$"
+}
+
+// FormAttrs returns the HTML5 validation attributes of the field's input
+// element each preceded by a space.
+func (f *DbField) FormAttrs() string {
+	var str strings.Builder
+
+	if f.Required && !f.Incr {
+		str.WriteString(" required")
+	}
+	if f.IsNumeric() {
+		if f.Min != nil {
+			fmt.Fprintf(&str, " min=\"%s\"", f.MinValue())
+		}
+		if f.Max != nil {
+			fmt.Fprintf(&str, " max=\"%s\"", f.MaxValue())
+		}
+		if f.Dec > 0 && !f.IsFloat() {
+			fmt.Fprintf(&str, " step=\"%s\"", "0."+strings.Repeat("0", f.Dec-1)+"1")
+		} else if f.IsDec() && !f.HasDecScale() {
+			str.WriteString(" step=\"any\"")
+		}
+	}
+	if n := f.MaxLen(); n > 0 {
+		fmt.Fprintf(&str, " maxlength=\"%d\"", n)
+	}
+	if len(f.Pattern) > 0 {
+		fmt.Fprintf(&str, " pattern=\"%s\"", html.EscapeString(f.Pattern))
+	} else if len(f.Enum) > 0 {
+		var alts []string
+		for _, v := range f.Enum {
+			alts = append(alts, regexp.QuoteMeta(v))
+		}
+		fmt.Fprintf(&str, " pattern=\"%s\"", html.EscapeString(strings.Join(alts, "|")))
+	}
+
+	return str.String()
+}
+
+// GenEnumList generates the slice of the Enum values.
+func (f *DbField) GenEnumList() string {
+	var vals []string
+
+	for _, v := range f.Enum {
+		vals = append(vals, strconv.Quote(v))
+	}
+	return "[]string{" + strings.Join(vals, ", ") + "}"
+}
+
+// GenPatternVar generates the package variable holding the compiled
+// Pattern of the field if it has one.
+func (f *DbField) GenPatternVar() string {
+	if len(f.Pattern) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("var pattern%s = regexp.MustCompile(%s)\n",
+		f.TitledName(), strconv.Quote(f.PatternRegexp()))
+}
+
+// GenValidate generates the code to check the rules of the field (dn)
+// adding a message for the first one broken to the map (errs). dn and
+// errs are variable names.
+func (f *DbField) GenValidate(dn, errs string) string {
+	var str strings.Builder
+	var conds [][2]string
+
+	// The rules of a NULL field are only checked if it is not NULL.
+	v := f.ValueName(dn)
+	add := func(msg, format string, a ...interface{}) {
+		if f.IsNull() {
+			format = f.GenNotNull(dn) + " && " + format
+		}
+		conds = append(conds, [2]string{fmt.Sprintf(format, a...), msg})
+	}
+	if f.IsNull() && f.Required {
+		conds = append(conds, [2]string{f.GenIsNull(dn), "is required"})
+	}
+
+	switch {
+	case f.IsDec():
+		for _, r := range f.decRules(v) {
+			add(r[1], "%s", r[0])
+		}
+	case f.IsNumeric():
+		if f.Min != nil {
+			add("must be at least "+f.MinValue(), "float64(%s) < %s", v, f.MinValue())
+		}
+		if f.Max != nil {
+			add("must be at most "+f.MaxValue(), "float64(%s) > %s", v, f.MaxValue())
+		}
+	case f.IsJson():
+		if f.Required {
+			add("is required", "len(strings.TrimSpace(%s)) == 0", v)
+		}
+		add("must be JSON", "len(strings.TrimSpace(%s)) > 0 && !json.Valid([]byte(%s))", v, v)
+	case f.IsText():
+		if f.Required {
+			add("is required", "len(strings.TrimSpace(%s)) == 0", v)
+		}
+		if n := f.MaxLen(); n > 0 {
+			add(fmt.Sprintf("must be at most %d characters", n), "len([]rune(%s)) > %d", v, n)
+		}
+		if len(f.Pattern) > 0 {
+			add("is not in the required format", "len(%s) > 0 && !pattern%s.MatchString(%s)",
+				v, f.TitledName(), v)
+		}
+		if len(f.Enum) > 0 {
+			var alts []string
+			for _, e := range f.Enum {
+				alts = append(alts, fmt.Sprintf("%s == %s", v, strconv.Quote(e)))
+			}
+			add("must be one of "+strings.Join(f.Enum, ", "), "len(%s) > 0 && !(%s)",
+				v, strings.Join(alts, " || "))
+		}
+	case f.GoType() == "time.Time":
+		if f.Required {
+			add("is required", "%s.IsZero()", v)
+		}
+	case f.IsBlob():
+		if f.Required {
+			add("is required", "len(%s) == 0", v)
+		}
+	}
+
+	for i, c := range conds {
+		if i == 0 {
+			fmt.Fprintf(&str, "\tif %s {\n", c[0])
+		} else {
+			fmt.Fprintf(&str, "\t} else if %s {\n", c[0])
+		}
+		fmt.Fprintf(&str, "\t\t%s[%q] = %s\n", errs, f.TitledName(), strconv.Quote(c[1]))
+	}
+	if len(conds) > 0 {
+		str.WriteString("\t}\n")
+	}
+
+	return str.String()
+}
+
+// GenFromFormString generates the code to go from a form value string
+// (sn) to a field of (dn) adding a message to the map (errs) if the
+// string can not be converted. sn, dn and errs are variable names and
+// err must be an error variable. An empty string gives NULL if the field
+// may be NULL.
+func (f *DbField) GenFromFormString(dn, sn, errs string) string {
+	return f.genNullFrom(dn+"."+f.TitledName(), sn, func(vn string) string {
+		return f.genFromFormString(vn, sn, errs)
+	})
+}
+
+// genFromFormString generates the code to go from a form value string
+// (sn) to the variable (vn) of the field's Go type.
+func (f *DbField) genFromFormString(vn, sn, errs string) string {
+	var str strings.Builder
+	var parse string
+	var msg string
+	var ind string
+
+	fn := f.TitledName()
+	switch f.GoType() {
+	case "int", "int32", "int64":
+		parse = fmt.Sprintf("%s, err = strconv.ParseInt(%s, 0, 64)", vn, sn)
+		msg = "must be a whole number"
+	case "float64":
+		parse = fmt.Sprintf("%s, err = strconv.ParseFloat(%s, 64)", vn, sn)
+		msg = "must be a number"
+	case "decimal.Decimal":
+		parse = fmt.Sprintf("%s, err = decimal.NewFromString(%s)", vn, sn)
+		msg = "must be a number"
+	case "bool":
+		// Checkboxes are only sent when they are checked.
+		parse = fmt.Sprintf("%s, err = strconv.ParseBool(%s)", vn, sn)
+		msg = "must be true or false"
+	case "[]byte":
+		// Files are read by GenFromFormFile() otherwise the API sends base64.
+		parse = fmt.Sprintf("%s, err = base64.StdEncoding.DecodeString(%s)", vn, sn)
+		msg = "must be base64"
+	case "time.Time":
+		// Browsers send the HTML5 layout, but the API uses RFC3339.
+		msg = "must be a date"
+		if f.Typ.Html == "time" {
+			msg = "must be a time"
+		}
+	default:
+		fmt.Fprintf(&str, "\t%s = %s\n", vn, sn)
+		return str.String()
+	}
+
+	// NULL fields are only converted if the string is not empty.
+	if f.IsNull() {
+		fmt.Fprintf(&str, "\t%s = strings.TrimSpace(%s)\n", sn, sn)
+	} else {
+		fmt.Fprintf(&str, "\tif %s = strings.TrimSpace(%s); len(%s) > 0 {\n", sn, sn, sn)
+		ind = "\t"
+	}
+	if f.GoType() == "time.Time" {
+		fmt.Fprintf(&str, "%s\tif %s, err = time.Parse(time.RFC3339, %s); err != nil {\n", ind, vn, sn)
+		fmt.Fprintf(&str, "%s\t\tif %s, err = time.Parse(%q, %s); err != nil {\n", ind, vn, f.FormLayout(), sn)
+		fmt.Fprintf(&str, "%s\t\t\t%s[%q] = %q\n", ind, errs, fn, msg)
+		fmt.Fprintf(&str, "%s\t\t}\n", ind)
+	} else {
+		fmt.Fprintf(&str, "%s\tif %s; err != nil {\n", ind, parse)
+		fmt.Fprintf(&str, "%s\t\t%s[%q] = %q\n", ind, errs, fn, msg)
+	}
+	fmt.Fprintf(&str, "%s\t}\n", ind)
+	if f.IsNull() {
+		return str.String()
+	}
+	if f.Required && !f.Incr && !f.IsBool() && f.GoType() != "time.Time" {
+		str.WriteString("\t} else {\n")
+		fmt.Fprintf(&str, "\t\t%s[%q] = %q\n", errs, fn, "is required")
+	}
+	str.WriteString("\t}\n")
+
+	return str.String()
+}
+
+// GenFromFormFile generates the code to go from the file of a form (rn)
+// to a blob field of (dn) or, if no file was sent, from its form value
+// the same as GenFromFormString() using sn. rn, sn, dn and errs are
+// variable names and err must be an error variable.
+func (f *DbField) GenFromFormFile(dn, rn, sn, errs string) string {
+	var str strings.Builder
+
+	fn := f.TitledName()
+	fmt.Fprintf(&str, "\tif file, _, err := %s.FormFile(%q); err == nil {\n", rn, fn)
+	fmt.Fprintf(&str, "\t\t%s.%s, err = ioutil.ReadAll(file)\n", dn, fn)
+	str.WriteString("\t\tfile.Close()\n")
+	str.WriteString("\t\tif err != nil {\n")
+	fmt.Fprintf(&str, "\t\t\t%s[%q] = %q\n", errs, fn, "could not be read")
+	str.WriteString("\t\t}\n")
+	str.WriteString("\t} else {\n")
+	fmt.Fprintf(&str, "\t%s = %s.FormValue(%q)\n", sn, rn, fn)
+	str.WriteString(f.GenFromFormString(dn, sn, errs))
+	str.WriteString("\t}\n")
+
+	return str.String()
+}
+
+// FormLayout returns the time layout that the field's input element
+// uses for its value.
+func (f *DbField) FormLayout() string {
+
+	switch f.Typ.Html {
+	case "date":
+		return "2006-01-02"
+	case "time":
+		return "15:04"
+	}
+
+	return "2006-01-02T15:04"
+}
+
+// HasRules returns true if any field of the table has validation rules.
+func (t *DbTable) HasRules() bool {
+	for i := range t.Fields {
+		if t.Fields[i].HasRules() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPatterns returns true if any field of the table has a Pattern.
+func (t *DbTable) HasPatterns() bool {
+	for i := range t.Fields {
+		if len(t.Fields[i].Pattern) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeRules checks the validation rules of the fields of one table.
+func (d *Database) analyzeRules(t *DbTable, tblPath string, plg dbPlugin.PluginData,
+	p *Problems) {
+	var isNumeric bool
+	var isText bool
+
+	for j := range t.Fields {
+		f := &t.Fields[j]
+		fldPath := fmt.Sprintf("%s.%s", tblPath, f.Name)
+		if !f.HasRules() {
+			continue
+		}
+		if plg.Types != nil {
+			td := plg.Types.FindDefn(f.TypeDefn)
+			if td == nil {
+				continue // reported by analyzeTable
+			}
+			isNumeric = td.Html == "number"
+			isText = td.IsText() && !isNumeric && !f.IsDate() && td.Html != "time"
+		} else {
+			isNumeric = true
+			isText = true
+		}
+
+		if f.Required && f.Incr {
+			p.AddError(fldPath, "Required is not allowed on an Incr field")
+		}
+		if (f.Min != nil || f.Max != nil) && !isNumeric {
+			p.AddError(fldPath, "Min and Max are only allowed on a numeric field, not %s", f.TypeDefn)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			p.AddError(fldPath, "Min, %s, is larger than Max, %s", f.MinValue(), f.MaxValue())
+		}
+		if len(f.Pattern) > 0 {
+			if !isText {
+				p.AddError(fldPath, "Pattern is only allowed on a text field, not %s", f.TypeDefn)
+			}
+			if _, err := regexp.Compile(f.PatternRegexp()); err != nil {
+				p.AddError(fldPath, "Pattern, %s, is not valid: %s", f.Pattern, err.Error())
+			}
+		}
+		if len(f.Enum) > 0 {
+			if !isText {
+				p.AddError(fldPath, "Enum is only allowed on a text field, not %s", f.TypeDefn)
+			}
+			vals := map[string]bool{}
+			for _, v := range f.Enum {
+				if len(v) == 0 {
+					p.AddError(fldPath, "Enum has an empty value")
+				} else if f.Len > 0 && len([]rune(v)) > f.Len {
+					p.AddError(fldPath, "Enum value, %s, is longer than Len, %d", v, f.Len)
+				}
+				if vals[v] {
+					p.AddError(fldPath, "Enum value, %s, is duplicated", v)
+				}
+				vals[v] = true
+			}
+			f.analyzeEnum(fldPath, p)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the field validation rules support
+
+package dbJson
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"genapp/pkg/genSqlAppGo/dbType"
+)
+
+//----------------------------------------------------------------------------
+//								TestRules
+//----------------------------------------------------------------------------
+
+func TestRules(t *testing.T) {
+	var min = 0.0
+	var max = 100.0
+
+	log.Printf("dbJson::TestRules()..\n")
+	db := newRefDatabase()
+	tb := db.FindTable("customer")
+	tb.Fields = append(tb.Fields,
+		DbField{Name: "balance", TypeDefn: "money", Len: 9, Dec: 2, Min: &min},
+		DbField{Name: "score", TypeDefn: "int", Min: &min, Max: &max},
+		DbField{Name: "state", TypeDefn: "text", Len: 2, Pattern: "[A-Z]{2}"},
+		DbField{Name: "status", TypeDefn: "text", Len: 6, Enum: []string{"open", "closed"}},
+	)
+	for i := range tb.Fields {
+		tb.Fields[i].Typ = dbType.DefaultTable.FindDefn(tb.Fields[i].TypeDefn)
+	}
+	tb.FindField("name").Required = true
+
+	if tb.FindField("num").HasRules() || !tb.HasRules() || !tb.HasPatterns() {
+		t.Errorf("TestRules() invalid HasRules() or HasPatterns()\n")
+	}
+
+	attrs := []struct {
+		fld   string
+		attrs string
+	}{
+		{"num", ""},
+		{"name", ` required maxlength="30"`},
+		{"balance", ` min="0" step="0.01"`},
+		{"score", ` min="0" max="100"`},
+		{"state", ` maxlength="2" pattern="[A-Z]{2}"`},
+		{"status", ` maxlength="6" pattern="open|closed"`},
+	}
+	for _, tst := range attrs {
+		if str := tb.FindField(tst.fld).FormAttrs(); str != tst.attrs {
+			t.Errorf("TestRules() %s attributes should be %q but are %q\n", tst.fld, tst.attrs, str)
+		}
+	}
+	if str := tb.FindField("name").FormInput(); !strings.HasSuffix(str, `value="{{.Rcd.Name}}" required maxlength="30">`) {
+		t.Errorf("TestRules() invalid form input: %s\n", str)
+	}
+
+	validates := []struct {
+		fld  string
+		code []string
+	}{
+		{"num", nil},
+		{"name", []string{`if len(strings.TrimSpace(s.Name)) == 0 {`, `errs["Name"] = "is required"`,
+			`} else if len([]rune(s.Name)) > 30 {`}},
+		{"balance", []string{`if !s.Balance.Equal(s.Balance.Truncate(2)) {`,
+			`} else if s.Balance.LessThan(decimal.RequireFromString("0")) {`}},
+		{"score", []string{`if float64(s.Score) < 0 {`, `} else if float64(s.Score) > 100 {`}},
+		{"state", []string{`!patternState.MatchString(s.State)`}},
+		{"status", []string{`!(s.Status == "open" || s.Status == "closed")`}},
+	}
+	for _, tst := range validates {
+		str := tb.FindField(tst.fld).GenValidate("s", "errs")
+		if len(tst.code) == 0 && len(str) > 0 {
+			t.Errorf("TestRules() %s should not generate validation:\n%s\n", tst.fld, str)
+		}
+		for _, c := range tst.code {
+			if !strings.Contains(str, c) {
+				t.Errorf("TestRules() %s validation is missing %q:\n%s\n", tst.fld, c, str)
+			}
+		}
+	}
+	if str := tb.FindField("state").GenPatternVar(); str != "var patternState = regexp.MustCompile(\"^(?:[A-Z]{2})$\")\n" {
+		t.Errorf("TestRules() invalid pattern variable: %s\n", str)
+	}
+	if str := tb.FindField("score").GenFromFormString("s", "str", "errs"); !strings.Contains(str, `errs["Score"] = "must be a whole number"`) {
+		t.Errorf("TestRules() invalid form conversion:\n%s\n", str)
+	}
+	if str := tb.FindField("score").ApiSchema(0); !strings.Contains(str, "minimum: 0\nmaximum: 100") {
+		t.Errorf("TestRules() invalid API schema:\n%s\n", str)
+	}
+
+	p := db.Analyze()
+	if p.Err() != nil {
+		t.Errorf("TestRules() valid rules had problems:\n%s\n", p.String())
+	}
+	tb.FindField("name").Min = &max
+	tb.FindField("score").Min = &max
+	tb.FindField("score").Max = &min
+	tb.FindField("state").Pattern = "[A-Z"
+	tb.FindField("status").Enum = append(tb.FindField("status").Enum, "suspended")
+	p = db.Analyze()
+	for _, msg := range []string{"only allowed on a numeric field", "larger than Max",
+		"Pattern, [A-Z, is not valid", "longer than Len"} {
+		if !strings.Contains(p.String(), msg) {
+			t.Errorf("TestRules() problem %q was not found:\n%s\n", msg, p.String())
+		}
+	}
+
+	t.Log("...end of dbJson::TestRules\n")
+}
@@ -0,0 +1,259 @@
+// See License.txt in main repository directory
+
+// types contains the support for the types defined by the database
+// definition ("Types") which are added to the plugin's type definitions
+// or override them.
+
+// Notes:
+//	*	A type is given such as:
+//			"Types":[
+//				{"Name":"sku", "Base":"text", "Len":12, "Pattern":"[A-Z0-9]+"},
+//				{"Name":"percent", "Base":"dec", "Len":5, "Dec":2, "Min":0, "Max":100},
+//				{"Name":"percent", "SqlType":"postgres", "Sql":"NUMERIC"}
+//			]
+//	*	A new type starts as a copy of its Base which must be a plugin type
+//		or a type defined before it. Without a Base, Sql, Go and Html must
+//		all be given. A type of an existing name overrides it.
+//	*	An entry with a SqlType only applies to that SQL Server. So, the
+//		SQL of a type can be changed for just one of them.
+//	*	Len, Dec and the rules are the defaults of the fields of the type
+//		which do not give their own.
+//	*	The plugin's table is not changed. SetupPlugin() saves a merged copy
+//		in the Database's plugin data.
+
+package dbJson
+
+import (
+	"fmt"
+	"strings"
+
+	"genapp/pkg/genSqlAppGo/dbPlugin"
+	"genapp/pkg/genSqlAppGo/dbType"
+)
+
+// GoTypes are the Go types that a type may be kept as.
+var GoTypes = []string{"string", "int64", "float64", "bool", "[]byte", "time.Time", "decimal.Decimal"}
+
+// DbType defines a type of the database definition.
+type DbType struct {
+	Name    string   `json:"Name,omitempty"`    // Type Name
+	Base    string   `json:"Base,omitempty"`    // Type that this one is copied from
+	SqlType string   `json:"SqlType,omitempty"` // Only used for this SQL Server if given
+	Html    string   `json:"Html,omitempty"`    // HTML Type
+	Sql     string   `json:"Sql,omitempty"`     // SQL Type
+	Go      string   `json:"Go,omitempty"`      // GO Type
+	Len     int      `json:"Len,omitempty"`     // Default Length
+	Dec     int      `json:"Dec,omitempty"`     // Default Decimal Positions
+	Min     *float64 `json:"Min,omitempty"`     // Default Minimum numeric value
+	Max     *float64 `json:"Max,omitempty"`     // Default Maximum numeric value
+	Pattern string   `json:"Pattern,omitempty"` // Default regular expression
+	Enum    []string `json:"Enum,omitempty"`    // Default values permitted
+}
+
+// appliesTo returns true if the type is used for the given SQL Server.
+func (y *DbType) appliesTo(sqlType string) bool {
+	return len(y.SqlType) == 0 || strings.EqualFold(y.SqlType, sqlType)
+}
+
+// overlay sets the values given in y within the type definition (td) and
+// the defaults of the fields (dft).
+func (y *DbType) overlay(td *dbType.TypeDefn, dft *DbType) {
+
+	if len(y.Html) > 0 {
+		td.Html = y.Html
+	}
+	if len(y.Sql) > 0 {
+		td.Sql = y.Sql
+	}
+	if len(y.Go) > 0 {
+		td.Go = y.Go
+	}
+	if y.Len > 0 {
+		td.DftLen = y.Len
+		dft.Len = y.Len
+	}
+	if y.Dec > 0 {
+		td.DftDec = y.Dec
+		dft.Dec = y.Dec
+	}
+	if y.Min != nil {
+		dft.Min = y.Min
+	}
+	if y.Max != nil {
+		dft.Max = y.Max
+	}
+	if len(y.Pattern) > 0 {
+		dft.Pattern = y.Pattern
+	}
+	if len(y.Enum) > 0 {
+		dft.Enum = y.Enum
+	}
+}
+
+// mergeTypes returns a copy of the type definitions (tds) with the Types
+// which apply to the database's SqlType merged into it and the defaults
+// of the fields for each of the Types. Types whose Base is not defined
+// before them are skipped.
+func (d *Database) mergeTypes(tds *dbType.TypeDefns) (*dbType.TypeDefns, map[string]*DbType) {
+	var merged dbType.TypeDefns
+	dfts := map[string]*DbType{}
+
+	if tds != nil {
+		merged = append(merged, *tds...)
+	}
+	for i := range d.Types {
+		y := &d.Types[i]
+		if len(y.Name) == 0 || !y.appliesTo(d.SqlType) {
+			continue
+		}
+		nt := dbType.TypeDefn{Name: y.Name}
+		dft := &DbType{Name: y.Name}
+		if len(y.Base) > 0 {
+			base := merged.FindDefn(y.Base)
+			if base == nil || y.Base == y.Name {
+				continue
+			}
+			nt = *base
+			nt.Name = y.Name
+			nt.Base = base.BaseName()
+			if bd := dfts[y.Base]; bd != nil {
+				*dft = *bd
+				dft.Name = y.Name
+			}
+		} else if dfts[y.Name] != nil {
+			dft = dfts[y.Name]
+		}
+		td := merged.FindDefn(y.Name)
+		switch {
+		case td == nil:
+			merged = append(merged, nt)
+			td = &merged[len(merged)-1]
+		case len(y.Base) > 0:
+			*td = nt
+		}
+		dfts[y.Name] = dft
+		y.overlay(td, dft)
+	}
+
+	return &merged, dfts
+}
+
+// TypeDefns returns the type definitions (tds) of the database's plugin
+// with the Types merged into them.
+func (d *Database) TypeDefns(tds *dbType.TypeDefns) *dbType.TypeDefns {
+	merged, _ := d.mergeTypes(tds)
+	return merged
+}
+
+// applyTypes sets the Len, Dec and rules of the fields of the Types which
+// do not give their own.
+func (d *Database) applyTypes(dfts map[string]*DbType) {
+
+	for i := range d.Tables {
+		for j := range d.Tables[i].Fields {
+			f := &d.Tables[i].Fields[j]
+			dft := dfts[f.TypeDefn]
+			if dft == nil {
+				continue
+			}
+			if f.Len == 0 {
+				f.Len = dft.Len
+				if f.Dec == 0 {
+					f.Dec = dft.Dec
+				}
+			}
+			if f.Min == nil {
+				f.Min = dft.Min
+			}
+			if f.Max == nil {
+				f.Max = dft.Max
+			}
+			if len(f.Pattern) == 0 {
+				f.Pattern = dft.Pattern
+			}
+			if len(f.Enum) == 0 {
+				f.Enum = dft.Enum
+			}
+		}
+	}
+}
+
+// analyzeTypes checks the Types. plg is the plugin of the database whose
+// types have not been merged.
+func (d *Database) analyzeTypes(dbPath string, plg dbPlugin.PluginData, p *Problems) {
+	var known dbType.TypeDefns
+
+	if len(d.Types) == 0 {
+		return
+	}
+	if plg.Types != nil {
+		known = append(known, *plg.Types...)
+	}
+	for i := range d.Types {
+		y := &d.Types[i]
+		typPath := fmt.Sprintf("%s.Types.%s", dbPath, y.Name)
+		if len(y.Name) == 0 {
+			typPath = fmt.Sprintf("%s.Types[%d]", dbPath, i)
+			p.AddError(typPath, "type name is missing")
+			continue
+		}
+		if len(y.SqlType) > 0 {
+			if _, err := dbPlugin.FindPlugin(y.SqlType); err != nil {
+				p.AddError(typPath, "SqlType, %s, is not supported", y.SqlType)
+			}
+		}
+		if len(y.Go) > 0 && !stringIn(y.Go, GoTypes) {
+			p.AddError(typPath, "Go, %s, is not one of %s", y.Go, strings.Join(GoTypes, ", "))
+		}
+		if !y.appliesTo(d.SqlType) || plg.Types == nil {
+			continue
+		}
+		switch {
+		case y.Base == y.Name:
+			p.AddError(typPath, "Base, %s, may not be the type itself", y.Base)
+		case len(y.Base) > 0 && known.FindDefn(y.Base) == nil:
+			p.AddError(typPath, "Base, %s, is not a type defined before it", y.Base)
+		case len(y.Base) == 0 && known.FindDefn(y.Name) == nil &&
+			(len(y.Sql) == 0 || len(y.Go) == 0 || len(y.Html) == 0):
+			p.AddError(typPath, "Base or Sql, Go and Html are required for a new type")
+		}
+		if known.FindDefn(y.Name) == nil {
+			known = append(known, dbType.TypeDefn{Name: y.Name})
+		}
+	}
+
+	// The defaults are checked the same as the rules of a field of the type.
+	if plg.Types == nil {
+		return
+	}
+	tds, dfts := d.mergeTypes(plg.Types)
+	t := &DbTable{Name: "Types", DB: d}
+	for i := range d.Types {
+		dft := dfts[d.Types[i].Name]
+		if dft == nil || t.FindField(dft.Name) != nil {
+			continue
+		}
+		t.Fields = append(t.Fields, DbField{Name: dft.Name, TypeDefn: dft.Name, Len: dft.Len,
+			Dec: dft.Dec, Min: dft.Min, Max: dft.Max, Pattern: dft.Pattern, Enum: dft.Enum})
+	}
+	for i := range t.Fields {
+		t.Fields[i].Tbl = t
+		t.Fields[i].Typ = tds.FindDefn(t.Fields[i].TypeDefn)
+		if f := &t.Fields[i]; f.Dec > f.Len {
+			p.AddError(fmt.Sprintf("%s.Types.%s", dbPath, f.Name), "Dec, %d, is larger than Len, %d",
+				f.Dec, f.Len)
+		}
+	}
+	plg.Types = tds
+	d.analyzeRules(t, dbPath+".Types", plg, p)
+}
+
+// stringIn returns true if str is one of the strings in list.
+func stringIn(str string, list []string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
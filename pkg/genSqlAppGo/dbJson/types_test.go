@@ -0,0 +1,142 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test the types defined by the database definition
+
+package dbJson
+
+import (
+	"log"
+	"testing"
+
+	"genapp/pkg/genSqlAppGo/dbType"
+)
+
+// newTypesDatabase returns a database using the given Types.
+func newTypesDatabase(types []DbType) *Database {
+	var zero = 0.0
+
+	db := &Database{Name: "app", SqlType: "analyze", Types: types,
+		Tables: []DbTable{
+			{Name: "item",
+				Fields: []DbField{
+					{Name: "code", TypeDefn: "sku", KeyNum: 1},
+					{Name: "alt", TypeDefn: "sku", Len: 20},
+					{Name: "pct", TypeDefn: "percent"},
+					{Name: "disc", TypeDefn: "percent", Min: &zero},
+				},
+			},
+		},
+	}
+	for i := range db.Tables {
+		db.Tables[i].DB = db
+		for j := range db.Tables[i].Fields {
+			db.Tables[i].Fields[j].Tbl = &db.Tables[i]
+		}
+	}
+
+	return db
+}
+
+//----------------------------------------------------------------------------
+//								TestTypes
+//----------------------------------------------------------------------------
+
+func TestTypes(t *testing.T) {
+	var min = 0.0
+	var max = 100.0
+	var ten = 10.0
+
+	log.Printf("dbJson::TestTypes()..\n")
+	db := newTypesDatabase([]DbType{
+		{Name: "sku", Base: "text", Len: 12, Pattern: "[A-Z0-9]+"},
+		{Name: "percent", Base: "dec", Len: 5, Dec: 2, Min: &min, Max: &max},
+		{Name: "percent", SqlType: "analyze", Sql: "NUMERIC"},
+	})
+	db.Tables[0].Fields[3].Min = &ten
+
+	if p := db.Analyze(); p.Err() != nil {
+		t.Fatalf("TestTypes() valid types had problems:\n%s\n", p.String())
+	}
+	// The plugins can not be imported here. So, postgres is not analyzed.
+	db.Types = append(db.Types, DbType{Name: "percent", SqlType: "postgres", Sql: "REAL", Min: &ten})
+	if err := db.SetupPlugin(); err != nil {
+		t.Fatalf("TestTypes() SetupPlugin() failed: %s\n", err)
+	}
+	if dbType.DefaultTable.FindDefn("sku") != nil {
+		t.Errorf("TestTypes() the plugin's types should not be changed\n")
+	}
+
+	tb := &db.Tables[0]
+	f := tb.FindField("code")
+	if f.Typ == nil || f.Typ.Sql != "VARCHAR" || f.Typ.Base != "text" || !f.IsText() ||
+		f.Len != 12 || f.Pattern != "[A-Z0-9]+" || f.MaxLen() != 12 {
+		t.Errorf("TestTypes() invalid sku field: %+v %+v\n", f, f.Typ)
+	}
+	if f = tb.FindField("alt"); f.Len != 20 {
+		t.Errorf("TestTypes() a field's Len should be kept, but is %d\n", f.Len)
+	}
+	f = tb.FindField("pct")
+	if f.Typ == nil || f.Typ.Sql != "NUMERIC" || f.GoType() != "decimal.Decimal" || !f.IsDec() ||
+		f.BaseType() != "dec" || f.Len != 5 || f.Dec != 2 || *f.Min != 0 || *f.Max != 100 {
+		t.Errorf("TestTypes() invalid percent field: %+v %+v\n", f, f.Typ)
+	}
+	if str := f.ColumnType(); str != "NUMERIC(5,2)" {
+		t.Errorf("TestTypes() invalid column type: %s\n", str)
+	}
+	if f = tb.FindField("disc"); *f.Min != 10 || *f.Max != 100 {
+		t.Errorf("TestTypes() a field's rules should be kept: %v %v\n", *f.Min, *f.Max)
+	}
+
+	// The types of another SQL Server are not used.
+	db.SqlType = "postgres"
+	if td := db.TypeDefns(&dbType.DefaultTable).FindDefn("percent"); td == nil || td.Sql != "REAL" {
+		t.Errorf("TestTypes() invalid postgres type: %+v\n", td)
+	}
+
+	t.Log("dbJson::TestTypes: end of test\n")
+}
+
+//----------------------------------------------------------------------------
+//								TestAnalyzeTypes
+//----------------------------------------------------------------------------
+
+func TestAnalyzeTypes(t *testing.T) {
+	var min = 5.0
+	var max = 1.0
+
+	log.Printf("dbJson::TestAnalyzeTypes()..\n")
+	db := newTypesDatabase([]DbType{
+		{Base: "text"},
+		{Name: "sku", Base: "serial"},
+		{Name: "percent", Sql: "NUMERIC"},
+		{Name: "code", Base: "text", Go: "uint8", SqlType: "bogus"},
+		{Name: "flag", Base: "bool", Pattern: "[yn]"},
+		{Name: "score", Base: "int", Min: &min, Max: &max},
+		{Name: "state", Base: "text", Len: 2, Dec: 3, Enum: []string{"open"}},
+	})
+
+	p := db.Analyze()
+	tests := []struct {
+		path string
+		msg  string
+	}{
+		{"app.Types[0]", "type name is missing"},
+		{"app.Types.sku", "Base, serial, is not a type defined before it"},
+		{"app.Types.percent", "Base or Sql, Go and Html are required for a new type"},
+		{"app.Types.code", "SqlType, bogus, is not supported"},
+		{"app.Types.code", "Go, uint8, is not one of"},
+		{"app.Types.flag", "Pattern is only allowed on a text field"},
+		{"app.Types.score", "Min, 5, is larger than Max, 1"},
+		{"app.Types.state", "Dec, 3, is larger than Len, 2"},
+		{"app.Types.state", "longer than Len"},
+		{"app.item.code", "TypeDef, sku, is not defined for analyze"},
+	}
+	for _, tst := range tests {
+		if !hasProblem(p, tst.path, tst.msg) {
+			t.Errorf("TestAnalyzeTypes() missing %s: %s:\n%s\n", tst.path, tst.msg, p.String())
+		}
+	}
+
+	t.Log("dbJson::TestAnalyzeTypes: end of test\n")
+}
@@ -36,7 +36,6 @@ import (
 	"genapp/pkg/genSqlAppGo/dbJson"
 	"genapp/pkg/genSqlAppGo/dbPlugin"
 	"genapp/pkg/genSqlAppGo/dbType"
-	"genapp/pkg/sharedData"
 	"log"
 	"strings"
 
@@ -44,22 +43,32 @@ import (
 )
 
 // Notes:
-//	* We are now using a Decimal Package for support of decimal operations including
-//		monetary calculations via https://github.com/ericlagergren/decimal
+//	* The decimal types are kept as decimal.Decimal from
+//		https://github.com/shopspring/decimal so that monetary calculations are exact.
+//	* The driver returns a UNIQUEIDENTIFIER as its 16 bytes so the generated i/o
+//		reads it into its string through a scanner (see DbTable.ScanNameList()).
 var tds = dbType.TypeDefns{
 	{Name: "date", Html: "date", Sql: "DATE", Go: "time.Time", DftLen: 0},
 	{Name: "datetime", Html: "datetime", Sql: "DATETIME", Go: "time.Time", DftLen: 0},
 	{Name: "email", Html: "email", Sql: "VARCHAR", Go: "string", DftLen: 50},
-	{Name: "dec", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
-	{Name: "decimal", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
+	{Name: "dec", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
+	{Name: "decimal", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "int", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "integer", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
-	{Name: "money", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
+	{Name: "money", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "number", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "tel", Html: "tel", Sql: "VARCHAR", Go: "string", DftLen: 19}, //+nnn (nnn) nnn-nnnn
 	{Name: "text", Html: "text", Sql: "NVARCHAR", Go: "string", DftLen: 0},
 	{Name: "time", Html: "time", Sql: "TIME", Go: "time.Time", DftLen: 0},
 	{Name: "url", Html: "url", Sql: "TEXT", Go: "string", DftLen: 50},
+	{Name: "bigint", Html: "number", Sql: "BIGINT", Go: "int64", DftLen: 0},
+	{Name: "blob", Html: "file", Sql: "VARBINARY(MAX)", Go: "[]byte", DftLen: 0},
+	{Name: "bool", Html: "checkbox", Sql: "BIT", Go: "bool", DftLen: 0},
+	{Name: "boolean", Html: "checkbox", Sql: "BIT", Go: "bool", DftLen: 0},
+	{Name: "float", Html: "number", Sql: "FLOAT", Go: "float64", DftLen: 0},
+	{Name: "json", Html: "text", Sql: "NVARCHAR(MAX)", Go: "string", DftLen: 0},
+	{Name: "timestamptz", Html: "datetime-local", Sql: "DATETIMEOFFSET", Go: "time.Time", DftLen: 0},
+	{Name: "uuid", Html: "text", Sql: "UNIQUEIDENTIFIER", Go: "string", DftLen: 36},
 }
 
 //----------------------------------------------------------------------------
@@ -180,6 +189,75 @@ func (pd *Plugin) GenExecErrorCheck(db *dbJson.Database) string {
 	return str.String()
 }
 
+// GenFieldAddStmts generates the statements to add a column. T-SQL does
+// not use COLUMN in ALTER TABLE ADD.
+func (pd *Plugin) GenFieldAddStmts(c *dbJson.DbChange) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s%s ADD %s", c.New.DB.Schema, c.New.TitledName(),
+		c.NewField.ColumnDefn())}
+}
+
+// GenFieldAlterStmts generates the statements to alter a column. T-SQL
+// requires the type and nullability to be given together. The CHECK
+// constraint of an enumerated field must be dropped before the column is
+// altered and is added again with the new values.
+func (pd *Plugin) GenFieldAlterStmts(c *dbJson.DbChange) []string {
+	var stmts []string
+
+	tn := c.New.DB.Schema + c.New.TitledName()
+	of := c.OldField.InTable(c.New)
+	of.Name = c.NewField.Name
+	if of.IsEnum() {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tn, of.EnumCheckName()))
+	}
+	nl := "NOT NULL"
+	if c.NewField.Nullable {
+		nl = "NULL"
+	}
+	stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s", tn,
+		c.NewField.Name, c.NewField.ColumnType(), nl))
+	if c.NewField.IsEnum() {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD %s", tn, c.NewField.EnumCheckSql()))
+	}
+
+	return stmts
+}
+
+// GenFieldDropStmts generates the statements to drop a column. T-SQL does
+// not drop a column with a CHECK constraint, so that of an enumerated
+// field is dropped first.
+func (pd *Plugin) GenFieldDropStmts(c *dbJson.DbChange) []string {
+	var stmts []string
+
+	tn := c.New.DB.Schema + c.New.TitledName()
+	if c.OldField.IsEnum() {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tn,
+			c.OldField.InTable(c.New).EnumCheckName()))
+	}
+	stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", tn, c.OldField.Name))
+
+	return stmts
+}
+
+// GenFieldRenameStmts generates the statements to rename a column which
+// T-SQL does with sp_rename. The CHECK constraint of an enumerated field
+// is renamed with it.
+func (pd *Plugin) GenFieldRenameStmts(c *dbJson.DbChange) []string {
+	stmts := []string{fmt.Sprintf("EXEC sp_rename '%s%s.%s', '%s', 'COLUMN'", c.New.DB.Schema,
+		c.New.TitledName(), c.OldField.Name, c.NewField.Name)}
+	return append(stmts, genCheckRenameStmts(c.OldField.InTable(c.New), c.NewField)...)
+}
+
+// genCheckRenameStmts generates the statement which renames the CHECK
+// constraint of an enumerated field (of) to its name as the other field
+// (nf) if its name changed.
+func genCheckRenameStmts(of, nf *dbJson.DbField) []string {
+	if !of.IsEnum() || of.EnumCheckName() == nf.EnumCheckName() {
+		return nil
+	}
+	return []string{fmt.Sprintf("EXEC sp_rename '%s%s', '%s', 'OBJECT'", of.Tbl.DB.Schema,
+		of.EnumCheckName(), nf.EnumCheckName())}
+}
+
 // GenFlagArgDefns generates a string that defines the various CLI options to allow the
 // user to modify the connection string parameters for the Database connection.
 func (pd *Plugin) GenFlagArgDefns(name string) string {
@@ -212,6 +290,26 @@ func (pd *Plugin) GenImportString() string {
 	return "\"github.com/denisenkom/go-mssqldb\""
 }
 
+// GenIndexAddStmts generates the statements to add an index to an
+// existing table.
+func (pd *Plugin) GenIndexAddStmts(c *dbJson.DbChange) []string {
+	tn := c.New.DB.Schema + c.New.TitledName()
+	if c.NewIndex.IsConstraint() {
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD %s", tn, c.NewIndex.ConstraintSql())}
+	}
+	return []string{c.NewIndex.CreateSql(tn)}
+}
+
+// GenIndexDropStmts generates the statements to drop an index. T-SQL
+// requires the table of the index.
+func (pd *Plugin) GenIndexDropStmts(c *dbJson.DbChange) []string {
+	tn := c.New.DB.Schema + c.New.TitledName()
+	if c.OldIndex.IsConstraint() {
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tn, c.OldIndex.Name)}
+	}
+	return []string{fmt.Sprintf("DROP INDEX %s ON %s", c.OldIndex.Name, tn)}
+}
+
 // GenKeySearchPlaceHolder generates the string for multiple keys when an expression
 // is involved such as used in RowFind(). The expression will always be '=' and will
 // apply to all keys in the table. Example: "key1 = $1 AND key2 = $2"
@@ -347,10 +445,10 @@ func (pd *Plugin) GenSqlBuildConn(dbServer, dbPort, dbUser, dbPW, dbName string)
 
 // GenSqlOpen generates the code to issue sql.Open() which is unique
 // for each database server.
-func (pd *Plugin) GenSqlOpen(dbSql string) string {
+func (pd *Plugin) GenSqlOpen(db *dbJson.Database, dbSql string) string {
 	var strs util.StringBuilder
 
-	if sharedData.GenDebugging() {
+	if db.Context().GenDebugging() {
 		strs.WriteStringf("\t\tlog.Printf(\"\\tConnecting to %s using %%s\\n\", connStr)\n", pd.DriverName())
 	}
 	strs.WriteStringf("\t\t%s, err = sql.Open(\"%s\", connStr)\n", dbSql, pd.DriverName())
@@ -360,10 +458,39 @@ func (pd *Plugin) GenSqlOpen(dbSql string) string {
 
 func (pd *Plugin) GenTableCreateStmt(t *dbJson.DbTable) string {
 	var str strings.Builder
-	var hasKeys bool
+	var cons []string
 
 	db := t.DB
 
+	// The table constraints follow the fields.
+	if t.KeyCount() > 0 {
+		cons = append(cons, fmt.Sprintf("CONSTRAINT PK_%s PRIMARY KEY(%s)", t.TitledName(), t.KeysList("", "")))
+	}
+	for _, x := range t.IndexDefns() {
+		if x.IsConstraint() {
+			cons = append(cons, x.ConstraintSql())
+		}
+	}
+	for _, r := range t.ForeignKeys() {
+		parent := r.Table
+		if p := db.FindTable(r.Table); p != nil {
+			parent = p.TitledName()
+		}
+		// T-SQL does not support RESTRICT, but NO ACTION is the same.
+		if strings.ToLower(r.OnDelete) == "restrict" {
+			r.OnDelete = "no action"
+		}
+		if strings.ToLower(r.OnUpdate) == "restrict" {
+			r.OnUpdate = "no action"
+		}
+		cons = append(cons, r.CreateSql(db.Schema+parent))
+	}
+	for i := range t.Fields {
+		if c := t.Fields[i].EnumCheckSql(); len(c) > 0 {
+			cons = append(cons, strings.Replace(c, "\"", "\\\"", -1))
+		}
+	}
+
 	str.WriteString(fmt.Sprintf("CREATE TABLE %s%s (\\n", db.Schema, t.TitledName()))
 	for i, _ := range t.Fields {
 		var cm string
@@ -373,33 +500,20 @@ func (pd *Plugin) GenTableCreateStmt(t *dbJson.DbTable) string {
 		var nl string
 		var pk string
 		var sp string
+		var uq string
 
 		f = &t.Fields[i]
 		cm = ""
-		if i != (len(t.Fields) - 1) {
+		if i != (len(t.Fields)-1) || len(cons) > 0 {
 			cm = ","
-		} else {
-			if hasKeys {
-				cm = ","
-			}
 		}
 
 		td := f.Typ
 		if td == nil {
-			log.Fatalln("Error - Could not find Type definition for field,",
-				f.Name, "type:", f.TypeDefn)
-		}
-		tdd := f.Typ.SqlType()
-
-		if f.Len > 0 {
-			if f.Dec > 0 {
-				ft = fmt.Sprintf("%s(%d,%d)", tdd, f.Len, f.Dec)
-			} else {
-				ft = fmt.Sprintf("%s(%d)", tdd, f.Len)
-			}
-		} else {
-			ft = tdd
+			panic(fmt.Sprintf("Error - Could not find Type definition for field, %s type: %s",
+				f.Name, f.TypeDefn))
 		}
+		ft = f.ColumnType()
 		nl = " NOT NULL"
 		if f.Nullable {
 			nl = ""
@@ -409,19 +523,23 @@ func (pd *Plugin) GenTableCreateStmt(t *dbJson.DbTable) string {
 			incr = " IDENTITY(1,1)"
 		}
 		pk = ""
-		if f.KeyNum > 0 {
-			hasKeys = true
+		uq = ""
+		if f.Unique && f.KeyNum == 0 {
+			uq = " UNIQUE"
 		}
 		sp = ""
 		if len(f.SQLParms) > 0 {
 			sp = " " + f.SQLParms
 		}
 
-		str.WriteString(fmt.Sprintf("\\t%s\\t%s%s%s%s%s%s\\n", f.Name, ft, nl, incr, pk, cm, sp))
+		str.WriteString(fmt.Sprintf("\\t%s\\t%s%s%s%s%s%s%s\\n", f.Name, ft, nl, incr, pk, uq, cm, sp))
 	}
-	if hasKeys {
-		wrk := fmt.Sprintf("\\tCONSTRAINT PK_%s PRIMARY KEY(%s)\\n", t.TitledName(), t.KeysList("", ""))
-		str.WriteString(wrk)
+	for i, c := range cons {
+		cm := ","
+		if i == len(cons)-1 {
+			cm = ""
+		}
+		str.WriteString(fmt.Sprintf("\\t%s%s\\n", c, cm))
 	}
 	str.WriteString(")")
 	if len(t.SQLParms) > 0 {
@@ -435,6 +553,50 @@ func (pd *Plugin) GenTableCreateStmt(t *dbJson.DbTable) string {
 	return str.String()
 }
 
+// GenTableIndexStmts generates the CREATE INDEX statements for the
+// indexes which are not table constraints. A Where clause creates a
+// filtered index in T-SQL.
+func (pd *Plugin) GenTableIndexStmts(t *dbJson.DbTable) []string {
+	var stmts []string
+
+	db := t.DB
+
+	for _, x := range t.IndexDefns() {
+		if !x.IsConstraint() {
+			stmt := strings.Replace(x.CreateSql(db.Schema+t.TitledName()), "\"", "\\\"", -1)
+			stmts = append(stmts, stmt+";\\n")
+		}
+	}
+
+	return stmts
+}
+
+// GenTableLookupStmt generates the SELECT which returns the key and
+// display field of every row ordered by the display field.
+func (pd *Plugin) GenTableLookupStmt(t *dbJson.DbTable) string {
+	var str util.StringBuilder
+
+	db := t.DB
+
+	str.WriteStringf("SELECT %s, %s FROM %s%s ORDER BY %s;\\n", t.KeysList("", ""),
+		t.LookupField().Name, db.Schema, t.TitledName(), t.LookupField().Name)
+
+	return str.String()
+}
+
+// GenTableRenameStmts generates the statements to rename a table which
+// T-SQL does with sp_rename along with the CHECK constraints of its
+// enumerated fields.
+func (pd *Plugin) GenTableRenameStmts(c *dbJson.DbChange) []string {
+	stmts := []string{fmt.Sprintf("EXEC sp_rename '%s%s', '%s'", c.Old.DB.Schema, c.Old.TitledName(),
+		c.New.TitledName())}
+	for i := range c.Old.Fields {
+		stmts = append(stmts, genCheckRenameStmts(&c.Old.Fields[i],
+			c.Old.Fields[i].InTable(c.New))...)
+	}
+	return stmts
+}
+
 // GenTrailer returns any trailer information needed for I/O.
 // This is included in both Database I/O and Table I/O.
 func (pd *Plugin) GenTrailer() string {
@@ -450,6 +612,20 @@ func (pd *Plugin) Name() string {
 	return "mssql"
 }
 
+// ReservedWords returns the words which MS SQL reserves.
+func (pd *Plugin) ReservedWords() []string {
+	return []string{
+		"ADD", "ALL", "ALTER", "AND", "AS", "ASC", "BEGIN", "BETWEEN", "BY",
+		"CASE", "CHECK", "COLUMN", "CONSTRAINT", "CREATE", "CROSS", "DEFAULT",
+		"DELETE", "DESC", "DISTINCT", "DROP", "ELSE", "END", "EXISTS", "FOREIGN",
+		"FROM", "FUNCTION", "GROUP", "HAVING", "IDENTITY", "IN", "INDEX", "INNER",
+		"INSERT", "INTO", "IS", "JOIN", "KEY", "LEFT", "LIKE", "NOT", "NULL", "ON",
+		"OR", "ORDER", "OUTER", "PRIMARY", "PROCEDURE", "REFERENCES", "RIGHT",
+		"SELECT", "SET", "TABLE", "THEN", "TO", "TOP", "TRANSACTION", "UNION",
+		"UNIQUE", "UPDATE", "USER", "VALUES", "VIEW", "WHEN", "WHERE",
+	}
+}
+
 // SchemaName simply returns the external name that this plugin is known by
 // or supports.
 // Required method
@@ -8,10 +8,10 @@
 package dbMysql
 
 import (
+	"fmt"
 	"genapp/pkg/genSqlAppGo/dbJson"
 	"genapp/pkg/genSqlAppGo/dbPlugin"
 	"genapp/pkg/genSqlAppGo/dbType"
-	"genapp/pkg/sharedData"
 	"log"
 
 	"github.com/2kranki/go_util"
@@ -22,22 +22,32 @@ const (
 )
 
 // Notes:
-//	* We are now using a Decimal Package for support of decimal operations including
-//		monetary calculations via https://github.com/ericlagergren/decimal
+//	* The decimal types are kept as decimal.Decimal from
+//		https://github.com/shopspring/decimal so that monetary calculations are exact.
+//	* TIMESTAMP is kept as a string since the connection does not ask the driver
+//		to parse times. BOOLEAN is TINYINT(1) which is read into a bool.
 var tds = dbType.TypeDefns{
 	{Name: "date", Html: "date", Sql: "DATE", Go: "string", DftLen: 0},
 	{Name: "datetime", Html: "datetime", Sql: "DATETIME", Go: "string", DftLen: 0},
 	{Name: "email", Html: "email", Sql: "NVARCHAR", Go: "string", DftLen: 50},
-	{Name: "dec", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
-	{Name: "decimal", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
+	{Name: "dec", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
+	{Name: "decimal", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "int", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "integer", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
-	{Name: "money", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
+	{Name: "money", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "number", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "tel", Html: "tel", Sql: "NVARCHAR", Go: "string", DftLen: 19}, //+nnn (nnn) nnn-nnnn
 	{Name: "text", Html: "text", Sql: "NVARCHAR", Go: "string", DftLen: 0},
 	{Name: "time", Html: "time", Sql: "TIME", Go: "string", DftLen: 0},
 	{Name: "url", Html: "url", Sql: "NVARCHAR", Go: "string", DftLen: 50},
+	{Name: "bigint", Html: "number", Sql: "BIGINT", Go: "int64", DftLen: 0},
+	{Name: "blob", Html: "file", Sql: "LONGBLOB", Go: "[]byte", DftLen: 0},
+	{Name: "bool", Html: "checkbox", Sql: "BOOLEAN", Go: "bool", DftLen: 0},
+	{Name: "boolean", Html: "checkbox", Sql: "BOOLEAN", Go: "bool", DftLen: 0},
+	{Name: "float", Html: "number", Sql: "DOUBLE", Go: "float64", DftLen: 0},
+	{Name: "json", Html: "text", Sql: "JSON", Go: "string", DftLen: 0},
+	{Name: "timestamptz", Html: "datetime-local", Sql: "TIMESTAMP", Go: "string", DftLen: 0},
+	{Name: "uuid", Html: "text", Sql: "CHAR(36)", Go: "string", DftLen: 36},
 }
 
 //----------------------------------------------------------------------------
@@ -49,6 +59,17 @@ var tds = dbType.TypeDefns{
 // functionality.
 type Plugin struct{}
 
+// AnalyzeTable adds the checks specific to MySQL to the analysis of
+// the table. MySQL does not support partial indexes.
+func (pd *Plugin) AnalyzeTable(t *dbJson.DbTable, p *dbJson.Problems) {
+	for _, x := range t.IndexDefns() {
+		if len(x.Where) > 0 {
+			p.AddError(fmt.Sprintf("%s.%s.%s", t.DB.Name, t.Name, x.Name),
+				"Where (partial index) is not supported by MySQL")
+		}
+	}
+}
+
 // CreateDatabase indicatess if the Database needs to be
 // created before it can be used.
 func (pd Plugin) CreateDatabase() bool {
@@ -155,6 +176,19 @@ func (pd Plugin) GenImportString() string {
 	return "\"github.com/go-sql-driver/mysql\""
 }
 
+// GenFieldAlterStmts generates the statements to alter a column.
+func (pd *Plugin) GenFieldAlterStmts(c *dbJson.DbChange) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s%s MODIFY COLUMN %s", c.New.DB.Schema, c.New.Name,
+		c.NewField.ColumnDefn())}
+}
+
+// GenIndexDropStmts generates the statements to drop an index. MySQL
+// drops unique constraints as indexes.
+func (pd *Plugin) GenIndexDropStmts(c *dbJson.DbChange) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s%s DROP INDEX %s", c.New.DB.Schema, c.New.Name,
+		c.OldIndex.Name)}
+}
+
 // GenSqlBuildConn generates the code to build the connection string that would be
 // issued to sql.Open() which is unique for each database server.
 func (pd *Plugin) GenSqlBuildConn(dbServer, dbPort, dbUser, dbPW, dbName string) string {
@@ -172,10 +206,10 @@ func (pd *Plugin) GenSqlBuildConn(dbServer, dbPort, dbUser, dbPW, dbName string)
 
 // GenSqlOpen generates the code to issue sql.Open() which is unique
 // for each database server.
-func (pd *Plugin) GenSqlOpen(dbSql string) string {
+func (pd *Plugin) GenSqlOpen(db *dbJson.Database, dbSql string) string {
 	var strs util.StringBuilder
 
-	if sharedData.GenDebugging() {
+	if db.Context().GenDebugging() {
 		strs.WriteStringf("\t\tlog.Printf(\"\\tConnecting %%d to %s using %%s\\n\", i, connStr)\n", pd.DriverName())
 	}
 	strs.WriteStringf("\t\t%s, err = sql.Open(\"%s\", connStr)\n", dbSql, pd.DriverName())
@@ -198,6 +232,20 @@ func (pd Plugin) Name() string {
 	return extName
 }
 
+// ReservedWords returns the words which MySQL reserves.
+func (pd *Plugin) ReservedWords() []string {
+	return []string{
+		"ADD", "ALL", "ALTER", "AND", "AS", "ASC", "BETWEEN", "BY", "CASE",
+		"CHECK", "COLUMN", "CONSTRAINT", "CREATE", "CROSS", "DATABASE", "DEFAULT",
+		"DELETE", "DESC", "DISTINCT", "DROP", "ELSE", "END", "EXISTS", "FOREIGN",
+		"FROM", "GROUP", "HAVING", "IN", "INDEX", "INNER", "INSERT", "INTERVAL",
+		"INTO", "IS", "JOIN", "KEY", "LEFT", "LIKE", "LIMIT", "NOT", "NULL", "ON",
+		"OR", "ORDER", "OUTER", "PRIMARY", "RANGE", "READ", "REFERENCES",
+		"RELEASE", "RIGHT", "SCHEMA", "SELECT", "SET", "TABLE", "THEN", "TO",
+		"UNION", "UNIQUE", "UPDATE", "USAGE", "VALUES", "WHEN", "WHERE",
+	}
+}
+
 // SchemaName simply returns the external name that this plugin is known by
 // or supports.
 // Required method
@@ -43,6 +43,15 @@ type GenTableCreateStringer interface {
 	GenDeleteTableSQL(table interface{}) string
 }
 
+// ReservedWorder is an optional interface which returns the words that
+// the SQL Server reserves. Analysis reports a table or field whose name is
+// one of them ignoring case since it could not be used without quoting
+// it. The list need not be complete, but should cover the words most
+// likely to be chosen as names.
+type ReservedWorder interface {
+	ReservedWords() []string
+}
+
 type SchemaNamer interface {
 	SchemaName() string
 }
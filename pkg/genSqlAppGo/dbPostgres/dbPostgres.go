@@ -29,22 +29,30 @@ const (
 )
 
 // Notes:
-//	* We are now using a Decimal Package for support of decimal operations including
-//		monetary calculations via https://github.com/ericlagergren/decimal
+//	* The decimal types are kept as decimal.Decimal from
+//		https://github.com/shopspring/decimal so that monetary calculations are exact.
 var tds = dbType.TypeDefns{
 	{Name: "date", Html: "date", Sql: "DATE", Go: "string", DftLen: 0},
 	{Name: "datetime", Html: "datetime", Sql: "DATETIME", Go: "string", DftLen: 0},
 	{Name: "email", Html: "email", Sql: "VARCHAR", Go: "string", DftLen: 50},
-	{Name: "dec", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
-	{Name: "decimal", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
+	{Name: "dec", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
+	{Name: "decimal", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "int", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "integer", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
-	{Name: "money", Html: "number", Sql: "DEC", Go: "float64", DftLen: 0},
+	{Name: "money", Html: "number", Sql: "DEC", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "number", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "tel", Html: "tel", Sql: "VARCHAR", Go: "string", DftLen: 19}, //+nnn (nnn) nnn-nnnn
 	{Name: "text", Html: "text", Sql: "VARCHAR", Go: "string", DftLen: 0},
 	{Name: "time", Html: "time", Sql: "TIME", Go: "string", DftLen: 0},
 	{Name: "url", Html: "url", Sql: "VARCHAR", Go: "string", DftLen: 50},
+	{Name: "bigint", Html: "number", Sql: "BIGINT", Go: "int64", DftLen: 0},
+	{Name: "blob", Html: "file", Sql: "BYTEA", Go: "[]byte", DftLen: 0},
+	{Name: "bool", Html: "checkbox", Sql: "BOOLEAN", Go: "bool", DftLen: 0},
+	{Name: "boolean", Html: "checkbox", Sql: "BOOLEAN", Go: "bool", DftLen: 0},
+	{Name: "float", Html: "number", Sql: "DOUBLE PRECISION", Go: "float64", DftLen: 0},
+	{Name: "json", Html: "text", Sql: "JSONB", Go: "string", DftLen: 0},
+	{Name: "timestamptz", Html: "datetime-local", Sql: "TIMESTAMPTZ", Go: "time.Time", DftLen: 0},
+	{Name: "uuid", Html: "text", Sql: "UUID", Go: "string", DftLen: 36},
 }
 
 //----------------------------------------------------------------------------
@@ -181,6 +189,38 @@ func (pd Plugin) GenRowPageStmt(t *dbJson.DbTable) string {
 	return str.String()
 }
 
+// GenRowUpdateStmt generates the UPDATE statement numbering the place
+// holders for the fields being set followed by the keys.
+func (pd Plugin) GenRowUpdateStmt(t *dbJson.DbTable) string {
+	var str util.StringBuilder
+	var n int
+
+	db := t.DB
+
+	str.WriteStringf("UPDATE %s%s SET ", db.Schema, t.Name)
+	for i, f := range t.UpdateFields() {
+		cm := ", "
+		if i == 0 {
+			cm = ""
+		}
+		n++
+		str.WriteStringf("%s%s = $%d", cm, f.Name, n)
+	}
+	str.WriteString(" WHERE ")
+	keys, _ := t.Keys()
+	for i, k := range keys {
+		cm := " AND "
+		if i == 0 {
+			cm = ""
+		}
+		n++
+		str.WriteStringf("%s%s = $%d", cm, k, n)
+	}
+	str.WriteString(";\\n")
+
+	return str.String()
+}
+
 // GenSqlBuildConn generates the code to build the connection string that would be
 // issued to sql.Open() which is unique for each database server.
 func (pd *Plugin) GenSqlBuildConn(dbServer, dbPort, dbUser, dbPW, dbName string) string {
@@ -218,6 +258,20 @@ func (pd Plugin) Name() string {
 	return extName
 }
 
+// ReservedWords returns the words which PostgreSQL reserves.
+func (pd *Plugin) ReservedWords() []string {
+	return []string{
+		"ADD", "ALL", "ALTER", "ANALYZE", "AND", "ARRAY", "AS", "ASC", "BETWEEN",
+		"BY", "CASE", "CAST", "CHECK", "COLUMN", "CONSTRAINT", "CREATE", "CROSS",
+		"DEFAULT", "DELETE", "DESC", "DISTINCT", "DROP", "ELSE", "END", "EXISTS",
+		"FOREIGN", "FROM", "GROUP", "HAVING", "IN", "INDEX", "INNER", "INSERT",
+		"INTO", "IS", "JOIN", "KEY", "LEFT", "LIKE", "LIMIT", "NOT", "NULL",
+		"OFFSET", "ON", "OR", "ORDER", "OUTER", "PRIMARY", "REFERENCES",
+		"RETURNING", "RIGHT", "SELECT", "SET", "TABLE", "THEN", "TO", "UNION",
+		"UNIQUE", "UPDATE", "USER", "VALUES", "WHEN", "WHERE", "WINDOW",
+	}
+}
+
 // SchemaName simply returns the external name that this plugin is known by
 // or supports.
 // Required method
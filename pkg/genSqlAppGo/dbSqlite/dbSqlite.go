@@ -32,24 +32,34 @@ const (
 )
 
 // Notes:
-//	* SQLite does We will be using a Decimal Package for support of decimal operations including
-//		monetary calculations via https://github.com/ericlagergren/decimal
-//    Currently, we just have decimal as text. SQLite does not handle decimal only
-//		text, real64 and int64.
+//	* SQLite does not handle decimal only text, real64 and int64. So, the decimal
+//		types are stored as TEXT and kept as decimal.Decimal from
+//		https://github.com/shopspring/decimal so that no rounding creeps in.
+//		Calculations should be done in Go rather than in SQL.
+//	* BOOLEAN and TIMESTAMP are declared so that the driver returns them as bool
+//		and time.Time.
 var tds = dbType.TypeDefns{
 	{Name: "date", Html: "date", Sql: "DATE", Go: "time.Time", DftLen: 0},
 	{Name: "datetime", Html: "datetime", Sql: "DATETIME", Go: "time.Time", DftLen: 0},
 	{Name: "email", Html: "email", Sql: "VARCHAR", Go: "string", DftLen: 50},
-	{Name: "dec", Html: "number", Sql: "TEXT", Go: "string", DftLen: 0},
-	{Name: "decimal", Html: "number", Sql: "TEXT", Go: "string", DftLen: 0},
+	{Name: "dec", Html: "number", Sql: "TEXT", Go: "decimal.Decimal", DftLen: 0},
+	{Name: "decimal", Html: "number", Sql: "TEXT", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "int", Html: "number", Sql: "INTEGER", Go: "int64", DftLen: 0},
 	{Name: "integer", Html: "number", Sql: "INTEGER", Go: "int64", DftLen: 0},
-	{Name: "money", Html: "number", Sql: "TEXT", Go: "string", DftLen: 0},
+	{Name: "money", Html: "number", Sql: "TEXT", Go: "decimal.Decimal", DftLen: 0},
 	{Name: "number", Html: "number", Sql: "INT", Go: "int64", DftLen: 0},
 	{Name: "tel", Html: "tel", Sql: "VARCHAR", Go: "string", DftLen: 19}, //+nnn (nnn) nnn-nnnn
 	{Name: "text", Html: "text", Sql: "VARCHAR", Go: "string", DftLen: 0},
 	{Name: "time", Html: "time", Sql: "TIME", Go: "time.Time", DftLen: 0},
 	{Name: "url", Html: "url", Sql: "VARCHAR", Go: "string", DftLen: 50},
+	{Name: "bigint", Html: "number", Sql: "INTEGER", Go: "int64", DftLen: 0},
+	{Name: "blob", Html: "file", Sql: "BLOB", Go: "[]byte", DftLen: 0},
+	{Name: "bool", Html: "checkbox", Sql: "BOOLEAN", Go: "bool", DftLen: 0},
+	{Name: "boolean", Html: "checkbox", Sql: "BOOLEAN", Go: "bool", DftLen: 0},
+	{Name: "float", Html: "number", Sql: "REAL", Go: "float64", DftLen: 0},
+	{Name: "json", Html: "text", Sql: "TEXT", Go: "string", DftLen: 0},
+	{Name: "timestamptz", Html: "datetime-local", Sql: "TIMESTAMP", Go: "time.Time", DftLen: 0},
+	{Name: "uuid", Html: "text", Sql: "TEXT", Go: "string", DftLen: 36},
 }
 
 //----------------------------------------------------------------------------
@@ -61,6 +71,47 @@ var tds = dbType.TypeDefns{
 // functionality.
 type Plugin struct{}
 
+// AnalyzeTable adds the checks specific to SQLite to the analysis of
+// the table. An auto-incremented key in SQLite must be an alias for the
+// rowid which is only true for a single "INTEGER PRIMARY KEY" column.
+func (pd *Plugin) AnalyzeTable(t *dbJson.DbTable, p *dbJson.Problems) {
+	var path string
+
+	types := t.DB.TypeDefns(&tds)
+	keyCount := 0
+	for i := range t.Fields {
+		if t.Fields[i].KeyNum > 0 {
+			keyCount++
+		}
+	}
+
+	for i := range t.Fields {
+		f := &t.Fields[i]
+		path = fmt.Sprintf("%s.%s.%s", t.DB.Name, t.Name, f.Name)
+		switch strings.ToLower(f.Name) {
+		case "rowid", "oid", "_rowid_":
+			p.AddWarning(path, "field name hides the SQLite rowid")
+		}
+		td := types.FindDefn(f.TypeDefn)
+		if td == nil || f.KeyNum == 0 {
+			continue
+		}
+		if f.Incr {
+			if keyCount > 1 {
+				p.AddError(path, "Incr is not allowed in SQLite with a multi-field key")
+			} else if i != 0 {
+				p.AddError(path, "Incr in SQLite requires the key to be the first field")
+			}
+			if td.SqlType() != "INTEGER" || f.Len > 0 {
+				p.AddError(path, "Incr in SQLite requires the field to be INTEGER without a Len")
+			}
+		} else if keyCount == 1 && td.IsInteger() && td.SqlType() != "INTEGER" {
+			p.AddWarning(path, "%s key is not an alias for the SQLite rowid, use int or integer",
+				f.TypeDefn)
+		}
+	}
+}
+
 // CreateDatabase indicates if the Database needs to be
 // created before it can be used.
 func (pd Plugin) CreateDatabase() bool {
@@ -134,6 +185,20 @@ func (pd Plugin) GenImportString() string {
 	return "\"github.com/mattn/go-sqlite3\""
 }
 
+// GenIndexAddStmts generates the statements to add an index to an
+// existing table. SQLite can not add a table constraint. So, a unique
+// constraint is added as a unique index instead.
+func (pd *Plugin) GenIndexAddStmts(c *dbJson.DbChange) []string {
+	x := *c.NewIndex
+	return []string{x.CreateSql(c.New.DB.Schema + c.New.Name)}
+}
+
+// GenIndexDropStmts generates the statements to drop an index. The index
+// may already be gone if the table was rebuilt.
+func (pd *Plugin) GenIndexDropStmts(c *dbJson.DbChange) []string {
+	return []string{fmt.Sprintf("DROP INDEX IF EXISTS %s%s", c.New.DB.Schema, c.OldIndex.Name)}
+}
+
 // GenSqlBuildConn generates the code to build the connection string that would be
 // issued to sql.Open() which is unique for each database server.
 func (pd *Plugin) GenSqlBuildConn(dbServer, dbPort, dbUser, dbPW, dbName string) string {
@@ -161,6 +226,36 @@ func (pd Plugin) Name() string {
 	return extName
 }
 
+// RebuildTable returns true if the change must be made by rebuilding
+// the table. SQLite's ALTER TABLE can not alter a column or drop a
+// constraint. Nor can it drop a column used in a CHECK constraint such
+// as an enumerated field.
+func (pd *Plugin) RebuildTable(c *dbJson.DbChange) bool {
+	switch c.Kind {
+	case dbJson.FieldAlter:
+		return true
+	case dbJson.FieldDrop:
+		return c.OldField.IsEnum()
+	case dbJson.IndexDrop:
+		return c.OldIndex.IsConstraint()
+	}
+	return false
+}
+
+// ReservedWords returns the words which SQLite reserves.
+func (pd *Plugin) ReservedWords() []string {
+	return []string{
+		"ADD", "ALL", "ALTER", "AND", "AS", "ASC", "AUTOINCREMENT", "BETWEEN",
+		"BY", "CASE", "CHECK", "COLLATE", "COLUMN", "CONSTRAINT", "CREATE",
+		"CROSS", "DEFAULT", "DELETE", "DESC", "DISTINCT", "DROP", "ELSE", "END",
+		"EXISTS", "FOREIGN", "FROM", "GROUP", "HAVING", "IN", "INDEX", "INNER",
+		"INSERT", "INTO", "IS", "JOIN", "KEY", "LEFT", "LIKE", "LIMIT", "NOT",
+		"NULL", "ON", "OR", "ORDER", "OUTER", "PRIMARY", "REFERENCES", "RIGHT",
+		"SELECT", "SET", "TABLE", "THEN", "TO", "TRANSACTION", "UNION", "UNIQUE",
+		"UPDATE", "VALUES", "WHEN", "WHERE",
+	}
+}
+
 // SchemaName simply returns the external name that this plugin is known by
 // or supports.
 // Required method
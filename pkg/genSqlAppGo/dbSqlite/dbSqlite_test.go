@@ -8,6 +8,7 @@ package dbSqlite
 import (
 	"genapp/pkg/sharedData"
 	"log"
+	"strings"
 	"testing"
 	//"time"
 	"genapp/pkg/genSqlAppGo/dbJson"
@@ -31,7 +32,7 @@ func TestCreate(t *testing.T) {
 	if err = dbJson.ReadJsonFile(sharedData.MainPath()); err != nil {
 		t.Fatalf("TestCreate() Reading Main JSON failed: %s\n", sharedData.MainPath())
 	}
-	if err = dbJson.SetupPlugin(*pluginData); err != nil {
+	if err = dbJson.DbStruct().SetupPlugin(); err != nil {
 		t.Fatalf("TestCreate() SetupPlugin failed: %s\n", err)
 	}
 
@@ -63,3 +64,75 @@ func TestGenSqlOpen(t *testing.T) {
 
 	t.Log("...end of dbSqlite::TestGenSqlOpen\n")
 }
+
+func TestAnalyzeTable(t *testing.T) {
+	var p dbJson.Problems
+	var db dbJson.Database
+
+	log.Printf("dbSqlite::TestAnalyzeTable()..\n")
+	sharedData.SetDebug(true)
+
+	db = dbJson.Database{Name: "app", SqlType: extName,
+		Tables: []dbJson.DbTable{
+			{Name: "good",
+				Fields: []dbJson.DbField{
+					{Name: "id", TypeDefn: "integer", KeyNum: 1, Incr: true},
+					{Name: "name", TypeDefn: "text", Len: 30},
+				},
+			},
+			{Name: "composite",
+				Fields: []dbJson.DbField{
+					{Name: "id", TypeDefn: "int", KeyNum: 1, Incr: true},
+					{Name: "seq", TypeDefn: "int", KeyNum: 2},
+				},
+			},
+			{Name: "notfirst",
+				Fields: []dbJson.DbField{
+					{Name: "name", TypeDefn: "text", Len: 30},
+					{Name: "id", TypeDefn: "int", KeyNum: 1, Incr: true},
+				},
+			},
+			{Name: "notinteger",
+				Fields: []dbJson.DbField{
+					{Name: "id", TypeDefn: "number", KeyNum: 1, Incr: true},
+				},
+			},
+			{Name: "alias",
+				Fields: []dbJson.DbField{
+					{Name: "id", TypeDefn: "number", KeyNum: 1},
+					{Name: "rowid", TypeDefn: "int"},
+				},
+			},
+		},
+	}
+	p = db.Analyze()
+	t.Logf("Problems:\n%s\n", p.String())
+
+	tests := []struct {
+		path    string
+		msg     string
+		warning bool
+	}{
+		{"app.composite.id", "multi-field key", false},
+		{"app.notfirst.id", "first field", false},
+		{"app.notinteger.id", "INTEGER", false},
+		{"app.alias.id", "rowid", true},
+		{"app.alias.rowid", "rowid", true},
+	}
+	for _, tst := range tests {
+		found := false
+		for _, v := range p {
+			if v.Path == tst.path && v.Warning == tst.warning && strings.Contains(v.Msg, tst.msg) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("TestAnalyzeTable() missing problem: %s: %s\n", tst.path, tst.msg)
+		}
+	}
+	if len(p) != len(tests) {
+		t.Fatalf("TestAnalyzeTable() should have %d problems but has %d\n", len(tests), len(p))
+	}
+
+	t.Log("...end of dbSqlite::TestAnalyzeTable\n")
+}
@@ -0,0 +1,326 @@
+// See License.txt in main repository directory
+
+// introspect reads the definitions of the tables of an existing SQLite
+// database from sqlite_master and the table PRAGMAs.
+
+// Remarks:
+//	*	SQLite allows any type name for a column. The types generated by
+//		this plugin are mapped back to their type names. Other types are
+//		mapped using SQLite's type affinity rules with a warning.
+//	*	A table without a primary key can not be defined and is skipped
+//		with a warning. So is the schema_migrations table maintained by
+//		the generated application.
+//	*	The SQLite driver requires cgo. Without it, genapp is built with
+//		introspect_nocgo.go instead so that the rest of it still builds.
+
+//go:build cgo
+// +build cgo
+
+package dbSqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"genapp/pkg/genSqlAppGo/dbJson"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqlTypeDecl parses a column type such as "VARCHAR(20)" or "TEXT(15,2)".
+var sqlTypeDecl = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z ]*?)\s*(?:\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\))?\s*$`)
+
+// Introspect reads the definitions of the tables in the given SQLite
+// database file adding them to db.
+func (pd *Plugin) Introspect(db *dbJson.Database, dsn string, p *dbJson.Problems) error {
+	var names, stmts []string
+
+	// Opening a missing file would create it.
+	if _, err := os.Stat(dsn); err != nil {
+		return fmt.Errorf("Error: %s does not exist!\n", dsn)
+	}
+	conn, err := sql.Open(pd.DriverName(), "file:"+dsn+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("Error: Cannot open %s: %s\n", dsn, err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.Query("SELECT name, sql FROM sqlite_master WHERE type = 'table' " +
+		"AND name NOT LIKE 'sqlite_%' ORDER BY name;")
+	if err != nil {
+		return fmt.Errorf("Error: Cannot read %s: %s\n", dsn, err)
+	}
+	for rows.Next() {
+		var name, stmt string
+		if err = rows.Scan(&name, &stmt); err != nil {
+			rows.Close()
+			return err
+		}
+		names = append(names, name)
+		stmts = append(stmts, stmt)
+	}
+	rows.Close()
+
+	for i, name := range names {
+		if name == "schema_migrations" {
+			continue
+		}
+		if err = pd.introspectTable(conn, db, name, stmts[i], p); err != nil {
+			return fmt.Errorf("Error: Cannot read table %s: %s\n", name, err)
+		}
+	}
+
+	return nil
+}
+
+// introspectTable reads the definition of one table.
+func (pd *Plugin) introspectTable(conn *sql.DB, db *dbJson.Database, name, stmt string, p *dbJson.Problems) error {
+	var tb dbJson.DbTable
+	var keyCount int
+
+	tblPath := fmt.Sprintf("%s.%s", db.Name, name)
+	tb.Name = name
+
+	// Columns
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA table_info(\"%s\");", name))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var cid, notNull, pk int
+		var fld, typ string
+		var dflt sql.NullString
+		if err = rows.Scan(&cid, &fld, &typ, &notNull, &dflt, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		f := dbJson.DbField{Name: fld, KeyNum: pk, Nullable: notNull == 0 && pk == 0}
+		f.TypeDefn, f.Len, f.Dec = pd.introspectType(typ, tblPath+"."+fld, p)
+		if dflt.Valid {
+			f.SQLParms = "DEFAULT " + dflt.String
+		}
+		if pk > 0 {
+			keyCount++
+		}
+		tb.Fields = append(tb.Fields, f)
+	}
+	rows.Close()
+	if keyCount == 0 {
+		p.AddWarning(tblPath, "has no primary key and is skipped")
+		return nil
+	}
+	if keyCount == 1 && strings.Contains(strings.ToUpper(stmt), "AUTOINCREMENT") {
+		for i := range tb.Fields {
+			if tb.Fields[i].KeyNum > 0 {
+				tb.Fields[i].Incr = true
+			}
+		}
+	}
+
+	if err = pd.introspectIndexes(conn, &tb, tblPath, p); err != nil {
+		return err
+	}
+	if err = pd.introspectReferences(conn, &tb); err != nil {
+		return err
+	}
+
+	db.Tables = append(db.Tables, tb)
+	return nil
+}
+
+// introspectIndexes reads the indexes and unique constraints of a table.
+// A unique constraint on one field is made Unique on the field.
+func (pd *Plugin) introspectIndexes(conn *sql.DB, tb *dbJson.DbTable, tblPath string, p *dbJson.Problems) error {
+	var cons, idxs []dbJson.DbIndex
+
+	type index struct {
+		name    string
+		unique  bool
+		origin  string
+		partial bool
+	}
+	var list []index
+
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA index_list(\"%s\");", tb.Name))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var seq, unique, partial int
+		var x index
+		if err = rows.Scan(&seq, &x.name, &unique, &x.origin, &partial); err != nil {
+			rows.Close()
+			return err
+		}
+		x.unique = unique != 0
+		x.partial = partial != 0
+		list = append(list, x)
+	}
+	rows.Close()
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+
+	for _, x := range list {
+		if x.origin == "pk" {
+			continue
+		}
+		var cols []string
+		expr := false
+		rows, err = conn.Query(fmt.Sprintf("PRAGMA index_info(\"%s\");", x.name))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var seqNo, cid int
+			var col sql.NullString
+			if err = rows.Scan(&seqNo, &cid, &col); err != nil {
+				rows.Close()
+				return err
+			}
+			if !col.Valid {
+				expr = true
+			}
+			cols = append(cols, col.String)
+		}
+		rows.Close()
+		if expr {
+			p.AddWarning(tblPath, "index, %s, is on an expression and is skipped", x.name)
+			continue
+		}
+
+		if x.origin == "u" {
+			if len(cols) == 1 {
+				tb.FindField(cols[0]).Unique = true
+			} else {
+				cons = append(cons, dbJson.DbIndex{Columns: cols, Unique: true})
+			}
+			continue
+		}
+		ix := dbJson.DbIndex{Name: x.name, Columns: cols, Unique: x.unique}
+		if x.partial {
+			var stmt string
+			err = conn.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?;",
+				x.name).Scan(&stmt)
+			if err != nil {
+				return err
+			}
+			ix.Where = partialWhere(stmt)
+		}
+		idxs = append(idxs, ix)
+	}
+
+	// The constraints are first so that their default names match.
+	tb.Indexes = append(cons, idxs...)
+	return nil
+}
+
+// partialWhere returns the condition of the WHERE clause of a CREATE
+// INDEX statement or "" if it has none. WHERE may be surrounded by any
+// white space.
+func partialWhere(stmt string) string {
+	fields := strings.Fields(stmt)
+	for i, w := range fields {
+		if strings.EqualFold(w, "WHERE") {
+			return strings.TrimRight(strings.Join(fields[i+1:], " "), ";")
+		}
+	}
+	return ""
+}
+
+// introspectReferences reads the foreign keys of a table.
+func (pd *Plugin) introspectReferences(conn *sql.DB, tb *dbJson.DbTable) error {
+	var ids []int
+
+	refs := map[int]*dbJson.DbReference{}
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA foreign_key_list(\"%s\");", tb.Name))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, seq int
+		var table, from, match string
+		var to sql.NullString
+		var onUpdate, onDelete string
+		if err = rows.Scan(&id, &seq, &table, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+			return err
+		}
+		r := refs[id]
+		if r == nil {
+			r = &dbJson.DbReference{Table: table, OnDelete: introspectAction(onDelete),
+				OnUpdate: introspectAction(onUpdate)}
+			refs[id] = r
+			ids = append(ids, id)
+		}
+		r.Fields = append(r.Fields, from)
+		if to.Valid && len(to.String) > 0 {
+			r.Columns = append(r.Columns, to.String)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// SQLite numbers the foreign keys from the last one defined.
+	sort.Sort(sort.Reverse(sort.IntSlice(ids)))
+	for _, id := range ids {
+		tb.References = append(tb.References, *refs[id])
+	}
+
+	return nil
+}
+
+// introspectAction returns the reference action as used in the
+// definitions. "no action" is the default and is left out.
+func introspectAction(action string) string {
+	action = strings.ToLower(action)
+	if action == "no action" {
+		return ""
+	}
+	return action
+}
+
+// introspectType returns the type name, length and decimal positions for
+// a column type.
+func (pd *Plugin) introspectType(decl, path string, p *dbJson.Problems) (string, int, int) {
+	var length, dec int
+
+	m := sqlTypeDecl.FindStringSubmatch(decl)
+	if m == nil {
+		p.AddWarning(path, "type, %s, is not supported and is mapped to text", decl)
+		return "text", 0, 0
+	}
+	base := strings.ToUpper(m[1])
+	length, _ = strconv.Atoi(m[2])
+	dec, _ = strconv.Atoi(m[3])
+
+	// This plugin generates TEXT for the decimal types.
+	if base == "TEXT" {
+		if dec > 0 {
+			return "dec", length, dec
+		}
+		return "text", length, 0
+	}
+	if name := dbJson.IntrospectType(&tds, base); len(name) > 0 {
+		return name, length, dec
+	}
+
+	// Use SQLite's type affinity rules.
+	switch {
+	case strings.Contains(base, "BOOL"):
+		return "bool", 0, 0
+	case strings.Contains(base, "INT"):
+		return "int", 0, 0
+	case strings.Contains(base, "CHAR") || strings.Contains(base, "CLOB"):
+		return "text", length, 0
+	case strings.Contains(base, "BLOB"):
+		return "blob", 0, 0
+	case strings.Contains(base, "DEC") || strings.Contains(base, "NUMERIC"):
+		return "dec", length, dec
+	}
+	p.AddWarning(path, "type, %s, is mapped to dec", decl)
+	return "dec", length, dec
+}
@@ -0,0 +1,20 @@
+// See License.txt in main repository directory
+
+// introspect_nocgo replaces introspect.go when genapp is built without
+// cgo which the SQLite driver requires.
+
+//go:build !cgo
+// +build !cgo
+
+package dbSqlite
+
+import (
+	"fmt"
+
+	"genapp/pkg/genSqlAppGo/dbJson"
+)
+
+// Introspect returns an error since the SQLite database can not be read.
+func (pd *Plugin) Introspect(db *dbJson.Database, dsn string, p *dbJson.Problems) error {
+	return fmt.Errorf("Error: introspect of SQLite requires genapp to be built with cgo (CGO_ENABLED=1)!\n")
+}
@@ -0,0 +1,142 @@
+// vi:nu:et:sts=4 ts=4 sw=4
+// See License.txt in main repository directory
+
+// Test reading the definitions of an existing SQLite database
+
+//go:build cgo
+// +build cgo
+
+package dbSqlite
+
+import (
+	"database/sql"
+	"genapp/pkg/genSqlAppGo/dbJson"
+	"genapp/pkg/sharedData"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var introspectSql = []string{
+	"CREATE TABLE Customer (Num INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, " +
+		"Name VARCHAR(30) NOT NULL UNIQUE, Addr1 VARCHAR(30), CurBal TEXT(15,2) NOT NULL DEFAULT '0')",
+	"CREATE TABLE Invoice (Num INTEGER NOT NULL PRIMARY KEY, CustNum INTEGER NOT NULL, " +
+		"PoNum VARCHAR(20), Amount REAL, Notes BLOB, Scan MEDIUMBLOB, Paid BOOLEAN, " +
+		"CONSTRAINT UQ_Invoice_1 UNIQUE(CustNum, PoNum), " +
+		"CONSTRAINT FK_Invoice_1 FOREIGN KEY(CustNum) REFERENCES Customer(Num) ON DELETE CASCADE)",
+	"CREATE INDEX IX_Invoice_Amount ON Invoice(Amount)\n\tWHERE Amount <> 0",
+	"CREATE TABLE Line (InvNum INTEGER NOT NULL REFERENCES Invoice, Seq INT NOT NULL, " +
+		"Item varchar(20), PRIMARY KEY(InvNum, Seq))",
+	"CREATE TABLE NoKey (a text)",
+	"CREATE TABLE schema_migrations (version INTEGER NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL)",
+}
+
+func hasWarning(p dbJson.Problems, path, msg string) bool {
+	for _, v := range p {
+		if v.Warning && v.Path == path && strings.Contains(v.Msg, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIntrospect(t *testing.T) {
+
+	log.Printf("dbSqlite::TestIntrospect()..\n")
+	sharedData.SetDebug(true)
+
+	dir, err := ioutil.TempDir("", "introspect")
+	if err != nil {
+		t.Fatalf("TestIntrospect() TempDir failed: %s\n", err)
+	}
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "legacy.db")
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("TestIntrospect() Open failed: %s\n", err)
+	}
+	for _, s := range introspectSql {
+		if _, err = conn.Exec(s); err != nil {
+			t.Fatalf("TestIntrospect() %s failed: %s\n", s, err)
+		}
+	}
+	conn.Close()
+
+	db, p, err := dbJson.Introspect("sqlite", "legacy", dbPath)
+	t.Logf("Problems:\n%s\n", p.String())
+	if err != nil {
+		t.Fatalf("TestIntrospect() failed: %s\n", err)
+	}
+	if len(db.Tables) != 3 {
+		t.Fatalf("TestIntrospect() should have 3 tables but has %d\n", len(db.Tables))
+	}
+	if !hasWarning(p, "legacy.NoKey", "no primary key") {
+		t.Errorf("TestIntrospect() missing primary key warning\n")
+	}
+	if hasWarning(p, "legacy.Invoice.Notes", "BLOB") {
+		t.Errorf("TestIntrospect() should not warn about BLOB\n")
+	}
+
+	tb := db.FindTable("Customer")
+	tests := []struct {
+		fld    string
+		typ    string
+		len    int
+		dec    int
+		null   bool
+		unique bool
+	}{
+		{"Num", "int", 0, 0, false, false},
+		{"Name", "text", 30, 0, false, true},
+		{"Addr1", "text", 30, 0, true, false},
+		{"CurBal", "dec", 15, 2, false, false},
+	}
+	for _, tst := range tests {
+		f := tb.FindField(tst.fld)
+		if f == nil || f.TypeDefn != tst.typ || f.Len != tst.len || f.Dec != tst.dec ||
+			f.Nullable != tst.null || f.Unique != tst.unique {
+			t.Errorf("TestIntrospect() invalid field %s: %+v\n", tst.fld, f)
+		}
+	}
+	if !tb.Fields[0].Incr || tb.Fields[0].KeyNum != 1 {
+		t.Errorf("TestIntrospect() Customer.Num should be an incremented key\n")
+	}
+
+	tb = db.FindTable("Invoice")
+	for fn, typ := range map[string]string{"Amount": "float", "Notes": "blob", "Scan": "blob", "Paid": "bool"} {
+		if f := tb.FindField(fn); f == nil || f.TypeDefn != typ {
+			t.Errorf("TestIntrospect() Invoice.%s should be %s: %+v\n", fn, typ, f)
+		}
+	}
+	idxs := tb.IndexDefns()
+	if len(idxs) != 2 || idxs[0].Name != "UQ_Invoice_1" || !idxs[0].IsConstraint() ||
+		idxs[1].Where != "Amount <> 0" {
+		t.Errorf("TestIntrospect() invalid indexes: %+v\n", idxs)
+	}
+	refs := tb.ForeignKeys()
+	if len(refs) != 1 || refs[0].Table != "Customer" || refs[0].OnDelete != "cascade" {
+		t.Errorf("TestIntrospect() invalid references: %+v\n", refs)
+	}
+
+	tb = db.FindTable("Line")
+	if keys, _ := tb.Keys(); len(keys) != 2 || keys[1] != "Seq" {
+		t.Errorf("TestIntrospect() invalid keys: %v\n", keys)
+	}
+
+	// It must round-trip.
+	jsonPath := filepath.Join(dir, "db.json.txt")
+	if err = db.WriteJsonFile(jsonPath); err != nil {
+		t.Fatalf("TestIntrospect() WriteJsonFile failed: %s\n", err)
+	}
+	if db, err = dbJson.ReadMigrationJsonFile(jsonPath); err != nil {
+		t.Fatalf("TestIntrospect() ReadJsonFile failed: %s\n", err)
+	}
+	if p = db.Analyze(); p.ErrorCount() > 0 {
+		t.Fatalf("TestIntrospect() has errors:\n%s\n", p.String())
+	}
+
+	t.Log("...end of dbSqlite::TestIntrospect\n")
+}
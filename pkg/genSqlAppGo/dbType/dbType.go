@@ -5,7 +5,9 @@
 
 package dbType
 
-import ()
+import (
+	"strings"
+)
 
 //============================================================================
 //								Interfaces
@@ -30,6 +32,17 @@ type TypeDefn struct {
 	DftDec int  `json:"DftDec,omitempty"` // Default Decimal Positions (used if length is not
 	//													//	given)(0 == Not Used)
 	GenDec bool `json:"GenDec,omitempty"` // If true, generate the decimal positions
+	Base   string `json:"Base,omitempty"`   // Type this one was derived from (if any)
+}
+
+// BaseName returns the name of the type that this one was derived from
+// or its own name if it was not derived from another. The kind of a type
+// (ie a date) is determined from it.
+func (t TypeDefn) BaseName() string {
+	if len(t.Base) > 0 {
+		return t.Base
+	}
+	return t.Name
 }
 
 // CanGenDec indicates if Decimal Position should be generated in SQL
@@ -53,15 +66,42 @@ func (t TypeDefn) HtmlType() string {
 	return t.Html
 }
 
+// IsBinary returns true if the type is kept as a slice of bytes such as
+// a blob.
+func (t TypeDefn) IsBinary() bool {
+	return t.Go == "[]byte"
+}
+
+// IsBool returns true if the type is a boolean.
+func (t TypeDefn) IsBool() bool {
+	return t.Go == "bool"
+}
+
 func (t TypeDefn) IsDec() bool {
 
-	if t.Name == "dec" {
+	if t.BaseName() == "dec" {
+		return true
+	}
+	if t.BaseName() == "decimal" {
+		return true
+	}
+	if t.BaseName() == "money" {
 		return true
 	}
-	if t.Name == "decimal" {
+
+	return false
+}
+
+// IsFixedSize returns true if the SQL type is complete without a length
+// such as a boolean, UUID, JSON, blob or timestamp with a time zone. A
+// length given for a field of the type is not added to its SQL type.
+func (t TypeDefn) IsFixedSize() bool {
+
+	if strings.Contains(t.Sql, "(") || t.IsBinary() || t.IsBool() {
 		return true
 	}
-	if t.Name == "money" {
+	switch t.BaseName() {
+	case "json", "timestamptz", "uuid":
 		return true
 	}
 
@@ -94,7 +134,7 @@ func (t TypeDefn) IsInteger() bool {
 
 func (t TypeDefn) IsText() bool {
 
-	if t.Name == "text" {
+	if t.BaseName() == "text" {
 		return true
 	}
 
@@ -159,22 +199,35 @@ func (t TypeDefns) SqlType(name string) string {
 //============================================================================
 
 // Notes:
-//	* We are now using a Decimal Package for support of decimal operations including
-//		monetary calculations via https://github.com/ericlagergren/decimal
+//	* The decimal types are kept as decimal.Decimal from
+//		https://github.com/shopspring/decimal so that monetary calculations are exact.
 //  * In this table, we pick the most common types which should be generic to any
 //		SQL Server if possible
+//	* The types after url were added later. They are kept after the others so
+//		that the original types are found first when mapping an SQL type back
+//		to a type (see dbJson.IntrospectType()).
+//	* blob is entered with a file input and converted to strings (ie CSV) as
+//		base64.
 var DefaultTable = TypeDefns{
 	{Name: "date", Html: "date", Sql: "DATE", Go: "string"},
 	{Name: "datetime", Html: "datetime", Sql: "DATETIME", Go: "string"},
 	{Name: "email", Html: "email", Sql: "VARCHAR", Go: "string", DftLen: 50, GenLen: true},
-	{Name: "dec", Html: "number", Sql: "DEC", Go: "string"},
-	{Name: "decimal", Html: "number", Sql: "DEC", Go: "string"},
+	{Name: "dec", Html: "number", Sql: "DEC", Go: "decimal.Decimal"},
+	{Name: "decimal", Html: "number", Sql: "DEC", Go: "decimal.Decimal"},
 	{Name: "int", Html: "number", Sql: "INT", Go: "int64"},
 	{Name: "integer", Html: "number", Sql: "INT", Go: "int64"},
-	{Name: "money", Html: "number", Sql: "DEC", Go: "string"},
+	{Name: "money", Html: "number", Sql: "DEC", Go: "decimal.Decimal"},
 	{Name: "number", Html: "number", Sql: "INT", Go: "int64"},
 	{Name: "tel", Html: "tel", Sql: "VARCHAR", Go: "string", DftLen: 19, GenLen: true}, //+nnn (nnn) nnn-nnnn
 	{Name: "text", Html: "text", Sql: "VARCHAR", Go: "string", DftLen: 0, GenLen: true},
 	{Name: "time", Html: "time", Sql: "TIME", Go: "string", DftLen: 0, GenLen: false},
 	{Name: "url", Html: "url", Sql: "VARCHAR", Go: "string", DftLen: 50, GenLen: true},
+	{Name: "bigint", Html: "number", Sql: "BIGINT", Go: "int64"},
+	{Name: "blob", Html: "file", Sql: "BLOB", Go: "[]byte"},
+	{Name: "bool", Html: "checkbox", Sql: "BOOLEAN", Go: "bool"},
+	{Name: "boolean", Html: "checkbox", Sql: "BOOLEAN", Go: "bool"},
+	{Name: "float", Html: "number", Sql: "FLOAT", Go: "float64"},
+	{Name: "json", Html: "text", Sql: "JSON", Go: "string"},
+	{Name: "timestamptz", Html: "datetime-local", Sql: "TIMESTAMP WITH TIME ZONE", Go: "time.Time"},
+	{Name: "uuid", Html: "text", Sql: "UUID", Go: "string", DftLen: 36},
 }
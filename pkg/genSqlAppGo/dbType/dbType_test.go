@@ -53,3 +53,47 @@ func TestFind(t *testing.T) {
 	t.Logf("dbType::TestFind: end of test\n")
 
 }
+
+func TestDefaultTable(t *testing.T) {
+
+	t.Logf("dbType::TestDefaultTable()..\n")
+
+	tests := []struct {
+		name string
+		html string
+		goT  string
+	}{
+		{"bigint", "number", "int64"},
+		{"blob", "file", "[]byte"},
+		{"bool", "checkbox", "bool"},
+		{"boolean", "checkbox", "bool"},
+		{"float", "number", "float64"},
+		{"json", "text", "string"},
+		{"timestamptz", "datetime-local", "time.Time"},
+		{"uuid", "text", "string"},
+	}
+	for _, tst := range tests {
+		typ := DefaultTable.FindDefn(tst.name)
+		if typ == nil || typ.Html != tst.html || typ.Go != tst.goT {
+			t.Errorf("Error: invalid '%s' entry: %+v\n", tst.name, typ)
+		}
+	}
+	if !DefaultTable.FindDefn("bool").IsBool() || !DefaultTable.FindDefn("blob").IsBinary() ||
+		!DefaultTable.FindDefn("float").IsFloat() || !DefaultTable.FindDefn("bigint").IsInteger() {
+		t.Errorf("Error: invalid IsBool(), IsBinary(), IsFloat() or IsInteger()\n")
+	}
+	if DefaultTable.FindDefn("text").IsBool() || DefaultTable.FindDefn("text").IsBinary() {
+		t.Errorf("Error: text should not be a bool or binary\n")
+	}
+	for _, name := range []string{"blob", "bool", "json", "timestamptz", "uuid"} {
+		if !DefaultTable.FindDefn(name).IsFixedSize() {
+			t.Errorf("Error: %s should be fixed size\n", name)
+		}
+	}
+	if DefaultTable.FindDefn("text").IsFixedSize() || DefaultTable.FindDefn("dec").IsFixedSize() ||
+		!(TypeDefn{Name: "x", Sql: "CHAR(36)"}).IsFixedSize() {
+		t.Errorf("Error: invalid IsFixedSize()\n")
+	}
+
+	t.Logf("dbType::TestDefaultTable: end of test\n")
+}
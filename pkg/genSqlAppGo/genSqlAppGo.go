@@ -46,12 +46,15 @@ import (
 	"fmt"
 	"genapp/pkg/genCmn"
 	_ "genapp/pkg/genSqlAppGo/dbForm"
-	_ "genapp/pkg/genSqlAppGo/dbGener"
+	"genapp/pkg/genSqlAppGo/dbGener"
 	"genapp/pkg/genSqlAppGo/dbJson"
-	"genapp/pkg/genSqlAppGo/dbPlugin"
 	sharedData "genapp/pkg/sharedData"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	// Include the various Database Plugins so that they will register
 	// with dbPlugin.
@@ -258,6 +261,14 @@ var FileDefs1 []genCmn.FileDefn = []genCmn.FileDefn{
 		"one",
 		0,
 	},
+	{"login.html.tmpl.txt",
+		[]string{"tmpl"},
+		"${DbName}.login.gohtml",
+		"text",
+		0644,
+		"one",
+		0,
+	},
 	{"go.mod.tmpl.txt",
 		[]string{""},
 		"go.mod",
@@ -290,6 +301,14 @@ var FileDefs1 []genCmn.FileDefn = []genCmn.FileDefn{
 		"one",
 		0,
 	},
+	{"openapi.yaml.tmpl.txt",
+		[]string{"api"},
+		"openapi.yaml",
+		"text",
+		0644,
+		"single",
+		0,
+	},
 	{"main_test.go.tmpl.txt",
 		[]string{"cmd","${DbName}"},
 		"main_test.go",
@@ -338,6 +357,22 @@ var FileDefs1 []genCmn.FileDefn = []genCmn.FileDefn{
 		"one",
 		0,
 	},
+	{"auth.go.tmpl.txt",
+		[]string{"pkg","auth"},
+		"auth.go",
+		"text",
+		0644,
+		"single",
+		0,
+	},
+	{"auth.test.go.tmpl.txt",
+		[]string{"pkg","auth"},
+		"auth_test.go",
+		"text",
+		0644,
+		"single",
+		0,
+	},
 	{"httpServer.go.tmpl.txt",
 		[]string{"pkg","httpServer"},
 		"httpServer.go",
@@ -426,6 +461,22 @@ var FileDefs1 []genCmn.FileDefn = []genCmn.FileDefn{
 		"single",
 		0,
 	},
+	{"io.migrate.go.tmpl.txt",
+		[]string{"pkg","io${DbName}"},
+		"io${DbName}Migrate.go",
+		"text",
+		0644,
+		"single",
+		0,
+	},
+	{"io.migrate.test.go.tmpl.txt",
+		[]string{"pkg","io${DbName}"},
+		"io${DbName}Migrate_test.go",
+		"text",
+		0644,
+		"single",
+		0,
+	},
 	{"io.table.go.tmpl.txt",
 		[]string{"pkg","io${DbName}${TblName}"},
 		"io${DbName}${TblName}.go",
@@ -470,6 +521,10 @@ var FileDefs2 []genCmn.FileDefn = []genCmn.FileDefn{
 // CreateOutputDir creates the output directory on disk given a
 // subdirectory (dir).
 func CreateOutputDir(dir []string, dn string, tn string) error {
+	return createOutputDir(sharedData.Default, dir, dn, tn)
+}
+
+func createOutputDir(ctx *sharedData.Context, dir []string, dn string, tn string) error {
 	var err error
 	var outPath *util.Path
 
@@ -487,7 +542,7 @@ func CreateOutputDir(dir []string, dn string, tn string) error {
 		return ""
 	}
 
-	outPath = util.NewPath(sharedData.OutDir())
+	outPath = util.NewPath(ctx.OutDir())
 	for _, d := range dir {
 		if len(dir) > 0 {
 			outPath = outPath.Append(d)
@@ -497,7 +552,7 @@ func CreateOutputDir(dir []string, dn string, tn string) error {
 
 	if !outPath.IsPathDir() {
 		log.Printf("\t\tCreating directory: %s...\n", outPath.String())
-		if sharedData.Noop() {
+		if ctx.Noop() {
 			log.Printf("\t\t\tSkipped CreateDir because NOOP!\n")
 		} else {
 			err = outPath.CreateDir()
@@ -520,22 +575,17 @@ func CreateOutputDirs(g *genCmn.GenData) error {
 	var err error
 	var outDir *util.Path
 
-	dn := dbJson.DbStruct().Name
-	if sharedData.Noop() {
+	ctx := g.Context()
+	dn := database(g).Name
+	if ctx.Noop() {
 		log.Printf("NOOP -- Skipping Creating directories\n")
 		return nil
 	}
-	outDir = util.NewPath(sharedData.OutDir())
+	outDir = util.NewPath(ctx.OutDir())
 
-	// We only delete main directory if forced to. Otherwise, we
-	// will simply replace our files within it.
-	if sharedData.Force() {
-		log.Printf("\tRemoving directory: %s...\n", outDir.String())
-		if err = outDir.RemoveDir(); err != nil {
-			return fmt.Errorf("Error: Could not remove output directory: %s: %s\n",
-				outDir.String(), err.Error())
-		}
-	}
+	// We do not delete the main directory since it may have hand-written
+	// code. Files which are no longer generated are removed using the
+	// manifest of the prior run (see genCmn.Manifest).
 
 	// Create the main directory if needed.
 	if !outDir.IsPathDir() {
@@ -548,32 +598,32 @@ func CreateOutputDirs(g *genCmn.GenData) error {
 
 	log.Printf("\tCreating general directories...\n")
 	// cmd is used for main.go
-	err = CreateOutputDir([]string{"cmd/${DbName}"}, dn, "")
+	err = createOutputDir(ctx, []string{"cmd/${DbName}"}, dn, "")
 	if err != nil {
 		return err
 	}
 	// pkg is used for application packages.
-	err = CreateOutputDir([]string{"pkg"}, dn, "")
+	err = createOutputDir(ctx, []string{"pkg"}, dn, "")
 	if err != nil {
 		return err
 	}
 	// Static is used for CSS, HTML, JPG and any other static data
-	err = CreateOutputDir([]string{"scripts", "ci"}, dn, "")
+	err = createOutputDir(ctx, []string{"scripts", "ci"}, dn, "")
 	if err != nil {
 		return err
 	}
-	err = CreateOutputDir([]string{"static"}, dn, "")
+	err = createOutputDir(ctx, []string{"static"}, dn, "")
 	if err != nil {
 		return err
 	}
 	// tmpl is used for web page templates normally named *.gohtml.
-	err = CreateOutputDir([]string{"tmpl"}, dn, "")
+	err = createOutputDir(ctx, []string{"tmpl"}, dn, "")
 	if err != nil {
 		return err
 	}
 	// vendor is used for application dependencies which should
 	// probably not be commited in git.
-	err = CreateOutputDir([]string{"vendor"}, dn, "")
+	err = createOutputDir(ctx, []string{"vendor"}, dn, "")
 	if err != nil {
 		return err
 	}
@@ -590,11 +640,15 @@ func CreateOutputDirs(g *genCmn.GenData) error {
 // name (tn). The dn and tn are only used if "$(DbName}" or "${TblName}"
 // are found in the file name.
 func CreateOutputPath(dir []string, dn, tn, fn string) (*util.Path, error) {
+	return createOutputPath(sharedData.Default, dir, dn, tn, fn)
+}
+
+func createOutputPath(ctx *sharedData.Context, dir []string, dn, tn, fn string) (*util.Path, error) {
 	var err error
 	var outPath string
 	var path *util.Path
 
-	outPath = sharedData.OutDir()
+	outPath = ctx.OutDir()
 	outPath += string(os.PathSeparator)
 	for _, d := range dir {
 		outPath += d
@@ -623,7 +677,7 @@ func CreateOutputPath(dir []string, dn, tn, fn string) (*util.Path, error) {
 	}
 
 	if path.IsPathRegularFile() {
-		if !sharedData.Replace() {
+		if !ctx.Replace() {
 			err = fmt.Errorf("Over-write error of %s!\n", outPath)
 		}
 	}
@@ -641,39 +695,46 @@ func SetupFile(g *genCmn.GenData, fd genCmn.FileDefn, wrk *util.WorkQueue) error
 	var err error
 	var pathIn *util.Path
 
+	ctx := g.Context()
+	db := database(g)
+
 	// Create the input model file path.
 	pathIn, err = g.CreateModelPath(fd.ModelName)
 	if err != nil {
-		return fmt.Errorf("Error: %s: %s\n", pathIn.String(), err.Error())
+		return err
 	}
-	if sharedData.Debug() {
+	if ctx.Debug() {
 		log.Println("\t\tmodelPath=", pathIn.String())
 	}
 
 	// Now set up to generate the file.
 	switch fd.PerGrp {
 	case 0: // Standard File
-		data := &genCmn.TaskData{FD: &fd, TD: &g.TmplData, PathIn: pathIn, Data: dbJson.DbStruct()}
+		data := &genCmn.TaskData{FD: &fd, TD: &g.TmplData, PathIn: pathIn, Data: db}
 		// Create the output path
-		data.PathOut, err = CreateOutputPath(fd.FileDir, dbJson.DbStruct().Name, "", fd.FileName)
+		data.PathOut, err = createOutputPath(ctx, fd.FileDir, db.Name, "", fd.FileName)
 		if err != nil {
-			log.Fatalln(err)
+			return err
 		}
-		if sharedData.Debug() {
+		if ctx.Debug() {
 			log.Println("\t\t outPath=", data.PathOut)
 		}
 		// Generate the file.
 		wrk.PushWork(data)
 	case 2: // Output File is Titled Table Name in Titled Database
 		// Name directory
-		dbJson.DbStruct().ForTables(
+		db.ForTables(
 			func(v *dbJson.DbTable) {
+				var err2 error
 				data := &genCmn.TaskData{FD: &fd, TD: &g.TmplData, Table: v, PathIn: pathIn.Copy()}
-				data.PathOut, err = CreateOutputPath(fd.FileDir, dbJson.DbStruct().Name, v.Name, fd.FileName)
-				if err != nil {
-					log.Fatalln(err)
+				data.PathOut, err2 = createOutputPath(ctx, fd.FileDir, db.Name, v.Name, fd.FileName)
+				if err2 != nil {
+					if err == nil {
+						err = err2
+					}
+					return
 				}
-				if sharedData.Debug() {
+				if ctx.Debug() {
 					log.Println("\t\t outPath=", data.PathOut)
 				}
 				// Generate the file.
@@ -683,7 +744,7 @@ func SetupFile(g *genCmn.GenData, fd genCmn.FileDefn, wrk *util.WorkQueue) error
 		log.Printf("Skipped %s because of type!\n", fd.FileName)
 	}
 
-	return nil
+	return err
 }
 
 //----------------------------------------------------------------------------
@@ -696,12 +757,12 @@ func ReadJsonFileData(g *genCmn.GenData) error {
 	var err error
 	var db *dbJson.Database
 
-	db = dbJson.DbStruct()
-	if err = db.ReadJsonFile(sharedData.DataPath()); err != nil {
+	ctx := g.Context()
+	db = database(g)
+	if err = db.ReadJsonFile(ctx.DataPath()); err != nil {
 		return fmt.Errorf("Error: Reading Data Json Input: %s - %s\n",
-			sharedData.DataPath(), err)
+			ctx.DataPath(), err)
 	}
-	g.TmplData.Data = db
 
 	if err = db.SetupPlugin(); err != nil {
 		return err
@@ -709,120 +770,270 @@ func ReadJsonFileData(g *genCmn.GenData) error {
 	if err = db.ValidatePlugin(); err != nil {
 		return err
 	}
+	if err = db.ValidateAuth(ctx.GenAuth()); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// database returns the Database being generated which is set up by
+// GenerateWith().
+func database(g *genCmn.GenData) *dbJson.Database {
+	if db, ok := g.TmplData.Data.(*dbJson.Database); ok {
+		return db
+	}
+	return dbJson.DbStruct()
+}
+
 // SetupPlugin finds the plugin needed and sets it up within the database.
 func SetupPlugin() error {
+	return dbJson.DbStruct().SetupPlugin()
+}
+
+// validatePlugin checks the JSON built structures for errors with
+// respect to the plugin. This assumes that the data was previously
+// validated.
+func validatePlugin() error {
+	return dbJson.DbStruct().ValidatePlugin()
+}
+
+//============================================================================
+//								GenSqlApp
+//============================================================================
+
+// Validate analyzes the data JSON file and reports all of the problems
+// found in it without generating anything. An error is returned if any
+// errors (not warnings) were found.
+func Validate(inDefns map[string]interface{}) error {
+	return ValidateWith(sharedData.Default)
+}
+
+// ValidateWith validates the data JSON file given by the Context.
+func ValidateWith(ctx *sharedData.Context) error {
 	var err error
-	var intr dbPlugin.SchemaNamer
-	var ok bool
-	var plg dbPlugin.PluginData
-	var db *dbJson.Database
+	var p dbJson.Problems
 
-	// Indicate the plugin needed.
-	db = dbJson.DbStruct()
-	if sharedData.Debug() {
-		log.Printf("\t\tSqtype: %s\n", db.SqlType)
+	if p, err = dbJson.AnalyzeJsonFile(ctx.DataPath()); err != nil {
+		return err
 	}
-
-	// Find the plugin for this database.
-	if plg, err = dbPlugin.FindPlugin(db.SqlType); err != nil {
-		return fmt.Errorf("Error: Can't find plugin for %s!\n\n\n", db.SqlType)
+	for _, v := range p {
+		fmt.Println(v.String())
 	}
-	if sharedData.Debug() {
-		log.Printf("\t\tPlugin Type: %T\n", plg)
-		log.Printf("\t\tPlugin: %+v\n", plg)
-		log.Printf("\t\tPlugin.Plugin: %+v\n", plg.Plugin)
+	if cnt := p.ErrorCount(); cnt > 0 {
+		return fmt.Errorf("Error: %s has %d error(s)!\n", ctx.DataPath(), cnt)
 	}
-
-	// Validate the Plugin if possible.
-	if plg.Types == nil {
-		return fmt.Errorf("Error: Plugin missing types for %s!\n\n\n", db.SqlType)
+	if !ctx.Quiet() {
+		log.Printf("\t%s is valid with %d warning(s).\n", ctx.DataPath(), len(p))
 	}
 
-	// Save the plugin.
-	db.Plugin = plg
+	return nil
+}
 
-	if len(db.Schema) == 0 {
-		intr, ok = plg.Plugin.(dbPlugin.SchemaNamer)
-		if ok {
-			db.Schema = intr.SchemaName()
+// migrationFile matches the names of the migration files.
+var migrationFile = regexp.MustCompile(`^(\d+)_[A-Za-z0-9_]+\.(up|down)\.sql$`)
+
+// nextMigration returns the number of the next migration in the given
+// directory.
+func nextMigration(dir string) int {
+	num := 1
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return num
+	}
+	for _, f := range files {
+		m := migrationFile.FindStringSubmatch(f.Name())
+		if m == nil {
+			continue
+		}
+		if n, _ := strconv.Atoi(m[1]); n >= num {
+			num = n + 1
 		}
 	}
 
-	// Set up the Table Fields so that point to the Plugin Field Type definition.
-	for _, t := range db.Tables {
-		for ii, _ := range t.Fields {
-			t.Fields[ii].Typ = plg.Types.FindDefn(t.Fields[ii].TypeDefn)
-			if t.Fields[ii].Typ == nil {
-				return fmt.Errorf("Error: Invalid Field Type for %s:%s!\n\n\n", t.Name, t.Fields[ii].Name)
-			}
-		}
+	return num
+}
+
+// writeMigration writes one migration file.
+func writeMigration(ctx *sharedData.Context, fn, from, to string, num int, chgs dbJson.DbChanges) error {
+	var str strings.Builder
+
+	fmt.Fprintf(&str, "-- Migration %04d\n", num)
+	fmt.Fprintf(&str, "-- From: %s\n", from)
+	fmt.Fprintf(&str, "-- To:   %s\n", to)
+	fmt.Fprintf(&str, "-- Generated: %s\n\n", ctx.Time())
+	str.WriteString(dbGener.GenMigration(chgs))
+
+	if !ctx.Quiet() {
+		log.Printf("\tGenerating - %s\n", fn)
+	}
+	if ctx.Noop() {
+		return nil
 	}
+	if err := ioutil.WriteFile(fn, []byte(str.String()), 0644); err != nil {
+		return fmt.Errorf("Error: Writing %s - %s\n", fn, err)
+	}
+
 	return nil
 }
 
-// ValidatePlugin checks the JSON built structures for errors with
-// respect to the plugin. This assumes that the data was previously
-// validated.
-func validatePlugin() error {
+// Migrate compares the data JSON file given by "From" with the one given
+// by "To" (the data JSON file by default) and writes the numbered up and
+// down migration files for the differences to the migrations directory
+// of the output directory. An error is returned if any errors (not
+// warnings) were found in the differences.
+func Migrate(inDefns map[string]interface{}) error {
+	return MigrateWith(sharedData.Default)
+}
+
+// MigrateWith writes the migration files for the definitions of the
+// Context.
+func MigrateWith(ctx *sharedData.Context) error {
 	var err error
-	var plg dbPlugin.PluginData
+	var from, to *dbJson.Database
+	var fromPath, toPath string
 
-	// Set up Plugin Support for this database type.
-	if plg, err = dbPlugin.FindPlugin(dbJson.DbStruct().SqlType); err != nil {
+	if x, ok := ctx.Defn("From").(string); ok {
+		fromPath = x
+	}
+	if len(fromPath) == 0 {
+		return fmt.Errorf("Error: migrate requires -from!\n")
+	}
+	toPath = ctx.DataPath()
+	if x, ok := ctx.Defn("To").(string); ok && len(x) > 0 {
+		toPath = x
+	}
+
+	if from, err = dbJson.ReadMigrationJsonFile(fromPath); err != nil {
+		return err
+	}
+	if to, err = dbJson.ReadMigrationJsonFile(toPath); err != nil {
 		return err
 	}
 
-	for _, t := range dbJson.DbStruct().Tables {
-		for _, f := range t.Fields {
-			td := plg.Types.FindDefn(f.TypeDefn)
-			if td == nil {
-				fmt.Errorf("Error - Could not find Type definition for field: %s  type: %s\n",
-					f.Name, f.TypeDefn)
-			}
+	chgs, p := dbJson.Diff(from, to)
+	for _, v := range p {
+		fmt.Println(v.String())
+	}
+	if cnt := p.ErrorCount(); cnt > 0 {
+		return fmt.Errorf("Error: %s to %s has %d error(s)!\n", fromPath, toPath, cnt)
+	}
+	if len(chgs) == 0 {
+		if !ctx.Quiet() {
+			log.Printf("\t%s and %s have no differences.\n", fromPath, toPath)
+		}
+		return nil
+	}
+
+	dir := filepath.Join(ctx.OutDir(), "migrations")
+	if !ctx.Noop() {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("Error: Creating %s - %s\n", dir, err)
 		}
 	}
+	num := nextMigration(dir)
+	slug := "update_" + strings.ToLower(to.Name)
+	if len(chgs) == 1 {
+		slug = chgs[0].Slug()
+	}
+	fn := filepath.Join(dir, fmt.Sprintf("%04d_%s", num, slug))
+
+	if err = writeMigration(ctx, fn+".up.sql", fromPath, toPath, num, chgs); err != nil {
+		return err
+	}
+	if err = writeMigration(ctx, fn+".down.sql", toPath, fromPath, num, chgs.Down()); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-//============================================================================
-//								GenSqlApp
-//============================================================================
+// Introspect reads the definitions of the existing database given by
+// "Dsn" and writes them as a data JSON file to the data path or to
+// db.json.txt in the output directory if no data path was given. The
+// database is named after the file name of the Dsn.
+func Introspect(inDefns map[string]interface{}) error {
+	return IntrospectWith(sharedData.Default)
+}
 
+// IntrospectWith writes the data JSON file of the database given by the
+// definitions of the Context.
+func IntrospectWith(ctx *sharedData.Context) error {
+	var dsn, sqlType, outPath string
+
+	if x, ok := ctx.Defn("Dsn").(string); ok {
+		dsn = x
+	}
+	if len(dsn) == 0 {
+		return fmt.Errorf("Error: introspect requires -dsn!\n")
+	}
+	sqlType = "sqlite"
+	if x, ok := ctx.Defn("SqlType").(string); ok && len(x) > 0 {
+		sqlType = x
+	}
+	outPath = ctx.DataPath()
+	if len(outPath) == 0 {
+		outPath = filepath.Join(ctx.OutDir(), dbJson.DefaultJsonFileName())
+	}
+
+	name := filepath.Base(dsn)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	db, p, err := dbJson.Introspect(sqlType, name, dsn)
+	for _, v := range p {
+		fmt.Println(v.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Quiet() {
+		log.Printf("\tGenerating - %s\n", outPath)
+	}
+	if ctx.Noop() {
+		return nil
+	}
+	if _, err = os.Stat(outPath); err == nil && !ctx.Replace() {
+		return fmt.Errorf("Error: %s already exists!\n", outPath)
+	}
+	if err = os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return fmt.Errorf("Error: Creating %s - %s\n", filepath.Dir(outPath), err)
+	}
+
+	return db.WriteJsonFile(outPath)
+}
+
+// Generate generates the application using the default Context.
 func Generate(inDefns map[string]interface{}) error {
+	return GenerateWith(sharedData.Default)
+}
+
+// GenerateWith generates the application defined by the Context. Each
+// call has its own data so more than one application can be generated
+// at the same time given separate Contexts.
+func GenerateWith(ctx *sharedData.Context) error {
 	var genData genCmn.GenData
 
+	db := &dbJson.Database{Ctx: ctx}
+
 	// Set up genData.
+	genData.Ctx = ctx
 	genData.Name = "sqlapp"
 	genData.FileDefs1 = &FileDefs1
 	genData.FileDefs2 = &FileDefs2
 	genData.CreateOutputDirs = CreateOutputDirs
 	genData.ReadJsonData = ReadJsonFileData
 	genData.SetupFile = SetupFile
-	genData.TmplData.Data = dbJson.DbStruct()
+	genData.TmplData.Data = db
 
-	if sharedData.Debug() {
+	if ctx.Debug() {
 		log.Println("\t sqlapp: In Debug Mode")
 		log.Printf("\t  args: %q\n", flag.Args())
-		log.Printf("\tmdldir: %s\n", sharedData.MdlDir())
+		log.Printf("\tmdldir: %s\n", ctx.MdlDir())
 	}
 
-	genData.GenOutput()
-
-	if dbJson.DbStruct().SqlType == "sqlite" && dbJson.DbStruct().HasDec() {
-		log.Printf("========================== WARNING ==========================\n")
-		log.Printf("SQLite does not directly support DEC, DECIMAL or MONEY! It\n")
-		log.Printf("internally converts decimal types to float. So, rounding\n")
-		log.Printf("errors can creep in! So, we define decimal types as string\n")
-		log.Printf("in Golang and recommend that you not do any calculations\n")
-		log.Printf("in SQL, but rather pull the rows out, convert the data\n")
-		log.Printf("to any of the IEEE 754R libraries and do your calculations\n")
-		log.Printf("there.\n")
-		log.Printf("=============================================================\n")
+	if err := genData.GenOutput(); err != nil {
+		return err
 	}
 
 	return nil
@@ -6,8 +6,13 @@
 package genSqlAppGo
 
 import (
+	"genapp/pkg/genCmn"
 	"genapp/pkg/sharedData"
 	"github.com/2kranki/go_util"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 )
@@ -65,3 +70,73 @@ func TestCreateOutputPath(t *testing.T) {
 	t.Log("...End of TestCreateOutputPath")
 }
 
+// genTree generates the application of the data json file into the
+// directory with its own Context returning the files generated.
+func genTree(t *testing.T, data, dir string) map[string]string {
+
+	ctx := sharedData.New()
+	ctx.SetDataPath(data)
+	ctx.SetMainPath(filepath.Join(filepath.Dir(data), "main.json.txt"))
+	ctx.SetMdlDir("")
+	ctx.SetOutDir(dir)
+	ctx.SetQuiet(true)
+	ctx.SetTime("Mon Jan  1, 2001 00:00")
+	if err := GenerateWith(ctx); err != nil {
+		t.Errorf("GenerateWith(%s) failed: %s\n", data, err)
+		return nil
+	}
+
+	files := map[string]string{}
+	filepath.Walk(dir,
+		func(fn string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() {
+				return err
+			}
+			b, err := ioutil.ReadFile(fn)
+			rel, _ := filepath.Rel(dir, fn)
+			files[rel] = string(b)
+			return err
+		})
+
+	return files
+}
+
+func TestGenerateWith(t *testing.T) {
+	var wg sync.WaitGroup
+
+	t.Log("TestGenerateWith()")
+	tmp, err := ioutil.TempDir("", "genSqlAppGo")
+	if err != nil {
+		t.Fatalf("TempDir() failed: %s\n", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	// Applications generated at the same time must be the same as when
+	// they are generated by themselves.
+	apps := []string{"test01sq", "test01pg"}
+	got := make([]map[string]string, len(apps))
+	for i, app := range apps {
+		wg.Add(1)
+		go func(i int, app string) {
+			defer wg.Done()
+			got[i] = genTree(t, "../../misc/"+app+"/db.json.txt", filepath.Join(tmp, "par", app))
+		}(i, app)
+	}
+	wg.Wait()
+	for i, app := range apps {
+		want := genTree(t, "../../misc/"+app+"/db.json.txt", filepath.Join(tmp, "seq", app))
+		if len(want) == 0 || len(got[i]) != len(want) {
+			t.Errorf("GenerateWith(%s) generated %d files, but should be %d\n", app, len(got[i]), len(want))
+		}
+		for fn, text := range want {
+			if fn != genCmn.ManifestPath && got[i][fn] != text {
+				t.Errorf("GenerateWith(%s) %s differs when generated concurrently\n", app, fn)
+			}
+		}
+	}
+	if got[0]["go.mod"] == got[1]["go.mod"] {
+		t.Errorf("GenerateWith() generated the same go.mod for %v\n", apps)
+	}
+
+	t.Log("...End of TestGenerateWith")
+}
@@ -91,11 +91,11 @@ func genFlagVar(flg MainFlag) string {
 }
 
 // GenEnvSetup generate the O/S Environment declarations.
-func GenEnvSetup(appName string) string {
+func (m *MainData) GenEnvSetup(appName string) string {
 	var str util.StringBuilder
 	var intName string
 
-	for _, v := range mainStruct.Flags {
+	for _, v := range m.Flags {
 		if len(v.Internal) > 0 {
 			intName = v.Internal
 		} else {
@@ -111,18 +111,18 @@ func GenEnvSetup(appName string) string {
 
 // GenFlagSetup generates the flag.~Var definitions for the
 // CLI variables
-func GenFlagSetup() string {
+func (m *MainData) GenFlagSetup() string {
 	s := ""
-	for _, v := range mainStruct.Flags {
+	for _, v := range m.Flags {
 		s += genFlagVar(v)
 	}
 	return s
 }
 
 // GenVarDefns generate the CLI variable definitions
-func GenVarDefns() string {
+func (m *MainData) GenVarDefns() string {
 	s := ""
-	for _, v := range mainStruct.Flags {
+	for _, v := range m.Flags {
 		s += "\t"
 		if len(v.Internal) > 0 {
 			s += fmt.Sprintf("%s\t", v.Internal)
@@ -138,12 +138,38 @@ func GenVarDefns() string {
 	return s
 }
 
+// SetFuncs adds the functions needed for templating this Main JSON
+// file to the Context.
+func (m *MainData) SetFuncs(ctx *sharedData.Context) {
+	ctx.SetFunc("GenEnvSetup", m.GenEnvSetup)
+	ctx.SetFunc("GenFlagSetup", m.GenFlagSetup)
+	ctx.SetFunc("GenVarDefns", m.GenVarDefns)
+}
+
+// GenEnvSetup generate the O/S Environment declarations for the
+// Main JSON file read by ReadJsonFileMain.
+func GenEnvSetup(appName string) string {
+	return mainStruct.GenEnvSetup(appName)
+}
+
+// GenFlagSetup generates the flag.~Var definitions for the Main JSON
+// file read by ReadJsonFileMain.
+func GenFlagSetup() string {
+	return mainStruct.GenFlagSetup()
+}
+
+// GenVarDefns generate the CLI variable definitions for the Main JSON
+// file read by ReadJsonFileMain.
+func GenVarDefns() string {
+	return mainStruct.GenVarDefns()
+}
+
 // init() adds the functions needed for templating to
 // shared data.
 func init() {
-	sharedData.SetFunc("GenEnvSetup", GenEnvSetup)
-	sharedData.SetFunc("GenFlagSetup", GenFlagSetup)
-	sharedData.SetFunc("GenVarDefns", GenVarDefns)
+	sharedData.RegisterFunc("GenEnvSetup", GenEnvSetup)
+	sharedData.RegisterFunc("GenFlagSetup", GenFlagSetup)
+	sharedData.RegisterFunc("GenVarDefns", GenVarDefns)
 }
 
 func MainJson() interface{} {
@@ -158,30 +184,46 @@ func MainStruct() *MainData {
 // and stores the generic JSON Table as well as the
 // decoded structs.
 func ReadJsonFileMain(fn string) error {
+	var err error
+	var m *MainData
+
+	if mainJson, m, err = ReadMain(fn, sharedData.Default); err != nil {
+		return err
+	}
+	mainStruct = *m
+
+	return nil
+}
+
+// ReadMain reads the input JSON file for main returning
+// the generic JSON Table as well as the decoded structs.
+func ReadMain(fn string, ctx *sharedData.Context) (interface{}, *MainData, error) {
 	var err error
 	var jsonPath string
+	var data interface{}
+	var m MainData
 
 	jsonPath, _ = filepath.Abs(fn)
-	if sharedData.Debug() {
+	if ctx.Debug() {
 		log.Println("json path:", jsonPath)
 	}
 
 	// Read in the json file generically
-	if mainJson, err = util.ReadJsonFile(jsonPath); err != nil {
-		return errors.New(fmt.Sprintln("Error: unmarshalling", jsonPath, ", JSON input file:", err))
+	if data, err = util.ReadJsonFile(jsonPath); err != nil {
+		return nil, nil, errors.New(fmt.Sprintln("Error: unmarshalling", jsonPath, ", JSON input file:", err))
 	}
 
 	// Read in the json file structurally
-	if err = util.ReadJsonFileToData(jsonPath, &mainStruct); err != nil {
-		return errors.New(fmt.Sprintln("Error: unmarshalling", jsonPath, ", JSON input file:", err))
+	if err = util.ReadJsonFileToData(jsonPath, &m); err != nil {
+		return nil, nil, errors.New(fmt.Sprintln("Error: unmarshalling", jsonPath, ", JSON input file:", err))
 	}
 
-	if sharedData.Debug() {
-		log.Println("\tJson Data:", mainJson)
-		log.Println("\tJson Struct:", mainStruct)
+	if ctx.Debug() {
+		log.Println("\tJson Data:", data)
+		log.Println("\tJson Struct:", m)
 	}
 
-	return nil
+	return data, &m, nil
 }
 
 func rowScan(mp map[string]interface{}) string {
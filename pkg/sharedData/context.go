@@ -0,0 +1,399 @@
+// See License.txt in main repository directory
+
+// Context contains the definitions of one generation such as the
+// command, the paths, the flags and the defines.
+
+// Notes:
+//	1.	Each generation is given its own Context so that more than one
+//		can be run at the same time in the same process. The package
+//		level functions in shared.go use the Default Context.
+//	2.	The template functions such as GenHttps and Time are bound to
+//		the Context that they are taken from by Funcs(). Functions
+//		registered with RegisterFunc() are shared by all Contexts.
+
+package sharedData
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Context struct {
+	mu         sync.RWMutex
+	cmd        string
+	dataPath   string
+	defns      map[string]interface{}
+	funcs      map[string]interface{}
+	mainPath   string
+	mdlDir     string
+	outDir     string
+	userMdlDir string
+}
+
+// Default is the Context used by the package level functions.
+var Default = New()
+
+// registered holds the template functions shared by all Contexts.
+var registered = struct {
+	sync.RWMutex
+	funcs map[string]interface{}
+}{funcs: map[string]interface{}{}}
+
+// New returns a Context with the default definitions.
+func New() *Context {
+	c := &Context{}
+	c.defns = map[string]interface{}{}
+	c.funcs = map[string]interface{}{}
+	c.mdlDir = "./models"
+	c.outDir = "./test"
+	c.defns["Debug"] = false
+	c.defns["Force"] = false
+	c.defns["GenAuth"] = "none"
+	c.defns["GenDebugging"] = false
+	c.defns["GenHttps"] = false
+	c.defns["GenLogging"] = false
+	c.defns["GenMuxWrapper"] = false
+	c.defns["Noop"] = false
+	c.defns["Quiet"] = false
+	c.defns["Replace"] = true
+	c.defns["Time"] = time.Now().Format("Mon Jan _2, 2006 15:04")
+	return c
+}
+
+// RegisterFunc adds a template function which is shared by all Contexts.
+func RegisterFunc(nm string, f interface{}) {
+	registered.Lock()
+	registered.funcs[nm] = f
+	registered.Unlock()
+}
+
+//----------------------------------------------------------------------------
+//								Definitions
+//----------------------------------------------------------------------------
+
+func (c *Context) bool(nm string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	b, _ := c.defns[nm].(bool)
+	return b
+}
+
+func (c *Context) setDefn(nm string, d interface{}) {
+	c.mu.Lock()
+	c.defns[nm] = d
+	c.mu.Unlock()
+}
+
+func (c *Context) Cmd() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cmd
+}
+
+func (c *Context) SetCmd(f string) {
+	c.mu.Lock()
+	c.cmd = f
+	c.mu.Unlock()
+}
+
+// DataPath is the path to the app json file.
+func (c *Context) DataPath() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dataPath
+}
+
+func (c *Context) SetDataPath(f string) {
+	c.mu.Lock()
+	c.dataPath = f
+	c.mu.Unlock()
+}
+
+func (c *Context) Debug() bool {
+	return c.bool("Debug")
+}
+
+func (c *Context) SetDebug(f bool) {
+	c.setDefn("Debug", f)
+}
+
+func (c *Context) Defn(nm string) interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	switch nm {
+	case "cmd":
+		return c.cmd
+	case "dataPath":
+		return c.dataPath
+	case "mainPath":
+		return c.mainPath
+	case "mdlDir":
+		return c.mdlDir
+	case "outDir":
+		return c.outDir
+	case "userMdlDir":
+		return c.userMdlDir
+	}
+	d, _ := c.defns[nm]
+	return d
+}
+
+func (c *Context) IsDefined(nm string) bool {
+	return c.Defn(nm) != nil
+}
+
+func (c *Context) SetDefn(nm string, d interface{}) {
+	var ok bool
+	var sw bool
+	var str string
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch nm {
+	case "cmd":
+		if str, ok = d.(string); ok {
+			c.cmd = str
+		}
+	case "dataPath":
+		if str, ok = d.(string); ok {
+			c.dataPath = str
+		}
+	case "Debug", "Force", "Noop", "Quiet", "Replace":
+		if sw, ok = d.(bool); ok {
+			c.defns[nm] = sw
+		}
+	case "mainPath":
+		if str, ok = d.(string); ok {
+			c.mainPath = str
+		}
+	case "mdlDir":
+		if str, ok = d.(string); ok {
+			c.mdlDir = str
+		}
+	case "outDir":
+		if str, ok = d.(string); ok {
+			c.outDir = str
+		}
+	case "Time":
+		if str, ok = d.(string); ok {
+			c.defns["Time"] = str
+		}
+	case "userMdlDir":
+		if str, ok = d.(string); ok {
+			c.userMdlDir = str
+		}
+	default:
+		c.defns[nm] = d
+	}
+}
+
+func (c *Context) Force() bool {
+	return c.bool("Force")
+}
+
+func (c *Context) SetForce(f bool) {
+	c.setDefn("Force", f)
+}
+
+// GenAuth is the authentication mode of the generated server which is
+// one of "none", "basic", "session" or "apikey".
+func (c *Context) GenAuth() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if str, ok := c.defns["GenAuth"].(string); ok && len(str) > 0 {
+		return str
+	}
+	return "none"
+}
+
+// GenAuthUsers returns true if the authentication mode needs the
+// users table.
+func (c *Context) GenAuthUsers() bool {
+	return c.GenAuth() == "basic" || c.GenAuth() == "session"
+}
+
+func (c *Context) GenDebugging() bool {
+	return c.bool("GenDebugging")
+}
+
+func (c *Context) GenHttps() bool {
+	return c.bool("GenHttps")
+}
+
+func (c *Context) GenLogging() bool {
+	return c.bool("GenLogging")
+}
+
+func (c *Context) GenMuxWrapper() bool {
+	return c.bool("GenMuxWrapper")
+}
+
+// MainPath is the path to the main json file.
+func (c *Context) MainPath() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mainPath
+}
+
+func (c *Context) SetMainPath(f string) {
+	c.mu.Lock()
+	c.mainPath = f
+	c.mu.Unlock()
+}
+
+func (c *Context) MdlDir() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mdlDir
+}
+
+func (c *Context) SetMdlDir(f string) {
+	c.mu.Lock()
+	c.mdlDir = f
+	c.mu.Unlock()
+}
+
+// MergeFrom merges the given map into the definitions optionally
+// replacing any that already exist.
+func (c *Context) MergeFrom(m map[string]interface{}, rep bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, v := range m {
+		if _, ok := c.defns[k]; rep || !ok {
+			c.defns[k] = v
+		}
+	}
+}
+
+// MergeTo merges the definitions into the given map optionally
+// replacing any in the given map.
+func (c *Context) MergeTo(m map[string]interface{}, rep bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for k, v := range c.defns {
+		if _, ok := m[k]; rep || !ok {
+			m[k] = v
+		}
+	}
+}
+
+func (c *Context) Noop() bool {
+	return c.bool("Noop")
+}
+
+func (c *Context) SetNoop(f bool) {
+	c.setDefn("Noop", f)
+}
+
+func (c *Context) OutDir() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.outDir
+}
+
+func (c *Context) SetOutDir(f string) {
+	c.mu.Lock()
+	c.outDir = f
+	c.mu.Unlock()
+}
+
+func (c *Context) Quiet() bool {
+	return c.bool("Quiet")
+}
+
+func (c *Context) SetQuiet(f bool) {
+	c.setDefn("Quiet", f)
+}
+
+func (c *Context) Replace() bool {
+	return c.bool("Replace")
+}
+
+func (c *Context) SetReplace(f bool) {
+	c.setDefn("Replace", f)
+}
+
+// String returns a stringified version of the definitions.
+func (c *Context) String() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	s := "{"
+	s += fmt.Sprintf("cmd:%q,", c.cmd)
+	s += fmt.Sprintf("dataPath:%q,", c.dataPath)
+	s += fmt.Sprintf("Debug:%v,", c.defns["Debug"])
+	s += fmt.Sprintf("Force:%v,", c.defns["Force"])
+	s += fmt.Sprintf("mainPath:%q,", c.mainPath)
+	s += fmt.Sprintf("mdlDir:%q,", c.mdlDir)
+	s += fmt.Sprintf("Noop:%v,", c.defns["Noop"])
+	s += fmt.Sprintf("outDir:%q,", c.outDir)
+	s += fmt.Sprintf("Quiet:%v,", c.defns["Quiet"])
+	s += fmt.Sprintf("Time:%q,", c.defns["Time"])
+	s += fmt.Sprintf("userMdlDir:%q,", c.userMdlDir)
+	s += "}"
+	return s
+}
+
+// Time is the time of the generation given in the generated files.
+func (c *Context) Time() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	str, _ := c.defns["Time"].(string)
+	return str
+}
+
+func (c *Context) SetTime(f string) {
+	c.setDefn("Time", f)
+}
+
+// UserMdlDir is the directory of the user's models which override the
+// built-in ones, but not the ones of MdlDir.
+func (c *Context) UserMdlDir() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.userMdlDir
+}
+
+func (c *Context) SetUserMdlDir(f string) {
+	c.mu.Lock()
+	c.userMdlDir = f
+	c.mu.Unlock()
+}
+
+//----------------------------------------------------------------------------
+//								Template Functions
+//----------------------------------------------------------------------------
+
+// Funcs returns the template functions of the Context which are the
+// registered ones, the definitions bound to this Context and any set
+// by SetFunc in that order of precedence.
+func (c *Context) Funcs() map[string]interface{} {
+	f := map[string]interface{}{}
+
+	registered.RLock()
+	for k, v := range registered.funcs {
+		f[k] = v
+	}
+	registered.RUnlock()
+	f["GenAuth"] = c.GenAuth
+	f["GenAuthUsers"] = c.GenAuthUsers
+	f["GenDebugging"] = c.GenDebugging
+	f["GenHttps"] = c.GenHttps
+	f["GenLogging"] = c.GenLogging
+	f["GenMuxWrapper"] = c.GenMuxWrapper
+	f["Time"] = c.Time
+	c.mu.RLock()
+	for k, v := range c.funcs {
+		f[k] = v
+	}
+	c.mu.RUnlock()
+
+	return f
+}
+
+// SetFunc adds a template function to this Context only.
+func (c *Context) SetFunc(nm string, d interface{}) {
+	c.mu.Lock()
+	c.funcs[nm] = d
+	c.mu.Unlock()
+}
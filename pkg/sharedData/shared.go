@@ -6,294 +6,188 @@
 // in sub-packages which have the need of reference and
 // sometimes manipulating or adding to that data.
 
-package sharedData
+// Notes:
+//	1.	These functions use the Default Context and are kept for
+//		compatibility. Code which may be run for more than one
+//		generation at a time should use its own Context (see
+//		context.go).
 
-import (
-	"fmt"
-	"time"
-)
-
-var cmd string
-var dataPath string
-var defns map[string]interface{}
-var funcs map[string]interface{}
-var mainPath string
-var mdlDir string
-var outDir string
-
-func init() {
-	defns = map[string]interface{}{}
-	funcs = map[string]interface{}{}
-	mdlDir = "./models"
-	outDir = "./test"
-	defns["Debug"] = false
-	defns["Force"] = false
-	defns["GenDebugging"] = false
-	funcs["GenDebugging"] = GenDebugging
-	defns["GenHttps"] = false
-	funcs["GenHttps"] = GenHttps
-	defns["GenLogging"] = false
-	funcs["GenLogging"] = GenLogging
-	defns["GenMuxWrapper"] = false
-	funcs["GenMuxWrapper"] = GenMuxWrapper
-	defns["Noop"] = false
-	defns["Quiet"] = false
-	defns["Replace"] = true
-	defns["Time"] = time.Now().Format("Mon Jan _2, 2006 15:04")
-	funcs["Time"] = Time
-}
+package sharedData
 
 func Cmd() string {
-	return cmd
+	return Default.Cmd()
 }
 
 func SetCmd(f string) {
-	cmd = f
+	Default.SetCmd(f)
 }
 
 // DataPath is the path to the app json file.
 func DataPath() string {
-	return dataPath
+	return Default.DataPath()
 }
 
 func SetDataPath(f string) {
-	dataPath = f
+	Default.SetDataPath(f)
 }
 
 func Debug() bool {
-	return defns["Debug"].(bool)
+	return Default.Debug()
 }
 
 func SetDebug(f bool) {
-	defns["Debug"] = f
+	Default.SetDebug(f)
 }
 
 func Defn(nm string) interface{} {
-	switch nm {
-	case "cmd":
-		return cmd
-	case "dataPath":
-		return dataPath
-	case "mainPath":
-		return mainPath
-	case "mdlDir":
-		return mdlDir
-	case "outDir":
-		return outDir
-	}
-	d, _ := defns[nm]
-	return d
+	return Default.Defn(nm)
 }
 
 func IsDefined(nm string) bool {
-	x := Defn(nm)
-	if x != nil {
-		return true
-	}
-	return false
+	return Default.IsDefined(nm)
 }
 
 func SetDefn(nm string, d interface{}) {
-	var ok bool
-	var sw bool
-	var str string
-
-	switch nm {
-	case "cmd":
-		if str, ok = d.(string); ok {
-			cmd = str
-		}
-	case "dataPath":
-		if str, ok = d.(string); ok {
-			dataPath = str
-		}
-	case "Debug":
-		if sw, ok = d.(bool); ok {
-			defns["Debug"] = sw
-		}
-	case "Force":
-		if sw, ok = d.(bool); ok {
-			defns["Force"] = sw
-		}
-	case "mainPath":
-		if str, ok = d.(string); ok {
-			mainPath = str
-		}
-	case "mdlDir":
-		if str, ok = d.(string); ok {
-			mdlDir = str
-		}
-	case "Noop":
-		if sw, ok = d.(bool); ok {
-			defns["Noop"] = sw
-		}
-	case "outDir":
-		if str, ok = d.(string); ok {
-			outDir = str
-		}
-	case "Quiet":
-		if sw, ok = d.(bool); ok {
-			defns["Quiet"] = sw
-		}
-	case "Replace":
-		if sw, ok = d.(bool); ok {
-			defns["Replace"] = sw
-		}
-	case "Time":
-		if str, ok = d.(string); ok {
-			defns["Time"] = str
-		}
-	default:
-		defns[nm] = d
-	}
+	Default.SetDefn(nm, d)
 }
 
 func Force() bool {
-	return defns["Force"].(bool)
+	return Default.Force()
 }
 
 func SetForce(f bool) {
-	defns["Force"] = f
+	Default.SetForce(f)
 }
 
 func Funcs() map[string]interface{} {
-	return funcs
+	return Default.Funcs()
 }
 
 func FuncsSlice() []interface{} {
 	var f = []interface{}{}
 
-	for _, v := range funcs {
+	for _, v := range Funcs() {
 		f = append(f, v)
 	}
 
 	return f
 }
 
+// SetFunc adds a template function which is shared by all Contexts.
 func SetFunc(nm string, d interface{}) {
-	funcs[nm] = d
+	RegisterFunc(nm, d)
+}
+
+// GenAuth is the authentication mode of the generated server which is
+// one of "none", "basic", "session" or "apikey".
+func GenAuth() string {
+	return Default.GenAuth()
+}
+
+// GenAuthUsers returns true if the authentication mode needs the
+// users table.
+func GenAuthUsers() bool {
+	return Default.GenAuthUsers()
 }
 
 func GenDebugging() bool {
-	return defns["GenDebugging"].(bool)
+	return Default.GenDebugging()
 }
 
 func GenHttps() bool {
-	return defns["GenHttps"].(bool)
+	return Default.GenHttps()
 }
 
 func GenLogging() bool {
-	return defns["GenLogging"].(bool)
+	return Default.GenLogging()
 }
 
 func GenMuxWrapper() bool {
-	return defns["GenMuxWrapper"].(bool)
+	return Default.GenMuxWrapper()
 }
 
 // MainPath is the path to the main json file.
 func MainPath() string {
-	return mainPath
+	return Default.MainPath()
 }
 
 func SetMainPath(f string) {
-	mainPath = f
+	Default.SetMainPath(f)
 }
 
 func MdlDir() string {
-	return mdlDir
+	return Default.MdlDir()
 }
 
 func SetMdlDir(f string) {
-	mdlDir = f
+	Default.SetMdlDir(f)
 }
 
 // MergeFrom merges the given map into the shared
 // data definitions optionally replacing any that
 // already exist.
 func MergeFrom(m map[string]interface{}, rep bool) {
-	var ok bool
-
-	for k, v := range m {
-		if rep {
-			defns[k] = v
-		} else {
-			if _, ok = defns[k]; !ok {
-				defns[k] = v
-			}
-		}
-	}
+	Default.MergeFrom(m, rep)
 }
 
 // MergeTo merges the shared into the given map
-// optionally replacing any in the given map.\
+// optionally replacing any in the given map.
 func MergeTo(m map[string]interface{}, rep bool) {
-	var ok bool
-
-	for k, v := range defns {
-		if rep {
-			m[k] = v
-		} else {
-			if _, ok = m[k]; !ok {
-				m[k] = v
-			}
-		}
-	}
+	Default.MergeTo(m, rep)
 }
 
 func Noop() bool {
-	return defns["Noop"].(bool)
+	return Default.Noop()
 }
 
 func SetNoop(f bool) {
-	defns["Noop"] = f
+	Default.SetNoop(f)
 }
 
 func OutDir() string {
-	return outDir
+	return Default.OutDir()
 }
 
 func SetOutDir(f string) {
-	outDir = f
+	Default.SetOutDir(f)
 }
 
 func Quiet() bool {
-	return defns["Quiet"].(bool)
+	return Default.Quiet()
 }
 
 func SetQuiet(f bool) {
-	defns["Quiet"] = f
+	Default.SetQuiet(f)
 }
 
 func Replace() bool {
-	return defns["Replace"].(bool)
+	return Default.Replace()
 }
 
 func SetReplace(f bool) {
-	defns["Replace"] = f
+	Default.SetReplace(f)
 }
 
 // String returns a stringified version of the shared data
 func String() string {
-	s := "{"
-	s += fmt.Sprintf("cmd:%q,", cmd)
-	s += fmt.Sprintf("dataPath:%q,", dataPath)
-	s += fmt.Sprintf("Debug:%v,", defns["Debug"])
-	s += fmt.Sprintf("Force:%v,", defns["Force"])
-	s += fmt.Sprintf("mainPath:%q,", mainPath)
-	s += fmt.Sprintf("mdlDir:%q,", mdlDir)
-	s += fmt.Sprintf("Noop:%v,", defns["Noop"])
-	s += fmt.Sprintf("outDir:%q,", outDir)
-	s += fmt.Sprintf("Quiet:%v,", defns["Quiet"])
-	s += fmt.Sprintf("Time:%q,", defns["Time"])
-	s += "}"
-	return s
+	return Default.String()
 }
 
-// MainPath is the path to the main json file.
+// Time is the time of the generation given in the generated files.
 func Time() string {
-	return defns["Time"].(string)
+	return Default.Time()
 }
 
 func SetTime(f string) {
-	defns["Time"] = f
+	Default.SetTime(f)
+}
+
+// UserMdlDir is the directory of the user's models which override the
+// built-in ones, but not the ones of MdlDir.
+func UserMdlDir() string {
+	return Default.UserMdlDir()
+}
+
+func SetUserMdlDir(f string) {
+	Default.SetUserMdlDir(f)
 }
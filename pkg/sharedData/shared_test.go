@@ -54,6 +54,21 @@ func TestForce(t *testing.T) {
 	}
 }
 
+func TestGenAuth(t *testing.T) {
+	if GenAuth() != "none" || GenAuthUsers() {
+		t.Errorf("TestGenAuth() failed: should be 'none' but is %s\n", GenAuth())
+	}
+	SetDefn("GenAuth", "session")
+	if GenAuth() != "session" || !GenAuthUsers() {
+		t.Errorf("TestGenAuth() failed: should be 'session' but is %s\n", GenAuth())
+	}
+	SetDefn("GenAuth", "apikey")
+	if GenAuth() != "apikey" || GenAuthUsers() {
+		t.Errorf("TestGenAuth() failed: should be 'apikey' but is %s\n", GenAuth())
+	}
+	SetDefn("GenAuth", "none")
+}
+
 func TestMainPath(t *testing.T) {
 	SetMainPath("xyzzy")
 	if MainPath() != "xyzzy" {
@@ -76,6 +91,18 @@ func TestMdlDir(t *testing.T) {
 	}
 }
 
+func TestUserMdlDir(t *testing.T) {
+	SetUserMdlDir("xyzzy")
+	if UserMdlDir() != "xyzzy" {
+		t.Errorf("TestUserMdlDir() failed: should be 'xyzzy' but is %s\n", UserMdlDir())
+	}
+	SetDefn("userMdlDir", "abc")
+	if Defn("userMdlDir") != "abc" {
+		t.Errorf("TestUserMdlDir() failed: should be 'abc' but is %s\n", Defn("userMdlDir"))
+	}
+	SetUserMdlDir("")
+}
+
 func TestNoop(t *testing.T) {
 	SetNoop(true)
 	if !Noop() {
@@ -108,3 +135,31 @@ func TestQuiet(t *testing.T) {
 		t.Errorf("TestQuiet() failed: should be false but is true\n")
 	}
 }
+
+func TestContext(t *testing.T) {
+	SetOutDir("abc")
+	ctx := New()
+	ctx.SetOutDir("xyzzy")
+	ctx.SetDefn("GenHttps", true)
+	if OutDir() != "abc" || ctx.OutDir() != "xyzzy" {
+		t.Errorf("TestContext() failed: OutDir should be 'abc' and 'xyzzy' but is %s and %s\n",
+			OutDir(), ctx.OutDir())
+	}
+	if GenHttps() || !ctx.GenHttps() {
+		t.Errorf("TestContext() failed: GenHttps() should only be true for the Context\n")
+	}
+
+	// The template functions are bound to their Context and ones set
+	// by the Context override the registered ones.
+	RegisterFunc("TestFunc", func() string { return "registered" })
+	ctx.SetFunc("TestFunc", func() string { return "context" })
+	if f, ok := ctx.Funcs()["GenHttps"].(func() bool); !ok || !f() {
+		t.Errorf("TestContext() failed: GenHttps should be bound to the Context\n")
+	}
+	if f, ok := ctx.Funcs()["TestFunc"].(func() string); !ok || f() != "context" {
+		t.Errorf("TestContext() failed: TestFunc should be from the Context\n")
+	}
+	if f, ok := Funcs()["TestFunc"].(func() string); !ok || f() != "registered" {
+		t.Errorf("TestContext() failed: TestFunc should be the registered one\n")
+	}
+}